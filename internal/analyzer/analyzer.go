@@ -7,128 +7,20 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/pgEdge/mm-ready-go/internal/catalog"
+	"github.com/pgEdge/mm-ready-go/internal/check"
 	"github.com/pgEdge/mm-ready-go/internal/models"
 	"github.com/pgEdge/mm-ready-go/internal/parser"
 )
 
-// CheckDef defines a static analysis check.
-type CheckDef struct {
-	// Name is the name value.
-	Name string
-	// Category is the check category.
-	Category string
-	// Description is a human-readable summary.
-	Description string
-	// Fn is the check function to execute.
-	Fn CheckFunc
-}
-
-// SkippedCheckDef defines a check that requires a live database connection.
-type SkippedCheckDef struct {
-	// Name is the name value.
-	Name string
-	// Category is the check category.
-	Category string
-	// Description is a human-readable summary.
-	Description string
-}
-
-// StaticChecks is the list of 19 checks that can run on parsed schema.
-var StaticChecks = []CheckDef{
-	{"primary_keys", "schema", "Tables without primary keys", checkPrimaryKeys},
-	{"sequence_pks", "schema", "Primary keys using standard sequences", checkSequencePKs},
-	{"foreign_keys", "schema", "Foreign key relationships", checkForeignKeys},
-	{"deferrable_constraints", "schema", "Deferrable unique/PK constraints", checkDeferrableConstraints},
-	{"exclusion_constraints", "schema", "Exclusion constraints", checkExclusionConstraints},
-	{"missing_fk_indexes", "schema", "Foreign key columns without indexes", checkMissingFKIndexes},
-	{"unlogged_tables", "schema", "UNLOGGED tables", checkUnloggedTables},
-	{"large_objects", "schema", "Large object (OID column) usage", checkLargeObjects},
-	{"column_defaults", "schema", "Volatile column defaults", checkColumnDefaults},
-	{"numeric_columns", "schema", "Numeric columns (Delta-Apply candidates)", checkNumericColumns},
-	{"multiple_unique_indexes", "schema", "Tables with multiple unique indexes", checkMultipleUniqueIndexes},
-	{"enum_types", "schema", "ENUM types", checkEnumTypes},
-	{"generated_columns", "schema", "Generated/stored columns", checkGeneratedColumns},
-	{"rules", "schema", "Rules on tables", checkRules},
-	{"inheritance", "schema", "Table inheritance (non-partition)", checkInheritance},
-	{"installed_extensions", "extensions", "Installed extensions audit", checkInstalledExtensions},
-	{"sequence_audit", "sequences", "Sequence inventory and ownership", checkSequenceAudit},
-	{"sequence_data_types", "sequences", "Sequence data types", checkSequenceDataTypes},
-	{"pg_version", "config", "PostgreSQL version compatibility with Spock 5", checkPgVersion},
-}
+// liveOnlyReason is the skip reason for checks that need a live database.
+const liveOnlyReason = "Requires live database connection"
 
-// SkippedChecks is the list of 37 checks that require a live database connection.
-var SkippedChecks = []SkippedCheckDef{
-	// Replication
-	{"wal_level", "replication", "WAL level check (wal_level = logical)"},
-	{"max_replication_slots", "replication", "Sufficient replication slots for Spock nodes"},
-	{"max_worker_processes", "replication", "Sufficient worker processes for Spock apply workers"},
-	{"max_wal_senders", "replication", "Sufficient WAL senders for replication connections"},
-	{"database_encoding", "replication", "Database encoding consistency across nodes"},
-	{"hba_config", "replication", "pg_hba.conf allows replication connections"},
-	{"stale_replication_slots", "replication", "Check for stale replication slots"},
-	{"multiple_databases", "replication", "Multiple databases in the cluster"},
-	{"repset_membership", "replication", "Spock replication set membership audit"},
-	{"subscription_health", "replication", "Spock subscription health check"},
-	{"conflict_log", "replication", "Spock conflict log review"},
-	{"exception_log", "replication", "Spock exception log review"},
-	// Config (except pg_version)
-	{"track_commit_timestamp", "config", "track_commit_timestamp GUC enabled"},
-	{"shared_preload_libraries", "config", "shared_preload_libraries includes spock"},
-	{"spock_gucs", "config", "Spock-specific GUC settings"},
-	{"idle_transaction_timeout", "config", "Idle transaction timeout settings"},
-	{"pg_minor_version", "config", "PostgreSQL minor version patch level"},
-	{"parallel_apply", "config", "Parallel apply worker settings"},
-	{"timezone_config", "config", "Timezone configuration consistency"},
-	// Extensions (except installed_extensions)
-	{"snowflake_check", "extensions", "pgEdge snowflake extension installation"},
-	{"pg_stat_statements_check", "extensions", "pg_stat_statements availability"},
-	{"lolor_check", "extensions", "LOLOR extension for large object replication"},
-	// SQL patterns
-	{"advisory_locks", "sql_patterns", "Advisory lock usage in queries"},
-	{"ddl_statements", "sql_patterns", "DDL statements in pg_stat_statements"},
-	{"truncate_cascade", "sql_patterns", "TRUNCATE CASCADE usage"},
-	{"concurrent_indexes", "sql_patterns", "Concurrent index operations"},
-	{"temp_table_queries", "sql_patterns", "Temporary table usage in queries"},
-	// Functions
-	{"stored_procedures", "functions", "Stored procedures and functions audit"},
-	{"trigger_functions", "functions", "Trigger functions audit"},
-	{"views_audit", "functions", "Views audit"},
-	// Schema (live-only)
-	{"tables_update_delete_no_pk", "schema", "UPDATE/DELETE on tables without PKs (requires pg_stat)"},
-	{"row_level_security", "schema", "Row-level security policies"},
-	{"partitioned_tables", "schema", "Partitioned table hierarchy"},
-	{"tablespace_usage", "schema", "Non-default tablespace usage"},
-	{"temp_tables", "schema", "Temporary table existence"},
-	{"event_triggers", "schema", "Event triggers"},
-	{"notify_listen", "schema", "NOTIFY/LISTEN channel usage"},
-}
-
-// RunAnalyze runs all static checks against a parsed schema dump.
+// RunAnalyze runs every structural check against a parsed schema dump. The
+// dump is converted to the same catalog model a live scan loads, and the
+// checks are the registered ones, so findings match those of a scan of the
+// same schema. Checks that need a live database are reported as skipped.
 func RunAnalyze(schema *parser.ParsedSchema, filePath string, categories []string, exclude []string, includeOnly []string, verbose bool) (*models.ScanReport, error) {
-	// Build category filter set
-	var catFilter map[string]bool
-	if len(categories) > 0 {
-		catFilter = make(map[string]bool)
-		for _, c := range categories {
-			catFilter[c] = true
-		}
-	}
-
-	var exclSet map[string]bool
-	if len(exclude) > 0 {
-		exclSet = make(map[string]bool)
-		for _, e := range exclude {
-			exclSet[e] = true
-		}
-	}
-	var inclSet map[string]bool
-	if len(includeOnly) > 0 {
-		inclSet = make(map[string]bool)
-		for _, i := range includeOnly {
-			inclSet[i] = true
-		}
-	}
-
 	// Determine database name from file path
 	dbName := filepath.Base(filePath)
 	ext := filepath.Ext(dbName)
@@ -150,72 +42,45 @@ func RunAnalyze(schema *parser.ParsedSchema, filePath string, categories []strin
 		report.PGVersion = "unknown"
 	}
 
-	// Filter checks by category, exclude, and include-only
-	var checksToRun []CheckDef
-	for _, chk := range StaticChecks {
-		if catFilter != nil && !catFilter[chk.Category] {
-			continue
-		}
-		if len(inclSet) > 0 && !inclSet[chk.Name] {
-			continue
-		}
-		if exclSet != nil && exclSet[chk.Name] && !inclSet[chk.Name] {
-			continue
+	cat := catalog.FromParsed(schema)
+	checks := check.GetChecks("", categories, exclude, includeOnly)
+
+	total := 0
+	for _, c := range checks {
+		if _, ok := c.(check.Structural); ok {
+			total++
 		}
-		checksToRun = append(checksToRun, chk)
 	}
-
-	total := len(checksToRun)
 	if verbose {
 		fmt.Fprintf(os.Stderr, "Analyze: running %d static checks against %s...\n", total, filePath)
 	}
 
-	// Run each check
-	for i, check := range checksToRun {
-		if verbose {
-			fmt.Fprintf(os.Stderr, "  [%d/%d] %s/%s: %s\n", i+1, total, check.Category, check.Name, check.Description)
-		}
-
-		result := models.CheckResult{
-			CheckName:   check.Name,
-			Category:    check.Category,
-			Description: check.Description,
+	done := 0
+	for _, c := range checks {
+		s, ok := c.(check.Structural)
+		if !ok {
+			report.Results = append(report.Results, models.CheckResult{
+				CheckName:   c.Name(),
+				Category:    c.Category(),
+				Description: c.Description(),
+				Skipped:     true,
+				SkipReason:  liveOnlyReason,
+			})
+			continue
 		}
 
-		// Run the check function with panic recovery
-		func() {
-			defer func() {
-				if r := recover(); r != nil {
-					result.Error = fmt.Sprintf("panic: %v", r)
-					if verbose {
-						fmt.Fprintf(os.Stderr, "    ERROR: %s\n", result.Error)
-					}
-				}
-			}()
-			result.Findings = check.Fn(schema, check.Name, check.Category)
-		}()
-
-		report.Results = append(report.Results, result)
-	}
-
-	// Add skipped checks
-	for _, skip := range SkippedChecks {
-		if catFilter != nil && !catFilter[skip.Category] {
-			continue
+		done++
+		if verbose {
+			fmt.Fprintf(os.Stderr, "  [%d/%d] %s/%s: %s\n", done, total, c.Category(), c.Name(), c.Description())
 		}
-		if len(inclSet) > 0 && !inclSet[skip.Name] {
-			continue
+		result := inspect(s, cat)
+		for i := range result.Findings {
+			result.Findings[i].Line = schema.ObjectLine(result.Findings[i].ObjectName)
 		}
-		if exclSet != nil && exclSet[skip.Name] && !inclSet[skip.Name] {
-			continue
+		if verbose && result.Error != "" {
+			fmt.Fprintf(os.Stderr, "    ERROR: %s\n", result.Error)
 		}
-		report.Results = append(report.Results, models.CheckResult{
-			CheckName:   skip.Name,
-			Category:    skip.Category,
-			Description: skip.Description,
-			Skipped:     true,
-			SkipReason:  "Requires live database connection",
-		})
+		report.Results = append(report.Results, result)
 	}
 
 	if verbose {
@@ -225,3 +90,24 @@ func RunAnalyze(schema *parser.ParsedSchema, filePath string, categories []strin
 
 	return report, nil
 }
+
+// inspect runs a structural check against cat, recovering from a panic so
+// that one bad check cannot stop the analysis.
+func inspect(s check.Structural, cat *catalog.Catalog) (result models.CheckResult) {
+	result = models.CheckResult{
+		CheckName:   s.Name(),
+		Category:    s.Category(),
+		Description: s.Description(),
+		Effort:      check.EffortOf(s),
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			result.Findings = nil
+			result.Error = fmt.Sprintf("panic: %v", r)
+			result.ErrorKind = models.ErrorKindPanic
+		}
+	}()
+	result.Findings = s.Inspect(cat)
+	result.SetFingerprints()
+	return result
+}
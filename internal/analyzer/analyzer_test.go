@@ -0,0 +1,174 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pgEdge/mm-ready-go/internal/check"
+	_ "github.com/pgEdge/mm-ready-go/internal/checks" // triggers all init() registrations
+	"github.com/pgEdge/mm-ready-go/internal/models"
+	"github.com/pgEdge/mm-ready-go/internal/parser"
+)
+
+func analyzeTestdata(t *testing.T) *models.ScanReport {
+	t.Helper()
+	schema, err := parser.ParseDump("testdata/schema.sql")
+	if err != nil {
+		t.Fatalf("ParseDump: %v", err)
+	}
+	report, err := RunAnalyze(schema, "testdata/schema.sql", nil, nil, nil, false)
+	if err != nil {
+		t.Fatalf("RunAnalyze: %v", err)
+	}
+	return report
+}
+
+func TestRunAnalyzeCoversRegistry(t *testing.T) {
+	report := analyzeTestdata(t)
+
+	all := check.AllRegistered()
+	if len(report.Results) != len(all) {
+		t.Fatalf("got %d results, want one per registered check (%d)", len(report.Results), len(all))
+	}
+	ran := 0
+	for _, r := range report.Results {
+		if r.Error != "" {
+			t.Errorf("%s: unexpected error %s", r.CheckName, r.Error)
+		}
+		if !r.Skipped {
+			ran++
+			continue
+		}
+		if r.SkipReason != liveOnlyReason {
+			t.Errorf("%s: skip reason %q", r.CheckName, r.SkipReason)
+		}
+	}
+	if ran != 20 {
+		t.Errorf("ran %d structural checks, want 20", ran)
+	}
+}
+
+func TestRunAnalyzeFilters(t *testing.T) {
+	schema, err := parser.ParseDump("testdata/schema.sql")
+	if err != nil {
+		t.Fatalf("ParseDump: %v", err)
+	}
+	report, err := RunAnalyze(schema, "schema.sql", []string{"sequences"}, nil, nil, false)
+	if err != nil {
+		t.Fatalf("RunAnalyze: %v", err)
+	}
+	for _, r := range report.Results {
+		if r.Category != "sequences" {
+			t.Errorf("category filter let %s/%s through", r.Category, r.CheckName)
+		}
+	}
+
+	report, err = RunAnalyze(schema, "schema.sql", nil, nil, []string{"primary_keys"}, false)
+	if err != nil {
+		t.Fatalf("RunAnalyze: %v", err)
+	}
+	if len(report.Results) != 1 || report.Results[0].CheckName != "primary_keys" {
+		t.Errorf("include-only gave %d results", len(report.Results))
+	}
+}
+
+func TestRunAnalyzeFindings(t *testing.T) {
+	report := analyzeTestdata(t)
+
+	titles := make(map[string]models.Severity)
+	for _, r := range report.Results {
+		for _, f := range r.Findings {
+			titles[r.CheckName+": "+f.Title] = f.Severity
+			if f.Fingerprint == "" {
+				t.Errorf("%s: finding %q has no fingerprint", r.CheckName, f.Title)
+			}
+		}
+	}
+
+	want := map[string]models.Severity{
+		"primary_keys: Table 'public.events' has no primary key":                                           models.SeverityWarning,
+		"primary_keys: Table 'public.archived_orders' has no primary key":                                  models.SeverityWarning,
+		"sequence_pks: PK column 'public.orders.id' uses a standard sequence":                              models.SeverityCritical,
+		"sequence_pks: PK column 'public.customers.id' uses a standard sequence":                           models.SeverityCritical,
+		"missing_fk_indexes: No index on FK columns 'public.orders' (customer_id)":                         models.SeverityWarning,
+		"multiple_unique_indexes: Table 'public.customers' has 2 unique indexes":                           models.SeverityConsider,
+		"deferrable_constraints: Deferrable UNIQUE 'customers_email_key' on 'public.customers'":            models.SeverityWarning,
+		"inheritance: Table inheritance: 'public.archived_orders' inherits from 'public.orders'":           models.SeverityWarning,
+		"numeric_columns: Delta-Apply candidate 'public.archived_orders.total' allows NULL":                models.SeverityWarning,
+		"rules: INSTEAD Rule 'events_no_delete' on 'public.events' (DELETE)":                               models.SeverityWarning,
+		"sequence_audit: Sequence 'public.orders_id_seq' (integer, owned by public.orders.id)":             models.SeverityWarning,
+		"sequence_data_types: Sequence 'public.orders_id_seq' uses integer (max 2147483647)":               models.SeverityWarning,
+		"pg_version: PostgreSQL 17 is supported by Spock 5":                                                models.SeverityInfo,
+		"replica_identity: Table 'public.audit_log' relies on REPLICA IDENTITY FULL without a primary key": models.SeverityWarning,
+		"replica_identity: Table 'public.customers' has REPLICA IDENTITY FULL and a primary key":           models.SeverityConsider,
+	}
+	for title, sev := range want {
+		got, ok := titles[title]
+		if !ok {
+			t.Errorf("missing finding %q", title)
+			continue
+		}
+		if got != sev {
+			t.Errorf("%q: severity %s, want %s", title, got, sev)
+		}
+	}
+}
+
+func TestRunAnalyzeLines(t *testing.T) {
+	report := analyzeTestdata(t)
+
+	lines := make(map[string]int)
+	for _, r := range report.Results {
+		for _, f := range r.Findings {
+			lines[r.CheckName+" "+f.ObjectName] = f.Line
+		}
+	}
+	want := map[string]int{
+		"primary_keys public.events":               53,
+		"enum_types public.order_status":           13,
+		"rules public.events.events_no_delete":     76,
+		"numeric_columns public.customers.balance": 19,
+		"sequence_data_types public.orders_id_seq": 44,
+		"pg_version pg_version":                    0,
+	}
+	for key, line := range want {
+		if got, ok := lines[key]; !ok || got != line {
+			t.Errorf("%s: line %d, want %d", key, got, line)
+		}
+	}
+}
+
+func TestRunAnalyzePrimaryKeyCandidates(t *testing.T) {
+	report := analyzeTestdata(t)
+
+	findings := make(map[string]models.Finding)
+	for _, r := range report.Results {
+		if r.CheckName != "primary_keys" {
+			continue
+		}
+		for _, f := range r.Findings {
+			findings[f.ObjectName] = f
+		}
+	}
+
+	tests := []struct {
+		table, source, remediation string
+	}{
+		{"public.audit_log", "unique_index", `ALTER TABLE "public"."audit_log" ADD PRIMARY KEY USING INDEX "audit_log_id_idx";`},
+		{"public.archived_orders", "column_name", `ALTER TABLE "public"."archived_orders" ADD PRIMARY KEY ("id");`},
+		{"public.events", "", "Add a primary key to 'public.events'"},
+	}
+	for _, tt := range tests {
+		f, ok := findings[tt.table]
+		if !ok {
+			t.Errorf("%s: no primary_keys finding", tt.table)
+			continue
+		}
+		if got, _ := f.Metadata["candidate_source"].(string); got != tt.source {
+			t.Errorf("%s: candidate_source %q, want %q", tt.table, got, tt.source)
+		}
+		if !strings.Contains(f.Remediation, tt.remediation) {
+			t.Errorf("%s: remediation %q does not contain %q", tt.table, f.Remediation, tt.remediation)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+--
+-- PostgreSQL database dump
+--
+
+-- Dumped from database version 17.2
+-- Dumped by pg_dump version 17.2
+
+SET statement_timeout = 0;
+SELECT pg_catalog.set_config('search_path', '', false);
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm WITH SCHEMA public;
+
+CREATE TYPE public.order_status AS ENUM (
+    'new',
+    'paid',
+    'shipped'
+);
+
+CREATE TABLE public.customers (
+    id bigint NOT NULL,
+    email character varying(255) NOT NULL,
+    balance numeric(12,2)
+);
+
+CREATE SEQUENCE public.customers_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE public.customers_id_seq OWNED BY public.customers.id;
+
+CREATE TABLE public.orders (
+    id integer NOT NULL,
+    customer_id bigint NOT NULL,
+    status public.order_status DEFAULT 'new'::public.order_status NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    price numeric NOT NULL,
+    tax numeric NOT NULL,
+    total numeric GENERATED ALWAYS AS ((price + tax)) STORED
+);
+
+ALTER TABLE public.orders ALTER COLUMN id ADD GENERATED ALWAYS AS IDENTITY (
+    SEQUENCE NAME public.orders_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1
+);
+
+CREATE UNLOGGED TABLE public.events (
+    payload text,
+    blob oid
+);
+
+CREATE TABLE public.archived_orders (
+)
+INHERITS (public.orders);
+
+ALTER TABLE ONLY public.customers ALTER COLUMN id SET DEFAULT nextval('public.customers_id_seq'::regclass);
+
+ALTER TABLE ONLY public.customers
+    ADD CONSTRAINT customers_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY public.customers
+    ADD CONSTRAINT customers_email_key UNIQUE (email) DEFERRABLE;
+
+ALTER TABLE ONLY public.orders
+    ADD CONSTRAINT orders_pkey PRIMARY KEY (id);
+
+ALTER TABLE ONLY public.orders
+    ADD CONSTRAINT orders_customer_id_fkey FOREIGN KEY (customer_id) REFERENCES public.customers(id) ON DELETE CASCADE;
+
+CREATE RULE events_no_delete AS ON DELETE TO public.events DO INSTEAD NOTHING;
+
+CREATE TABLE public.audit_log (
+    id bigint NOT NULL,
+    logged_at timestamp with time zone NOT NULL,
+    note text
+);
+
+CREATE UNIQUE INDEX audit_log_id_idx ON public.audit_log USING btree (id);
+
+CREATE UNIQUE INDEX audit_log_note_idx ON public.audit_log USING btree (note) WHERE (note IS NOT NULL);
+
+ALTER TABLE ONLY public.audit_log REPLICA IDENTITY FULL;
+
+ALTER TABLE ONLY public.customers REPLICA IDENTITY FULL;
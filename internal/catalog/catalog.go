@@ -0,0 +1,399 @@
+// Package catalog provides a typed, in-memory snapshot of the user objects in
+// a database: relations with their columns, constraints, indexes, sequences,
+// types, functions, rules, and extensions.
+//
+// A scan loads the catalog once and shares it between checks through the
+// context, so checks can inspect typed Go structures instead of each
+// re-querying pg_class, pg_attribute, and pg_constraint. The same model can be
+// built from a parsed pg_dump file with FromParsed, which lets structural
+// checks run unchanged in offline analysis.
+package catalog
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Relation kinds, as stored in pg_class.relkind.
+const (
+	KindTable            = "r"
+	KindPartitionedTable = "p"
+	KindView             = "v"
+	KindMatView          = "m"
+	KindForeignTable     = "f"
+)
+
+// Constraint types, spelled as in SQL DDL.
+const (
+	PrimaryKey      = "PRIMARY KEY"
+	Unique          = "UNIQUE"
+	ForeignKey      = "FOREIGN KEY"
+	Exclude         = "EXCLUDE"
+	CheckConstraint = "CHECK"
+)
+
+// Relation is a table, view, or other relation in a user schema.
+type Relation struct {
+	OID    uint32 // pg_class OID; zero when not loaded from a live database
+	Schema string // Schema containing the relation
+	Name   string // Relation name
+	// Kind is the pg_class.relkind value (see the Kind constants).
+	Kind string
+	// Persistence is the pg_class.relpersistence value: p (permanent),
+	// u (unlogged), or t (temporary).
+	Persistence string
+	// ReplicaIdentity is the pg_class.relreplident value: d (default),
+	// n (nothing), f (full), or i (index).
+	ReplicaIdentity string
+	Pages           int64    // Size in pages, as of the last VACUUM/ANALYZE
+	Tuples          float64  // Estimated row count; -1 if never analyzed
+	Columns         []Column // Columns in attnum order
+	// Inherits lists the parents, as "schema.name", from traditional
+	// INHERITS inheritance. Partitions do not list their parent here.
+	Inherits []string
+}
+
+// QualifiedName returns "schema.name".
+func (r *Relation) QualifiedName() string {
+	return r.Schema + "." + r.Name
+}
+
+// Column returns the named column, or nil if the relation has no such column.
+func (r *Relation) Column(name string) *Column {
+	for i := range r.Columns {
+		if r.Columns[i].Name == name {
+			return &r.Columns[i]
+		}
+	}
+	return nil
+}
+
+// ColumnsByName returns a copy of the columns ordered by name.
+func (r *Relation) ColumnsByName() []Column {
+	out := make([]Column, len(r.Columns))
+	copy(out, r.Columns)
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Column is a column of a relation.
+type Column struct {
+	Name     string // Column name
+	Num      int    // Attribute number; columns are ordered by it
+	DataType string // Type as rendered by format_type, e.g. "numeric(10,2)"
+	NotNull  bool   // Has a NOT NULL constraint
+	// Default is the default expression, or empty if there is none.
+	// Generated columns keep their expression in Generated instead.
+	Default string
+	// Identity is "ALWAYS" or "BY DEFAULT" for identity columns, else empty.
+	Identity string
+	// Generated is the expression of a generated column, else empty.
+	Generated string
+	// GeneratedKind is "STORED" or "VIRTUAL" for generated columns, else empty.
+	GeneratedKind string
+}
+
+// BaseType returns DataType lower-cased and without type modifiers, so that
+// "numeric(10,2)" and "NUMERIC" both give "numeric".
+func (c Column) BaseType() string {
+	var b strings.Builder
+	depth := 0
+	for _, r := range strings.ToLower(c.DataType) {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case depth == 0:
+			b.WriteRune(r)
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// Constraint is a table constraint.
+type Constraint struct {
+	Name       string   // Constraint name
+	Schema     string   // Schema containing the table
+	Table      string   // Table name
+	Type       string   // PRIMARY KEY, UNIQUE, FOREIGN KEY, EXCLUDE, or CHECK
+	Columns    []string // Constrained columns, in key order
+	RefSchema  string   // FK: referenced table schema
+	RefTable   string   // FK: referenced table name
+	RefColumns []string // FK: referenced columns
+	OnDelete   string   // FK: ON DELETE action, e.g. CASCADE
+	OnUpdate   string   // FK: ON UPDATE action
+	Deferrable bool     // Constraint is DEFERRABLE
+	// InitiallyDeferred reports whether the constraint is INITIALLY DEFERRED.
+	InitiallyDeferred bool
+}
+
+// Index is an index on a relation.
+type Index struct {
+	Name    string // Index name
+	Schema  string // Schema containing the table
+	Table   string // Table name
+	Method  string // Access method, e.g. btree
+	Unique  bool   // Is a unique index
+	Primary bool   // Backs the primary key
+	Valid   bool   // Usable by queries (false after a failed concurrent build)
+	Partial bool   // Has a WHERE predicate
+	// Deferrable reports whether uniqueness is checked only at the end of
+	// the statement or transaction (pg_index.indisimmediate is false), as
+	// for the index of a DEFERRABLE constraint.
+	Deferrable bool
+	// Expressions reports whether any key is an expression rather than a
+	// plain column.
+	Expressions bool
+	// ReplicaIdentity reports whether the table's REPLICA IDENTITY USING
+	// INDEX names this index.
+	ReplicaIdentity bool
+	// Columns holds the key columns in order; expression keys hold the
+	// expression text.
+	Columns []string
+}
+
+// Sequence is a sequence object.
+type Sequence struct {
+	Schema    string // Schema containing the sequence
+	Name      string // Sequence name
+	DataType  string // smallint, integer, or bigint
+	Start     *int64 // START WITH value (nil if unknown)
+	Increment *int64 // INCREMENT BY value (nil if unknown)
+	MinValue  *int64 // MINVALUE (nil if unknown)
+	MaxValue  *int64 // MAXVALUE (nil if unknown)
+	Cycle     bool   // CYCLE option enabled
+	// OwnedByTable is the owning table as "schema.table", for serial and
+	// identity sequences and those with OWNED BY; empty otherwise.
+	OwnedByTable  string
+	OwnedByColumn string // Owning column
+}
+
+// QualifiedName returns "schema.name".
+func (s *Sequence) QualifiedName() string {
+	return s.Schema + "." + s.Name
+}
+
+// Type is a user-defined type.
+type Type struct {
+	Schema string // Schema containing the type
+	Name   string // Type name
+	// Kind is the pg_type.typtype value: e (enum), d (domain),
+	// c (composite), r (range), m (multirange), or b (base).
+	Kind   string
+	Labels []string // Enum labels in sort order
+}
+
+// Function is a function or procedure.
+type Function struct {
+	Schema     string // Schema containing the function
+	Name       string // Function name
+	Arguments  string // Identity arguments, e.g. "integer, text"
+	Kind       string // pg_proc.prokind: f, p, a, or w
+	Language   string // Implementation language, e.g. plpgsql
+	Volatility string // pg_proc.provolatile: i, s, or v
+	Result     string // Result type; empty for procedures
+	// SecurityDefiner reports whether the function runs as its owner.
+	SecurityDefiner bool
+	// Extension reports whether the function belongs to an extension.
+	Extension bool
+}
+
+// Rule is a rewrite rule other than the _RETURN rule of a view.
+type Rule struct {
+	Schema  string // Schema containing the relation
+	Table   string // Relation the rule is attached to
+	Name    string // Rule name
+	Event   string // SELECT, INSERT, UPDATE, or DELETE
+	Instead bool   // Is a DO INSTEAD rule
+}
+
+// Extension is an installed extension.
+type Extension struct {
+	Name    string // Extension name
+	Schema  string // Schema the extension's objects live in
+	Version string // Installed version; empty when not known
+}
+
+// Catalog is a snapshot of the user objects in one database.
+type Catalog struct {
+	// ServerVersion is the server_version of the database, e.g. "17.2".
+	// Empty when not known.
+	ServerVersion string
+
+	Relations   []Relation   // Ordered by schema, name
+	Constraints []Constraint // Ordered by schema, table, name
+	Indexes     []Index      // Ordered by schema, table, name
+	Sequences   []Sequence   // Ordered by schema, name
+	Types       []Type       // Ordered by schema, name
+	Functions   []Function   // Ordered by schema, name, arguments
+	Rules       []Rule       // Ordered by schema, table, name
+	Extensions  []Extension  // Ordered by name
+
+	// LargeObjects is the number of large objects in the database. Schema
+	// dumps carry none, so it is zero for catalogs built by FromParsed.
+	LargeObjects int64
+
+	// Lookup maps built by index, keyed by "schema.name" or
+	// "schema.table". Without them, lookups fall back to linear scans.
+	relByName      map[string]*Relation
+	consByTable    map[string][]int
+	indexesByTable map[string][]int
+	seqByOwner     map[string]*Sequence
+}
+
+// Relation returns the named relation, or nil if there is none.
+func (c *Catalog) Relation(schema, name string) *Relation {
+	if c.relByName != nil {
+		return c.relByName[schema+"."+name]
+	}
+	for i := range c.Relations {
+		if c.Relations[i].Schema == schema && c.Relations[i].Name == name {
+			return &c.Relations[i]
+		}
+	}
+	return nil
+}
+
+// index builds the lookup maps. It must run before the catalog is shared
+// between goroutines.
+func (c *Catalog) index() {
+	c.relByName = make(map[string]*Relation, len(c.Relations))
+	for i := range c.Relations {
+		r := &c.Relations[i]
+		c.relByName[r.QualifiedName()] = r
+	}
+	c.consByTable = make(map[string][]int)
+	for i, con := range c.Constraints {
+		key := con.Schema + "." + con.Table
+		c.consByTable[key] = append(c.consByTable[key], i)
+	}
+	c.indexesByTable = make(map[string][]int)
+	for i, idx := range c.Indexes {
+		key := idx.Schema + "." + idx.Table
+		c.indexesByTable[key] = append(c.indexesByTable[key], i)
+	}
+	c.seqByOwner = make(map[string]*Sequence)
+	for i := range c.Sequences {
+		s := &c.Sequences[i]
+		if s.OwnedByTable != "" {
+			c.seqByOwner[s.OwnedByTable+"."+s.OwnedByColumn] = s
+		}
+	}
+}
+
+// Tables returns the ordinary tables (relkind r), in schema, name order.
+func (c *Catalog) Tables() []*Relation {
+	var out []*Relation
+	for i := range c.Relations {
+		if c.Relations[i].Kind == KindTable {
+			out = append(out, &c.Relations[i])
+		}
+	}
+	return out
+}
+
+// ConstraintsFor returns the constraints on a table. A non-empty
+// constraintType restricts the result to that type.
+func (c *Catalog) ConstraintsFor(schema, table, constraintType string) []Constraint {
+	var out []Constraint
+	match := func(con Constraint) {
+		if constraintType == "" || con.Type == constraintType {
+			out = append(out, con)
+		}
+	}
+	if c.consByTable != nil {
+		for _, i := range c.consByTable[schema+"."+table] {
+			match(c.Constraints[i])
+		}
+		return out
+	}
+	for _, con := range c.Constraints {
+		if con.Schema == schema && con.Table == table {
+			match(con)
+		}
+	}
+	return out
+}
+
+// ConstraintsOfType returns every constraint of the given type.
+func (c *Catalog) ConstraintsOfType(constraintType string) []Constraint {
+	var out []Constraint
+	for _, con := range c.Constraints {
+		if con.Type == constraintType {
+			out = append(out, con)
+		}
+	}
+	return out
+}
+
+// IndexesFor returns the indexes on a table.
+func (c *Catalog) IndexesFor(schema, table string) []Index {
+	var out []Index
+	if c.indexesByTable != nil {
+		for _, i := range c.indexesByTable[schema+"."+table] {
+			out = append(out, c.Indexes[i])
+		}
+		return out
+	}
+	for _, idx := range c.Indexes {
+		if idx.Schema == schema && idx.Table == table {
+			out = append(out, idx)
+		}
+	}
+	return out
+}
+
+// SequenceOwnedBy returns the sequence owned by a column, or nil if the
+// column owns none.
+func (c *Catalog) SequenceOwnedBy(schema, table, column string) *Sequence {
+	owner := schema + "." + table
+	if c.seqByOwner != nil {
+		return c.seqByOwner[owner+"."+column]
+	}
+	for i := range c.Sequences {
+		s := &c.Sequences[i]
+		if s.OwnedByTable == owner && s.OwnedByColumn == column {
+			return s
+		}
+	}
+	return nil
+}
+
+type sharedKey struct{}
+
+// shared is the catalog loaded once for the checks of one run, or the error
+// loading it failed with.
+type shared struct {
+	cat *Catalog
+	err error
+}
+
+// Preload loads the catalog of the database behind conn and returns a
+// context in which Get hands it to every caller. A positive timeout bounds
+// the load on its own, apart from any check's time limit. A failed load is
+// remembered too, so each check reports the error instead of retrying.
+func Preload(ctx context.Context, conn *pgx.Conn, timeout time.Duration) context.Context {
+	loadCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		loadCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	cat, err := Load(loadCtx, conn)
+	return context.WithValue(ctx, sharedKey{}, &shared{cat: cat, err: err})
+}
+
+// Get returns the catalog of the database behind conn. Inside a context from
+// Preload it returns the preloaded catalog, or the error loading it failed
+// with; otherwise every call loads a fresh catalog.
+func Get(ctx context.Context, conn *pgx.Conn) (*Catalog, error) {
+	if s, ok := ctx.Value(sharedKey{}).(*shared); ok {
+		return s.cat, s.err
+	}
+	return Load(ctx, conn)
+}
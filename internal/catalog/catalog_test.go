@@ -0,0 +1,94 @@
+package catalog
+
+import "testing"
+
+func sampleCatalog() *Catalog {
+	return &Catalog{
+		Relations: []Relation{
+			{Schema: "public", Name: "orders", Kind: KindTable, Columns: []Column{
+				{Name: "id", Num: 1, DataType: "bigint", NotNull: true, Identity: "ALWAYS"},
+				{Name: "customer_id", Num: 2, DataType: "integer"},
+				{Name: "amount", Num: 3, DataType: "numeric(12,2)"},
+			}},
+			{Schema: "public", Name: "order_totals", Kind: KindView},
+		},
+		Constraints: []Constraint{
+			{Name: "orders_pkey", Schema: "public", Table: "orders", Type: PrimaryKey, Columns: []string{"id"}},
+			{Name: "orders_customer_fk", Schema: "public", Table: "orders", Type: ForeignKey,
+				Columns: []string{"customer_id"}, RefSchema: "public", RefTable: "customers"},
+		},
+		Indexes: []Index{
+			{Name: "orders_pkey", Schema: "public", Table: "orders", Unique: true, Primary: true, Columns: []string{"id"}},
+		},
+		Sequences: []Sequence{
+			{Schema: "public", Name: "orders_id_seq", OwnedByTable: "public.orders", OwnedByColumn: "id"},
+		},
+	}
+}
+
+func TestColumnBaseType(t *testing.T) {
+	tests := map[string]string{
+		"numeric(12,2)":               "numeric",
+		"INTEGER":                     "integer",
+		"character varying(64)":       "character varying",
+		"timestamp(3) with time zone": "timestamp with time zone",
+		"double precision":            "double precision",
+	}
+	for in, want := range tests {
+		if got := (Column{DataType: in}).BaseType(); got != want {
+			t.Errorf("BaseType(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestLookupsWithAndWithoutIndex(t *testing.T) {
+	for _, indexed := range []bool{false, true} {
+		cat := sampleCatalog()
+		if indexed {
+			cat.index()
+		}
+
+		if r := cat.Relation("public", "orders"); r == nil || r.Column("amount") == nil {
+			t.Errorf("indexed=%v: Relation(public.orders) not found", indexed)
+		}
+		if cat.Relation("public", "missing") != nil {
+			t.Errorf("indexed=%v: unexpected relation", indexed)
+		}
+		if got := len(cat.ConstraintsFor("public", "orders", "")); got != 2 {
+			t.Errorf("indexed=%v: ConstraintsFor all = %d, want 2", indexed, got)
+		}
+		if got := len(cat.ConstraintsFor("public", "orders", PrimaryKey)); got != 1 {
+			t.Errorf("indexed=%v: ConstraintsFor PK = %d, want 1", indexed, got)
+		}
+		if got := len(cat.IndexesFor("public", "orders")); got != 1 {
+			t.Errorf("indexed=%v: IndexesFor = %d, want 1", indexed, got)
+		}
+		if s := cat.SequenceOwnedBy("public", "orders", "id"); s == nil || s.QualifiedName() != "public.orders_id_seq" {
+			t.Errorf("indexed=%v: SequenceOwnedBy = %v", indexed, s)
+		}
+		if cat.SequenceOwnedBy("public", "orders", "customer_id") != nil {
+			t.Errorf("indexed=%v: customer_id should own no sequence", indexed)
+		}
+	}
+}
+
+func TestTablesAndColumnsByName(t *testing.T) {
+	cat := sampleCatalog()
+	tables := cat.Tables()
+	if len(tables) != 1 || tables[0].Name != "orders" {
+		t.Fatalf("Tables() = %v, want only orders", tables)
+	}
+	cols := tables[0].ColumnsByName()
+	want := []string{"amount", "customer_id", "id"}
+	for i, c := range cols {
+		if c.Name != want[i] {
+			t.Errorf("ColumnsByName()[%d] = %s, want %s", i, c.Name, want[i])
+		}
+	}
+	if tables[0].Columns[0].Name != "id" {
+		t.Error("ColumnsByName should not reorder Columns")
+	}
+	if got := len(cat.ConstraintsOfType(ForeignKey)); got != 1 {
+		t.Errorf("ConstraintsOfType(FK) = %d, want 1", got)
+	}
+}
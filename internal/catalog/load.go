@@ -0,0 +1,465 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// userSchemas is the filter every query applies to pg_namespace n.
+const userSchemas = `n.nspname NOT IN ('pg_catalog', 'information_schema', 'spock', 'pg_toast')`
+
+// SQL expressions, over a pg_catalog.pg_class row aliased c, for the two parts
+// of a table's data that the initial COPY of a new node transfers: the heap's
+// main fork and the TOAST table with its index. Every check that reports
+// initial sync volume adds the two, so their figures agree.
+const (
+	HeapBytesSQL  = "pg_catalog.pg_relation_size(c.oid)"
+	ToastBytesSQL = "CASE WHEN c.reltoastrelid <> 0 " +
+		"THEN pg_catalog.pg_total_relation_size(c.reltoastrelid) ELSE 0 END"
+)
+
+// loader fills in one kind of object of a Catalog.
+type loader struct {
+	what string
+	fn   func(context.Context, *pgx.Conn, *Catalog) error
+}
+
+// basicLoaders read what every PostgreSQL version exposes the same way.
+var basicLoaders = []loader{
+	{"server version", loadServerVersion},
+	{"extensions", loadExtensions},
+}
+
+// Load reads the catalog of the database behind conn. It issues one query
+// per kind of object.
+func Load(ctx context.Context, conn *pgx.Conn) (*Catalog, error) {
+	return load(ctx, conn, slices.Concat(basicLoaders, []loader{
+		{"relations", loadRelations},
+		{"columns", loadColumns},
+		{"inheritance", loadInheritance},
+		{"constraints", loadConstraints},
+		{"indexes", loadIndexes},
+		{"sequences", loadSequences},
+		{"types", loadTypes},
+		{"functions", loadFunctions},
+		{"rules", loadRules},
+		{"large objects", loadLargeObjects},
+	}))
+}
+
+// LoadBasic reads only the server version and the installed extensions.
+// Unlike Load it works on servers too old for Spock, so the checks that
+// report such servers can still run there.
+func LoadBasic(ctx context.Context, conn *pgx.Conn) (*Catalog, error) {
+	return load(ctx, conn, basicLoaders)
+}
+
+func load(ctx context.Context, conn *pgx.Conn, loaders []loader) (*Catalog, error) {
+	cat := &Catalog{}
+	for _, l := range loaders {
+		if err := l.fn(ctx, conn, cat); err != nil {
+			return nil, fmt.Errorf("load catalog %s: %w", l.what, err)
+		}
+	}
+	cat.index()
+	return cat, nil
+}
+
+func loadServerVersion(ctx context.Context, conn *pgx.Conn, cat *Catalog) error {
+	return conn.QueryRow(ctx, "SELECT current_setting('server_version')").Scan(&cat.ServerVersion)
+}
+
+func loadRelations(ctx context.Context, conn *pgx.Conn, cat *Catalog) error {
+	rows, err := conn.Query(ctx, `
+		SELECT c.oid, n.nspname, c.relname, c.relkind::text, c.relpersistence::text,
+		       c.relreplident::text, c.relpages::bigint, c.reltuples::float8
+		FROM pg_catalog.pg_class c
+		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind IN ('r', 'p', 'v', 'm', 'f')
+		  AND `+userSchemas+`
+		ORDER BY n.nspname, c.relname;
+	`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r Relation
+		if err := rows.Scan(&r.OID, &r.Schema, &r.Name, &r.Kind, &r.Persistence,
+			&r.ReplicaIdentity, &r.Pages, &r.Tuples); err != nil {
+			return err
+		}
+		cat.Relations = append(cat.Relations, r)
+	}
+	return rows.Err()
+}
+
+func loadColumns(ctx context.Context, conn *pgx.Conn, cat *Catalog) error {
+	byOID := make(map[uint32]*Relation, len(cat.Relations))
+	for i := range cat.Relations {
+		byOID[cat.Relations[i].OID] = &cat.Relations[i]
+	}
+
+	rows, err := conn.Query(ctx, `
+		SELECT a.attrelid, a.attname, a.attnum::int,
+		       format_type(a.atttypid, a.atttypmod),
+		       a.attnotnull,
+		       coalesce(pg_get_expr(d.adbin, d.adrelid), ''),
+		       a.attidentity::text,
+		       a.attgenerated::text
+		FROM pg_catalog.pg_attribute a
+		JOIN pg_catalog.pg_class c ON c.oid = a.attrelid
+		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		LEFT JOIN pg_catalog.pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+		WHERE c.relkind IN ('r', 'p', 'v', 'm', 'f')
+		  AND a.attnum > 0
+		  AND NOT a.attisdropped
+		  AND `+userSchemas+`
+		ORDER BY a.attrelid, a.attnum;
+	`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var relOID uint32
+		var col Column
+		var expr, identity, generated string
+		if err := rows.Scan(&relOID, &col.Name, &col.Num, &col.DataType, &col.NotNull,
+			&expr, &identity, &generated); err != nil {
+			return err
+		}
+		switch identity {
+		case "a":
+			col.Identity = "ALWAYS"
+		case "d":
+			col.Identity = "BY DEFAULT"
+		}
+		switch generated {
+		case "s":
+			col.Generated, col.GeneratedKind = expr, "STORED"
+		case "v":
+			col.Generated, col.GeneratedKind = expr, "VIRTUAL"
+		default:
+			col.Default = expr
+		}
+		if r := byOID[relOID]; r != nil {
+			r.Columns = append(r.Columns, col)
+		}
+	}
+	return rows.Err()
+}
+
+func loadInheritance(ctx context.Context, conn *pgx.Conn, cat *Catalog) error {
+	rows, err := conn.Query(ctx, `
+		SELECT n.nspname, c.relname, pn.nspname || '.' || pc.relname
+		FROM pg_catalog.pg_inherits i
+		JOIN pg_catalog.pg_class c ON c.oid = i.inhrelid
+		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		JOIN pg_catalog.pg_class pc ON pc.oid = i.inhparent
+		JOIN pg_catalog.pg_namespace pn ON pn.oid = pc.relnamespace
+		WHERE NOT c.relispartition
+		  AND `+userSchemas+`
+		ORDER BY n.nspname, c.relname, i.inhseqno;
+	`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var schema, name, parent string
+		if err := rows.Scan(&schema, &name, &parent); err != nil {
+			return err
+		}
+		if r := cat.Relation(schema, name); r != nil {
+			r.Inherits = append(r.Inherits, parent)
+		}
+	}
+	return rows.Err()
+}
+
+// constraintTypes maps pg_constraint.contype to the SQL spelling.
+var constraintTypes = map[string]string{
+	"p": PrimaryKey,
+	"u": Unique,
+	"f": ForeignKey,
+	"x": Exclude,
+	"c": CheckConstraint,
+}
+
+// fkActions maps pg_constraint.confdeltype/confupdtype to the SQL spelling.
+var fkActions = map[string]string{
+	"a": "NO ACTION",
+	"r": "RESTRICT",
+	"c": "CASCADE",
+	"n": "SET NULL",
+	"d": "SET DEFAULT",
+}
+
+func loadConstraints(ctx context.Context, conn *pgx.Conn, cat *Catalog) error {
+	rows, err := conn.Query(ctx, `
+		SELECT con.conname, n.nspname, c.relname, con.contype::text,
+		       ARRAY(
+		           SELECT a.attname::text
+		           FROM unnest(con.conkey) WITH ORDINALITY AS k(attnum, ord)
+		           JOIN pg_catalog.pg_attribute a
+		             ON a.attrelid = con.conrelid AND a.attnum = k.attnum
+		           ORDER BY k.ord
+		       ),
+		       coalesce(rn.nspname::text, ''), coalesce(rc.relname::text, ''),
+		       ARRAY(
+		           SELECT a.attname::text
+		           FROM unnest(con.confkey) WITH ORDINALITY AS k(attnum, ord)
+		           JOIN pg_catalog.pg_attribute a
+		             ON a.attrelid = con.confrelid AND a.attnum = k.attnum
+		           ORDER BY k.ord
+		       ),
+		       con.confdeltype::text, con.confupdtype::text,
+		       con.condeferrable, con.condeferred
+		FROM pg_catalog.pg_constraint con
+		JOIN pg_catalog.pg_class c ON c.oid = con.conrelid
+		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		LEFT JOIN pg_catalog.pg_class rc ON rc.oid = con.confrelid
+		LEFT JOIN pg_catalog.pg_namespace rn ON rn.oid = rc.relnamespace
+		WHERE con.contype IN ('p', 'u', 'f', 'x', 'c')
+		  AND `+userSchemas+`
+		ORDER BY n.nspname, c.relname, con.conname;
+	`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var con Constraint
+		var contype, onDelete, onUpdate string
+		if err := rows.Scan(&con.Name, &con.Schema, &con.Table, &contype, &con.Columns,
+			&con.RefSchema, &con.RefTable, &con.RefColumns, &onDelete, &onUpdate,
+			&con.Deferrable, &con.InitiallyDeferred); err != nil {
+			return err
+		}
+		con.Type = constraintTypes[contype]
+		con.OnDelete = fkActions[onDelete]
+		con.OnUpdate = fkActions[onUpdate]
+		cat.Constraints = append(cat.Constraints, con)
+	}
+	return rows.Err()
+}
+
+func loadIndexes(ctx context.Context, conn *pgx.Conn, cat *Catalog) error {
+	rows, err := conn.Query(ctx, `
+		SELECT ic.relname, n.nspname, c.relname, am.amname::text,
+		       i.indisunique, i.indisprimary, i.indisvalid, i.indpred IS NOT NULL,
+		       NOT i.indisimmediate, i.indexprs IS NOT NULL, i.indisreplident,
+		       ARRAY(
+		           SELECT CASE WHEN k.attnum = 0
+		                       THEN pg_get_indexdef(i.indexrelid, k.ord::int, true)
+		                       ELSE a.attname::text
+		                  END
+		           FROM unnest(i.indkey::int2[]) WITH ORDINALITY AS k(attnum, ord)
+		           LEFT JOIN pg_catalog.pg_attribute a
+		             ON a.attrelid = i.indrelid AND a.attnum = k.attnum
+		           WHERE k.ord <= i.indnkeyatts
+		           ORDER BY k.ord
+		       )
+		FROM pg_catalog.pg_index i
+		JOIN pg_catalog.pg_class ic ON ic.oid = i.indexrelid
+		JOIN pg_catalog.pg_class c ON c.oid = i.indrelid
+		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		JOIN pg_catalog.pg_am am ON am.oid = ic.relam
+		WHERE `+userSchemas+`
+		ORDER BY n.nspname, c.relname, ic.relname;
+	`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var idx Index
+		if err := rows.Scan(&idx.Name, &idx.Schema, &idx.Table, &idx.Method,
+			&idx.Unique, &idx.Primary, &idx.Valid, &idx.Partial, &idx.Deferrable,
+			&idx.Expressions, &idx.ReplicaIdentity, &idx.Columns); err != nil {
+			return err
+		}
+		cat.Indexes = append(cat.Indexes, idx)
+	}
+	return rows.Err()
+}
+
+func loadSequences(ctx context.Context, conn *pgx.Conn, cat *Catalog) error {
+	// Serial and identity sequences depend on their column with deptype
+	// 'a' and 'i' respectively; this is what pg_get_serial_sequence follows.
+	rows, err := conn.Query(ctx, `
+		SELECT n.nspname, c.relname, format_type(s.seqtypid, NULL),
+		       s.seqstart, s.seqincrement, s.seqmin, s.seqmax, s.seqcycle,
+		       coalesce(tn.nspname || '.' || tc.relname, ''),
+		       coalesce(a.attname::text, '')
+		FROM pg_catalog.pg_sequence s
+		JOIN pg_catalog.pg_class c ON c.oid = s.seqrelid
+		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		LEFT JOIN pg_catalog.pg_depend d
+		  ON d.classid = 'pg_catalog.pg_class'::regclass
+		 AND d.objid = s.seqrelid
+		 AND d.refclassid = 'pg_catalog.pg_class'::regclass
+		 AND d.deptype IN ('a', 'i')
+		LEFT JOIN pg_catalog.pg_class tc ON tc.oid = d.refobjid
+		LEFT JOIN pg_catalog.pg_namespace tn ON tn.oid = tc.relnamespace
+		LEFT JOIN pg_catalog.pg_attribute a
+		  ON a.attrelid = d.refobjid AND a.attnum = d.refobjsubid
+		WHERE `+userSchemas+`
+		ORDER BY n.nspname, c.relname;
+	`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var s Sequence
+		var start, increment, minValue, maxValue int64
+		if err := rows.Scan(&s.Schema, &s.Name, &s.DataType, &start, &increment,
+			&minValue, &maxValue, &s.Cycle, &s.OwnedByTable, &s.OwnedByColumn); err != nil {
+			return err
+		}
+		s.Start, s.Increment, s.MinValue, s.MaxValue = &start, &increment, &minValue, &maxValue
+		cat.Sequences = append(cat.Sequences, s)
+	}
+	return rows.Err()
+}
+
+func loadTypes(ctx context.Context, conn *pgx.Conn, cat *Catalog) error {
+	// Row types of tables and views and array types are left out; only
+	// standalone composite types are kept.
+	rows, err := conn.Query(ctx, `
+		SELECT n.nspname, t.typname, t.typtype::text,
+		       ARRAY(
+		           SELECT e.enumlabel::text
+		           FROM pg_catalog.pg_enum e
+		           WHERE e.enumtypid = t.oid
+		           ORDER BY e.enumsortorder
+		       )
+		FROM pg_catalog.pg_type t
+		JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace
+		LEFT JOIN pg_catalog.pg_class c ON c.oid = t.typrelid
+		WHERE (t.typrelid = 0 OR c.relkind = 'c')
+		  AND t.typcategory <> 'A'
+		  AND `+userSchemas+`
+		ORDER BY n.nspname, t.typname;
+	`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var t Type
+		if err := rows.Scan(&t.Schema, &t.Name, &t.Kind, &t.Labels); err != nil {
+			return err
+		}
+		cat.Types = append(cat.Types, t)
+	}
+	return rows.Err()
+}
+
+func loadFunctions(ctx context.Context, conn *pgx.Conn, cat *Catalog) error {
+	rows, err := conn.Query(ctx, `
+		SELECT n.nspname, p.proname, pg_get_function_identity_arguments(p.oid),
+		       p.prokind::text, l.lanname::text, p.provolatile::text,
+		       coalesce(pg_get_function_result(p.oid), ''), p.prosecdef,
+		       EXISTS (
+		           SELECT 1 FROM pg_catalog.pg_depend d
+		           WHERE d.classid = 'pg_catalog.pg_proc'::regclass
+		             AND d.objid = p.oid
+		             AND d.deptype = 'e'
+		       )
+		FROM pg_catalog.pg_proc p
+		JOIN pg_catalog.pg_namespace n ON n.oid = p.pronamespace
+		JOIN pg_catalog.pg_language l ON l.oid = p.prolang
+		WHERE `+userSchemas+`
+		ORDER BY n.nspname, p.proname, 3;
+	`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var f Function
+		if err := rows.Scan(&f.Schema, &f.Name, &f.Arguments, &f.Kind, &f.Language,
+			&f.Volatility, &f.Result, &f.SecurityDefiner, &f.Extension); err != nil {
+			return err
+		}
+		cat.Functions = append(cat.Functions, f)
+	}
+	return rows.Err()
+}
+
+// ruleEvents maps pg_rewrite.ev_type to the SQL event name.
+var ruleEvents = map[string]string{
+	"1": "SELECT",
+	"2": "UPDATE",
+	"3": "INSERT",
+	"4": "DELETE",
+}
+
+func loadRules(ctx context.Context, conn *pgx.Conn, cat *Catalog) error {
+	rows, err := conn.Query(ctx, `
+		SELECT n.nspname, c.relname, r.rulename, r.ev_type::text, r.is_instead
+		FROM pg_catalog.pg_rewrite r
+		JOIN pg_catalog.pg_class c ON c.oid = r.ev_class
+		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		WHERE r.rulename <> '_RETURN'
+		  AND `+userSchemas+`
+		ORDER BY n.nspname, c.relname, r.rulename;
+	`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r Rule
+		var event string
+		if err := rows.Scan(&r.Schema, &r.Table, &r.Name, &event, &r.Instead); err != nil {
+			return err
+		}
+		r.Event = ruleEvents[event]
+		cat.Rules = append(cat.Rules, r)
+	}
+	return rows.Err()
+}
+
+func loadExtensions(ctx context.Context, conn *pgx.Conn, cat *Catalog) error {
+	// Extensions are listed whatever their schema: plpgsql lives in
+	// pg_catalog but is still an installed extension.
+	rows, err := conn.Query(ctx, `
+		SELECT e.extname, n.nspname, e.extversion
+		FROM pg_catalog.pg_extension e
+		JOIN pg_catalog.pg_namespace n ON n.oid = e.extnamespace
+		ORDER BY e.extname;
+	`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e Extension
+		if err := rows.Scan(&e.Name, &e.Schema, &e.Version); err != nil {
+			return err
+		}
+		cat.Extensions = append(cat.Extensions, e)
+	}
+	return rows.Err()
+}
+
+func loadLargeObjects(ctx context.Context, conn *pgx.Conn, cat *Catalog) error {
+	return conn.QueryRow(ctx, "SELECT count(*) FROM pg_catalog.pg_largeobject_metadata").Scan(&cat.LargeObjects)
+}
@@ -0,0 +1,353 @@
+package catalog
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pgEdge/mm-ready-go/internal/parser"
+)
+
+// FromParsed builds a catalog from a parsed pg_dump file, filling in what
+// the server would have recorded for the same DDL: type aliases take their
+// canonical names, PRIMARY KEY and UNIQUE constraints get their backing
+// indexes, and omitted sequence options take their defaults.
+//
+// A dump does not carry statistics, OIDs, or large objects, so Pages,
+// Tuples, OID, and LargeObjects stay zero.
+func FromParsed(schema *parser.ParsedSchema) *Catalog {
+	cat := &Catalog{ServerVersion: schema.PgVersion}
+
+	for _, t := range schema.Tables {
+		r := Relation{
+			Schema:          t.SchemaName,
+			Name:            t.TableName,
+			Kind:            KindTable,
+			Persistence:     "p",
+			ReplicaIdentity: "d",
+		}
+		if t.PartitionBy != "" {
+			r.Kind = KindPartitionedTable
+		}
+		if t.Unlogged {
+			r.Persistence = "u"
+		}
+		if code, ok := replicaIdentities[t.ReplicaIdentity]; ok {
+			r.ReplicaIdentity = code
+		}
+		for i, c := range t.Columns {
+			col := Column{
+				Name:     c.Name,
+				Num:      i + 1,
+				DataType: canonicalType(c.DataType),
+				NotNull:  c.NotNull,
+				Default:  c.DefaultExpr,
+				Identity: c.Identity,
+			}
+			if c.GeneratedExpr != "" {
+				col.Generated, col.GeneratedKind = c.GeneratedExpr, "STORED"
+				col.Default = ""
+			}
+			r.Columns = append(r.Columns, col)
+		}
+		for _, p := range t.Inherits {
+			r.Inherits = append(r.Inherits, qualify(p, t.SchemaName))
+		}
+		cat.Relations = append(cat.Relations, r)
+	}
+	inheritColumns(cat.Relations)
+
+	for _, c := range schema.Constraints {
+		con := Constraint{
+			Name:              c.Name,
+			Schema:            c.TableSchema,
+			Table:             c.TableName,
+			Type:              c.ConstraintType,
+			Columns:           c.Columns,
+			RefSchema:         c.RefSchema,
+			RefTable:          c.RefTable,
+			RefColumns:        c.RefColumns,
+			Deferrable:        c.Deferrable,
+			InitiallyDeferred: c.InitiallyDeferred,
+		}
+		if con.Type == ForeignKey {
+			con.OnDelete = fkAction(c.OnDelete)
+			con.OnUpdate = fkAction(c.OnUpdate)
+		}
+		cat.Constraints = append(cat.Constraints, con)
+
+		// The server creates a unique btree index for these constraints;
+		// the dump leaves it implicit.
+		if con.Type == PrimaryKey || con.Type == Unique {
+			cat.Indexes = append(cat.Indexes, Index{
+				Name:       con.Name,
+				Schema:     con.Schema,
+				Table:      con.Table,
+				Method:     "btree",
+				Unique:     true,
+				Primary:    con.Type == PrimaryKey,
+				Valid:      true,
+				Deferrable: con.Deferrable,
+				Columns:    con.Columns,
+			})
+		}
+	}
+
+	for _, i := range schema.Indexes {
+		method := strings.ToLower(i.Method)
+		if method == "" {
+			method = "btree"
+		}
+		cat.Indexes = append(cat.Indexes, Index{
+			Name:        i.Name,
+			Schema:      i.TableSchema,
+			Table:       i.TableName,
+			Method:      method,
+			Unique:      i.IsUnique,
+			Valid:       true,
+			Partial:     i.Partial,
+			Expressions: slices.ContainsFunc(i.Columns, isExpressionKey),
+			Columns:     i.Columns,
+		})
+	}
+
+	for _, t := range schema.Tables {
+		if t.ReplicaIdentityIndex == "" {
+			continue
+		}
+		for i := range cat.Indexes {
+			idx := &cat.Indexes[i]
+			if idx.Schema == t.SchemaName && idx.Table == t.TableName && idx.Name == t.ReplicaIdentityIndex {
+				idx.ReplicaIdentity = true
+			}
+		}
+	}
+
+	for _, s := range schema.Sequences {
+		seq := Sequence{
+			Schema:        s.SchemaName,
+			Name:          s.SequenceName,
+			DataType:      canonicalType(s.DataType),
+			Start:         s.StartValue,
+			Increment:     s.Increment,
+			MinValue:      s.MinValue,
+			MaxValue:      s.MaxValue,
+			Cycle:         s.Cycle,
+			OwnedByTable:  s.OwnedByTable,
+			OwnedByColumn: s.OwnedByColumn,
+		}
+		if seq.DataType == "" {
+			seq.DataType = "bigint"
+		}
+		fillSequenceDefaults(&seq)
+		cat.Sequences = append(cat.Sequences, seq)
+	}
+
+	for _, e := range schema.EnumTypes {
+		cat.Types = append(cat.Types, Type{Schema: e.SchemaName, Name: e.TypeName, Kind: "e", Labels: e.Labels})
+	}
+
+	for _, r := range schema.Rules {
+		cat.Rules = append(cat.Rules, Rule{
+			Schema:  r.SchemaName,
+			Table:   r.TableName,
+			Name:    r.RuleName,
+			Event:   strings.ToUpper(r.Event),
+			Instead: r.IsInstead,
+		})
+	}
+
+	for _, e := range schema.Extensions {
+		cat.Extensions = append(cat.Extensions, Extension{Name: e.Name, Schema: e.SchemaName})
+	}
+
+	cat.sort()
+	cat.index()
+	return cat
+}
+
+// inheritColumns gives each child table the columns of its parents, ahead
+// of its own, as the server does. A dump declares only a child's local
+// columns.
+func inheritColumns(relations []Relation) {
+	byName := make(map[string]*Relation, len(relations))
+	for i := range relations {
+		byName[relations[i].QualifiedName()] = &relations[i]
+	}
+	done := make(map[*Relation]bool)
+	var resolve func(r *Relation)
+	resolve = func(r *Relation) {
+		if done[r] {
+			return
+		}
+		done[r] = true
+		var cols []Column
+		seen := make(map[string]bool)
+		for _, name := range r.Inherits {
+			parent := byName[name]
+			if parent == nil {
+				continue
+			}
+			resolve(parent)
+			for _, col := range parent.Columns {
+				if !seen[col.Name] {
+					seen[col.Name] = true
+					col.Identity = "" // identity is not inherited
+					cols = append(cols, col)
+				}
+			}
+		}
+		for _, col := range r.Columns {
+			if !seen[col.Name] {
+				seen[col.Name] = true
+				cols = append(cols, col)
+			}
+		}
+		for i := range cols {
+			cols[i].Num = i + 1
+		}
+		r.Columns = cols
+	}
+	for i := range relations {
+		resolve(&relations[i])
+	}
+}
+
+// sort puts every slice in the order Load returns it in.
+func (c *Catalog) sort() {
+	byKey := func(key func(i int) []string) func(i, j int) bool {
+		return func(i, j int) bool { return slices.Compare(key(i), key(j)) < 0 }
+	}
+	sort.SliceStable(c.Relations, byKey(func(i int) []string {
+		return []string{c.Relations[i].Schema, c.Relations[i].Name}
+	}))
+	sort.SliceStable(c.Constraints, byKey(func(i int) []string {
+		return []string{c.Constraints[i].Schema, c.Constraints[i].Table, c.Constraints[i].Name}
+	}))
+	sort.SliceStable(c.Indexes, byKey(func(i int) []string {
+		return []string{c.Indexes[i].Schema, c.Indexes[i].Table, c.Indexes[i].Name}
+	}))
+	sort.SliceStable(c.Sequences, byKey(func(i int) []string {
+		return []string{c.Sequences[i].Schema, c.Sequences[i].Name}
+	}))
+	sort.SliceStable(c.Types, byKey(func(i int) []string {
+		return []string{c.Types[i].Schema, c.Types[i].Name}
+	}))
+	sort.SliceStable(c.Rules, byKey(func(i int) []string {
+		return []string{c.Rules[i].Schema, c.Rules[i].Table, c.Rules[i].Name}
+	}))
+	sort.SliceStable(c.Extensions, byKey(func(i int) []string {
+		return []string{c.Extensions[i].Name}
+	}))
+}
+
+// typeAliases maps alternative type spellings to the names format_type
+// returns.
+var typeAliases = map[string]string{
+	"int":         "integer",
+	"int4":        "integer",
+	"int8":        "bigint",
+	"int2":        "smallint",
+	"float4":      "real",
+	"float8":      "double precision",
+	"decimal":     "numeric",
+	"bool":        "boolean",
+	"varchar":     "character varying",
+	"char":        "character",
+	"timestamptz": "timestamp with time zone",
+	"timetz":      "time with time zone",
+}
+
+// canonicalType rewrites a type alias to its canonical name, keeping any
+// type modifiers, so that "DECIMAL(10,2)" becomes "numeric(10,2)".
+func canonicalType(dataType string) string {
+	base, mods := strings.TrimSpace(dataType), ""
+	if i := strings.IndexByte(base, '('); i >= 0 {
+		base, mods = strings.TrimSpace(base[:i]), base[i:]
+	}
+	if canonical, ok := typeAliases[strings.ToLower(base)]; ok {
+		return canonical + mods
+	}
+	return strings.TrimSpace(dataType)
+}
+
+// fkAction normalizes a parsed ON DELETE/ON UPDATE action; an omitted
+// action is NO ACTION.
+func fkAction(action string) string {
+	action = strings.Join(strings.Fields(strings.ToUpper(action)), " ")
+	if action == "" {
+		return "NO ACTION"
+	}
+	return action
+}
+
+// replicaIdentities maps a parsed REPLICA IDENTITY setting to
+// pg_class.relreplident.
+var replicaIdentities = map[string]string{
+	"DEFAULT": "d",
+	"NOTHING": "n",
+	"FULL":    "f",
+	"INDEX":   "i",
+}
+
+// qualify returns name as "schema.name", unquoted, using schema when name
+// carries none.
+func qualify(name, schema string) string {
+	name = strings.ReplaceAll(strings.TrimSpace(name), `"`, "")
+	if strings.Contains(name, ".") {
+		return name
+	}
+	return schema + "." + name
+}
+
+// sequenceTypeMax holds the largest value of each sequence data type.
+var sequenceTypeMax = map[string]int64{
+	"smallint": 32767,
+	"integer":  2147483647,
+	"bigint":   9223372036854775807,
+}
+
+// fillSequenceDefaults sets the options CREATE SEQUENCE leaves out to the
+// values the server would store.
+func fillSequenceDefaults(s *Sequence) {
+	typeMax, ok := sequenceTypeMax[s.DataType]
+	if !ok {
+		typeMax = sequenceTypeMax["bigint"]
+	}
+	if s.Increment == nil {
+		s.Increment = int64Ptr(1)
+	}
+	ascending := *s.Increment > 0
+	if s.MinValue == nil {
+		if ascending {
+			s.MinValue = int64Ptr(1)
+		} else {
+			s.MinValue = int64Ptr(-typeMax - 1)
+		}
+	}
+	if s.MaxValue == nil {
+		if ascending {
+			s.MaxValue = int64Ptr(typeMax)
+		} else {
+			s.MaxValue = int64Ptr(-1)
+		}
+	}
+	if s.Start == nil {
+		if ascending {
+			s.Start = int64Ptr(*s.MinValue)
+		} else {
+			s.Start = int64Ptr(*s.MaxValue)
+		}
+	}
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+// isExpressionKey reports whether an index key from a dump is an expression.
+// The parser keeps expression keys as their text, which has parentheses or
+// operators that plain column names almost never do.
+func isExpressionKey(key string) bool {
+	return strings.ContainsAny(key, "()+*/|:")
+}
@@ -0,0 +1,188 @@
+package catalog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pgEdge/mm-ready-go/internal/parser"
+)
+
+func int64p(v int64) *int64 { return &v }
+
+func parsedSample() *parser.ParsedSchema {
+	return &parser.ParsedSchema{
+		PgVersion: "17.2",
+		Tables: []parser.TableDef{
+			{SchemaName: "public", TableName: "orders", Columns: []parser.ColumnDef{
+				{Name: "id", DataType: "INT4", NotNull: true, Identity: "ALWAYS"},
+				{Name: "amount", DataType: "DECIMAL(10,2)", DefaultExpr: "0"},
+				{Name: "total", DataType: "numeric", GeneratedExpr: "amount * 2"},
+			}},
+			{SchemaName: "public", TableName: "archived", Inherits: []string{`"orders"`},
+				Columns: []parser.ColumnDef{{Name: "archived_at", DataType: "timestamptz"}}},
+			{SchemaName: "public", TableName: "log", Unlogged: true, PartitionBy: "RANGE (at)"},
+		},
+		Constraints: []parser.ConstraintDef{
+			{Name: "orders_pkey", ConstraintType: "PRIMARY KEY", TableSchema: "public", TableName: "orders",
+				Columns: []string{"id"}},
+			{Name: "orders_fk", ConstraintType: "FOREIGN KEY", TableSchema: "public", TableName: "orders",
+				Columns: []string{"id"}, RefSchema: "public", RefTable: "parents", OnDelete: "SET  null"},
+		},
+		Sequences: []parser.SequenceDef{
+			{SchemaName: "public", SequenceName: "up_seq", DataType: "integer"},
+			{SchemaName: "public", SequenceName: "down_seq", DataType: "smallint", Increment: int64p(-1)},
+		},
+		EnumTypes: []parser.EnumTypeDef{{SchemaName: "public", TypeName: "mood", Labels: []string{"ok"}}},
+		Rules:     []parser.RuleDef{{SchemaName: "public", TableName: "orders", RuleName: "r", Event: "delete"}},
+	}
+}
+
+func TestFromParsedRelations(t *testing.T) {
+	cat := FromParsed(parsedSample())
+
+	if cat.ServerVersion != "17.2" {
+		t.Errorf("ServerVersion = %q", cat.ServerVersion)
+	}
+	names := make([]string, 0, len(cat.Relations))
+	for _, r := range cat.Relations {
+		names = append(names, r.Name)
+	}
+	if want := []string{"archived", "log", "orders"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("relations = %v, want %v", names, want)
+	}
+
+	log := cat.Relation("public", "log")
+	if log.Kind != KindPartitionedTable || log.Persistence != "u" {
+		t.Errorf("log: kind %q persistence %q", log.Kind, log.Persistence)
+	}
+
+	orders := cat.Relation("public", "orders")
+	if got := orders.Column("id").DataType; got != "integer" {
+		t.Errorf("id type = %q, want integer", got)
+	}
+	if got := orders.Column("amount").DataType; got != "numeric(10,2)" {
+		t.Errorf("amount type = %q, want numeric(10,2)", got)
+	}
+	total := orders.Column("total")
+	if total.Generated != "amount * 2" || total.GeneratedKind != "STORED" || total.Default != "" {
+		t.Errorf("total = %+v", total)
+	}
+}
+
+func TestFromParsedInheritance(t *testing.T) {
+	cat := FromParsed(parsedSample())
+	archived := cat.Relation("public", "archived")
+
+	if want := []string{"public.orders"}; !reflect.DeepEqual(archived.Inherits, want) {
+		t.Errorf("Inherits = %v, want %v", archived.Inherits, want)
+	}
+	var cols []string
+	for _, c := range archived.Columns {
+		cols = append(cols, c.Name)
+	}
+	if want := []string{"id", "amount", "total", "archived_at"}; !reflect.DeepEqual(cols, want) {
+		t.Errorf("columns = %v, want %v", cols, want)
+	}
+	if id := archived.Column("id"); id.Identity != "" || !id.NotNull || id.Num != 1 {
+		t.Errorf("inherited id = %+v", id)
+	}
+}
+
+func TestFromParsedConstraintIndexes(t *testing.T) {
+	cat := FromParsed(parsedSample())
+
+	idx := cat.IndexesFor("public", "orders")
+	if len(idx) != 1 || idx[0].Name != "orders_pkey" || !idx[0].Primary || !idx[0].Unique {
+		t.Errorf("indexes = %+v, want the primary key's index", idx)
+	}
+	fk := cat.ConstraintsFor("public", "orders", ForeignKey)
+	if len(fk) != 1 || fk[0].OnDelete != "SET NULL" || fk[0].OnUpdate != "NO ACTION" {
+		t.Errorf("fk = %+v", fk)
+	}
+}
+
+func TestFromParsedReplicaIdentity(t *testing.T) {
+	schema := parsedSample()
+	schema.Tables[0].ReplicaIdentity = "FULL"
+	schema.Tables[1].ReplicaIdentity = "INDEX"
+	schema.Tables[1].ReplicaIdentityIndex = "archived_at_idx"
+	schema.Indexes = []parser.IndexDef{
+		{Name: "archived_at_idx", TableSchema: "public", TableName: "archived",
+			Columns: []string{"archived_at"}, IsUnique: true},
+		{Name: "archived_recent_idx", TableSchema: "public", TableName: "archived",
+			Columns: []string{"archived_at"}, IsUnique: true, Partial: true},
+	}
+	cat := FromParsed(schema)
+
+	for name, want := range map[string]string{"orders": "f", "archived": "i", "log": "d"} {
+		if got := cat.Relation("public", name).ReplicaIdentity; got != want {
+			t.Errorf("%s: replica identity %q, want %q", name, got, want)
+		}
+	}
+	idx := cat.IndexesFor("public", "archived")
+	if len(idx) != 2 || !idx[0].ReplicaIdentity || idx[0].Partial || idx[1].ReplicaIdentity || !idx[1].Partial {
+		t.Errorf("indexes = %+v", idx)
+	}
+}
+
+func TestFromParsedDeferrableAndExpressionIndexes(t *testing.T) {
+	schema := parsedSample()
+	schema.Constraints = append(schema.Constraints, parser.ConstraintDef{
+		Name: "archived_at_key", ConstraintType: "UNIQUE", TableSchema: "public", TableName: "archived",
+		Columns: []string{"archived_at"}, Deferrable: true,
+	})
+	schema.Indexes = []parser.IndexDef{
+		{Name: "archived_day_idx", TableSchema: "public", TableName: "archived",
+			Columns: []string{"date_trunc('day', archived_at)"}, IsUnique: true},
+	}
+	cat := FromParsed(schema)
+
+	indexes := cat.IndexesFor("public", "archived")
+	if len(indexes) != 2 {
+		t.Fatalf("indexes = %+v, want the constraint's and the expression index", indexes)
+	}
+	for _, idx := range indexes {
+		switch idx.Name {
+		case "archived_at_key":
+			if !idx.Deferrable || idx.Expressions {
+				t.Errorf("constraint index = %+v, want deferrable", idx)
+			}
+		case "archived_day_idx":
+			if idx.Deferrable || !idx.Expressions {
+				t.Errorf("expression index = %+v, want expressions", idx)
+			}
+		}
+	}
+}
+
+func TestFromParsedSequenceDefaults(t *testing.T) {
+	cat := FromParsed(parsedSample())
+
+	tests := []struct {
+		name                       string
+		start, inc, minVal, maxVal int64
+	}{
+		{"down_seq", -1, -1, -32768, -1},
+		{"up_seq", 1, 1, 1, 2147483647},
+	}
+	for i, tt := range tests {
+		s := cat.Sequences[i]
+		if s.Name != tt.name {
+			t.Fatalf("sequence %d = %s, want %s", i, s.Name, tt.name)
+		}
+		if *s.Start != tt.start || *s.Increment != tt.inc || *s.MinValue != tt.minVal || *s.MaxValue != tt.maxVal {
+			t.Errorf("%s: start %d inc %d min %d max %d", s.Name, *s.Start, *s.Increment, *s.MinValue, *s.MaxValue)
+		}
+	}
+}
+
+func TestFromParsedTypesAndRules(t *testing.T) {
+	cat := FromParsed(parsedSample())
+
+	if len(cat.Types) != 1 || cat.Types[0].Kind != "e" {
+		t.Errorf("types = %+v", cat.Types)
+	}
+	if len(cat.Rules) != 1 || cat.Rules[0].Event != "DELETE" {
+		t.Errorf("rules = %+v", cat.Rules)
+	}
+}
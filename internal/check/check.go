@@ -23,6 +23,21 @@ type Check interface {
 	Run(ctx context.Context, conn *pgx.Conn) ([]models.Finding, error)
 }
 
+// Estimated is implemented by checks that hint how much work remediating
+// one of their findings takes. Checks that do not implement it are small.
+type Estimated interface {
+	// Effort returns the size of the work to remediate one finding.
+	Effort() models.Effort
+}
+
+// EffortOf returns the effort hint of c, or models.EffortSmall.
+func EffortOf(c Check) models.Effort {
+	if e, ok := c.(Estimated); ok {
+		return e.Effort()
+	}
+	return models.EffortSmall
+}
+
 var registry []Check
 
 // Register adds a check to the global registry. Called from init() in each check file.
@@ -0,0 +1,41 @@
+package check
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pgEdge/mm-ready-go/internal/models"
+)
+
+// sqlStateKinds maps PostgreSQL SQLSTATE codes to error kinds.
+var sqlStateKinds = map[string]models.ErrorKind{
+	"42501": models.ErrorKindPermissionDenied, // insufficient_privilege
+	"42P01": models.ErrorKindUndefinedTable,   // undefined_table
+	"3F000": models.ErrorKindUndefinedTable,   // invalid_schema_name
+	"42883": models.ErrorKindUndefinedTable,   // undefined_function
+	"42704": models.ErrorKindUndefinedTable,   // undefined_object
+	"57014": models.ErrorKindTimeout,          // query_canceled
+}
+
+// ClassifyError derives an ErrorKind from an error returned by Check.Run.
+// PostgreSQL errors are classified by SQLSTATE; context deadline errors are
+// timeouts; anything else is a query error.
+func ClassifyError(err error) models.ErrorKind {
+	if err == nil {
+		return ""
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		if kind, ok := sqlStateKinds[pgErr.Code]; ok {
+			return kind
+		}
+		return models.ErrorKindQueryError
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return models.ErrorKindTimeout
+	}
+	return models.ErrorKindQueryError
+}
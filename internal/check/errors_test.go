@@ -0,0 +1,35 @@
+package check
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pgEdge/mm-ready-go/internal/models"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want models.ErrorKind
+	}{
+		{"nil", nil, ""},
+		{"insufficient privilege", &pgconn.PgError{Code: "42501"}, models.ErrorKindPermissionDenied},
+		{"wrapped undefined table", fmt.Errorf("query: %w", &pgconn.PgError{Code: "42P01"}), models.ErrorKindUndefinedTable},
+		{"undefined function", &pgconn.PgError{Code: "42883"}, models.ErrorKindUndefinedTable},
+		{"query canceled", &pgconn.PgError{Code: "57014"}, models.ErrorKindTimeout},
+		{"other sqlstate", &pgconn.PgError{Code: "22012"}, models.ErrorKindQueryError},
+		{"deadline", fmt.Errorf("scan: %w", context.DeadlineExceeded), models.ErrorKindTimeout},
+		{"plain error", errors.New("boom"), models.ErrorKindQueryError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyError(tt.err); got != tt.want {
+				t.Errorf("ClassifyError() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Prerequisites declares what must be present in the database for a check
+// to produce meaningful results. Empty fields impose no requirement.
+type Prerequisites struct {
+	// Extensions lists extensions that must be installed.
+	Extensions []string
+	// Schemas lists schemas that must exist.
+	Schemas []string
+	// Relations lists schema-qualified tables or views that must exist.
+	Relations []string
+	// MinServerVersion is the minimum server_version_num, e.g. 150000.
+	MinServerVersion int
+	// Roles lists roles the connected user must be a member of,
+	// e.g. pg_read_all_settings.
+	Roles []string
+	// GUCs lists configuration parameters that must be defined. A GUC
+	// owned by a library is only defined once the library is loaded.
+	GUCs []string
+}
+
+// Conditional is implemented by checks that declare prerequisites. Checks
+// whose prerequisites are unmet are skipped instead of run.
+type Conditional interface {
+	// Prerequisites returns what the check needs in order to run.
+	Prerequisites() Prerequisites
+}
+
+// Environment records which prerequisites the connected database satisfies.
+// It is loaded once per run by LoadEnvironment.
+type Environment struct {
+	// ServerVersion is the server_version_num of the connected server.
+	ServerVersion int
+	// Extensions, Schemas, Relations, Roles, and GUCs hold the requested
+	// names that are present.
+	Extensions map[string]bool
+	Schemas    map[string]bool
+	Relations  map[string]bool
+	Roles      map[string]bool
+	GUCs       map[string]bool
+}
+
+// LoadEnvironment queries the database for every prerequisite declared by
+// checks, in one round trip per kind of prerequisite.
+func LoadEnvironment(ctx context.Context, conn *pgx.Conn, checks []Check) (*Environment, error) {
+	var want Prerequisites
+	for _, c := range checks {
+		if cc, ok := c.(Conditional); ok {
+			p := cc.Prerequisites()
+			want.Extensions = append(want.Extensions, p.Extensions...)
+			want.Schemas = append(want.Schemas, p.Schemas...)
+			want.Relations = append(want.Relations, p.Relations...)
+			want.Roles = append(want.Roles, p.Roles...)
+			want.GUCs = append(want.GUCs, p.GUCs...)
+		}
+	}
+
+	env := &Environment{}
+	err := conn.QueryRow(ctx, "SELECT current_setting('server_version_num')::int").Scan(&env.ServerVersion)
+	if err != nil {
+		return nil, fmt.Errorf("query server_version_num: %w", err)
+	}
+
+	lookups := []struct {
+		kind  string
+		names []string
+		into  *map[string]bool
+		query string
+	}{
+		{"extensions", want.Extensions, &env.Extensions,
+			`SELECT extname FROM pg_catalog.pg_extension WHERE extname = ANY($1)`},
+		{"schemas", want.Schemas, &env.Schemas,
+			`SELECT nspname FROM pg_catalog.pg_namespace WHERE nspname = ANY($1)`},
+		{"relations", want.Relations, &env.Relations,
+			`SELECT r FROM unnest($1::text[]) AS r WHERE to_regclass(r) IS NOT NULL`},
+		{"roles", want.Roles, &env.Roles,
+			`SELECT r FROM unnest($1::text[]) AS r
+			 WHERE CASE WHEN EXISTS (SELECT 1 FROM pg_catalog.pg_roles WHERE rolname = r)
+			            THEN pg_has_role(current_user, r, 'USAGE')
+			            ELSE false
+			       END`},
+		{"settings", want.GUCs, &env.GUCs,
+			`SELECT name FROM pg_catalog.pg_settings WHERE name = ANY($1)`},
+	}
+	for _, l := range lookups {
+		*l.into = map[string]bool{}
+		if len(l.names) == 0 {
+			continue
+		}
+		present, err := queryNames(ctx, conn, l.query, l.names)
+		if err != nil {
+			return nil, fmt.Errorf("query %s: %w", l.kind, err)
+		}
+		*l.into = present
+	}
+
+	return env, nil
+}
+
+func queryNames(ctx context.Context, conn *pgx.Conn, query string, names []string) (map[string]bool, error) {
+	rows, err := conn.Query(ctx, query, names)
+	if err != nil {
+		return nil, err
+	}
+	present, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, err
+	}
+	set := make(map[string]bool, len(present))
+	for _, n := range present {
+		set[n] = true
+	}
+	return set, nil
+}
+
+// SkipReason returns why c cannot run in this environment, or "" if c
+// declares no prerequisites or all of them are met.
+func (e *Environment) SkipReason(c Check) string {
+	cc, ok := c.(Conditional)
+	if !ok {
+		return ""
+	}
+	p := cc.Prerequisites()
+
+	var unmet []string
+	if p.MinServerVersion > 0 && e.ServerVersion < p.MinServerVersion {
+		unmet = append(unmet, fmt.Sprintf("requires server_version_num >= %d (server is %d)",
+			p.MinServerVersion, e.ServerVersion))
+	}
+	unmet = appendMissing(unmet, p.Extensions, e.Extensions, "extension %s is not installed")
+	unmet = appendMissing(unmet, p.Schemas, e.Schemas, "schema %s does not exist")
+	unmet = appendMissing(unmet, p.Relations, e.Relations, "relation %s does not exist")
+	unmet = appendMissing(unmet, p.Roles, e.Roles, "current user is not a member of %s")
+	unmet = appendMissing(unmet, p.GUCs, e.GUCs, "setting %s is not defined")
+
+	return strings.Join(unmet, "; ")
+}
+
+func appendMissing(unmet, names []string, present map[string]bool, format string) []string {
+	var missing []string
+	for _, n := range names {
+		if !present[n] {
+			missing = append(missing, fmt.Sprintf(format, n))
+		}
+	}
+	return append(unmet, missing...)
+}
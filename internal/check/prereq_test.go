@@ -0,0 +1,69 @@
+package check
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pgEdge/mm-ready-go/internal/models"
+)
+
+type plainCheck struct{}
+
+func (plainCheck) Name() string        { return "plain" }
+func (plainCheck) Category() string    { return "test" }
+func (plainCheck) Description() string { return "no prerequisites" }
+func (plainCheck) Mode() string        { return "scan" }
+func (plainCheck) Run(context.Context, *pgx.Conn) ([]models.Finding, error) {
+	return nil, nil
+}
+
+type conditionalCheck struct {
+	plainCheck
+	prereqs Prerequisites
+}
+
+func (c conditionalCheck) Prerequisites() Prerequisites { return c.prereqs }
+
+func TestSkipReason(t *testing.T) {
+	env := &Environment{
+		ServerVersion: 150004,
+		Extensions:    map[string]bool{"pg_stat_statements": true},
+		Schemas:       map[string]bool{},
+		Relations:     map[string]bool{"spock.subscription": true},
+		Roles:         map[string]bool{},
+		GUCs:          map[string]bool{},
+	}
+
+	tests := []struct {
+		name  string
+		check Check
+		want  string
+	}{
+		{"no prerequisites", plainCheck{}, ""},
+		{"all met", conditionalCheck{prereqs: Prerequisites{
+			Extensions:       []string{"pg_stat_statements"},
+			Relations:        []string{"spock.subscription"},
+			MinServerVersion: 150000,
+		}}, ""},
+		{"missing extension", conditionalCheck{prereqs: Prerequisites{
+			Extensions: []string{"spock"},
+		}}, "extension spock is not installed"},
+		{"old server", conditionalCheck{prereqs: Prerequisites{
+			MinServerVersion: 160000,
+		}}, "requires server_version_num >= 160000 (server is 150004)"},
+		{"several unmet", conditionalCheck{prereqs: Prerequisites{
+			Schemas: []string{"spock"},
+			Roles:   []string{"pg_read_all_settings"},
+			GUCs:    []string{"pg_stat_statements.max"},
+		}}, "schema spock does not exist; current user is not a member of pg_read_all_settings; " +
+			"setting pg_stat_statements.max is not defined"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := env.SkipReason(tt.check); got != tt.want {
+				t.Errorf("SkipReason() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package check
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pgEdge/mm-ready-go/internal/catalog"
+	"github.com/pgEdge/mm-ready-go/internal/models"
+)
+
+// Structural is implemented by checks that look only at the schema's
+// structure. They inspect a catalog.Catalog, which a scan loads from the live
+// database and analyze builds from a pg_dump file, so both modes report the
+// same findings for the same schema.
+type Structural interface {
+	Check
+	// Inspect returns the findings for the schema in cat.
+	Inspect(cat *catalog.Catalog) []models.Finding
+}
+
+// RunStructural loads the catalog behind conn and inspects it with s.
+// Structural checks use it as their Run method.
+func RunStructural(ctx context.Context, conn *pgx.Conn, s Structural) ([]models.Finding, error) {
+	cat, err := catalog.Get(ctx, conn)
+	if err != nil {
+		return nil, fmt.Errorf("%s catalog load failed: %w", s.Name(), err)
+	}
+	return s.Inspect(cat), nil
+}
+
+// UsesCatalog reports whether any of checks is structural, so that a run
+// needs the catalog loaded.
+func UsesCatalog(checks []Check) bool {
+	for _, c := range checks {
+		if _, ok := c.(Structural); ok {
+			return true
+		}
+	}
+	return false
+}
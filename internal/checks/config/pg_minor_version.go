@@ -31,6 +31,9 @@ func (PgMinorVersionCheck) Description() string {
 // Mode returns when this check runs (scan, audit, or both).
 func (PgMinorVersionCheck) Mode() string { return "audit" }
 
+// Effort hints how much work remediating one finding takes.
+func (PgMinorVersionCheck) Effort() models.Effort { return models.EffortMedium }
+
 // Run executes the check against the database connection.
 func (c PgMinorVersionCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Finding, error) {
 	var fullVersion, serverVersion string
@@ -6,9 +6,11 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/pgEdge/mm-ready-go/internal/catalog"
 	"github.com/pgEdge/mm-ready-go/internal/check"
 	"github.com/pgEdge/mm-ready-go/internal/models"
 )
@@ -41,16 +43,53 @@ func (PgVersionCheck) Description() string { return "PostgreSQL version compatib
 // Mode returns when this check runs (scan, audit, or both).
 func (PgVersionCheck) Mode() string { return "scan" }
 
-// Run executes the check against the database connection.
+// Effort hints how much work remediating one finding takes.
+func (PgVersionCheck) Effort() models.Effort { return models.EffortLarge }
+
+// Run executes the check against the database connection. It reads the
+// version without the shared catalog, whose queries fail on the old servers
+// this check exists to report.
 func (c PgVersionCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Finding, error) {
-	var versionStr string
-	var versionNum int
-	err := conn.QueryRow(ctx, "SELECT version(), current_setting('server_version_num')::int;").Scan(&versionStr, &versionNum)
+	cat, err := catalog.LoadBasic(ctx, conn)
 	if err != nil {
 		return nil, fmt.Errorf("pg_version query failed: %w", err)
 	}
+	return c.Inspect(cat), nil
+}
+
+// Inspect returns the findings for the schema in cat.
+func (c PgVersionCheck) Inspect(cat *catalog.Catalog) []models.Finding {
+	versionStr := cat.ServerVersion
+	if versionStr == "" {
+		return []models.Finding{{
+			Severity:  models.SeverityWarning,
+			CheckName: c.Name(),
+			Category:  c.Category(),
+			Title:     "PostgreSQL version could not be determined",
+			Detail: "The server version is not known, so compatibility with " +
+				"Spock 5 cannot be assessed. pg_dump files record it in the " +
+				"'Dumped from database version' header comment.",
+			ObjectName:  "pg_version",
+			Remediation: "Verify the PostgreSQL version manually.",
+		}}
+	}
 
-	major := versionNum / 10000
+	digits := strings.IndexFunc(versionStr, func(r rune) bool { return r < '0' || r > '9' })
+	if digits < 0 {
+		digits = len(versionStr)
+	}
+	major, err := strconv.Atoi(versionStr[:digits])
+	if err != nil {
+		return []models.Finding{{
+			Severity:    models.SeverityWarning,
+			CheckName:   c.Name(),
+			Category:    c.Category(),
+			Title:       fmt.Sprintf("Unrecognized PostgreSQL version: %s", versionStr),
+			Detail:      fmt.Sprintf("Could not parse major version from '%s'.", versionStr),
+			ObjectName:  "pg_version",
+			Remediation: "Verify the PostgreSQL version manually.",
+		}}
+	}
 
 	sortedMajors := sortedSupportedMajors()
 	majorsList := make([]string, len(sortedMajors))
@@ -59,16 +98,14 @@ func (c PgVersionCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Findi
 	}
 	majorsStr := strings.Join(majorsList, ", ")
 
-	var findings []models.Finding
-
 	if !supportedMajors[major] {
-		findings = append(findings, models.Finding{
+		return []models.Finding{{
 			Severity:  models.SeverityCritical,
 			CheckName: c.Name(),
 			Category:  c.Category(),
 			Title:     fmt.Sprintf("PostgreSQL %d is not supported by Spock 5", major),
 			Detail: fmt.Sprintf(
-				"Server is running PostgreSQL %d (%s). "+
+				"Database runs PostgreSQL %d (%s). "+
 					"Spock 5 supports PostgreSQL versions: %s. "+
 					"A PostgreSQL upgrade is required before Spock can be installed.",
 				major, versionStr, majorsStr,
@@ -78,21 +115,18 @@ func (c PgVersionCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Findi
 				"Upgrade PostgreSQL to version %d (recommended) or any of: %s.",
 				sortedMajors[len(sortedMajors)-1], majorsStr,
 			),
-			Metadata: map[string]any{"major": major, "version_num": versionNum},
-		})
-	} else {
-		findings = append(findings, models.Finding{
-			Severity:   models.SeverityInfo,
-			CheckName:  c.Name(),
-			Category:   c.Category(),
-			Title:      fmt.Sprintf("PostgreSQL %d is supported by Spock 5", major),
-			Detail:     fmt.Sprintf("Server is running %s, which is compatible with Spock 5.", versionStr),
-			ObjectName: "pg_version",
-			Metadata:   map[string]any{"major": major, "version_num": versionNum},
-		})
+			Metadata: map[string]any{"major": major, "version": versionStr},
+		}}
 	}
-
-	return findings, nil
+	return []models.Finding{{
+		Severity:   models.SeverityInfo,
+		CheckName:  c.Name(),
+		Category:   c.Category(),
+		Title:      fmt.Sprintf("PostgreSQL %d is supported by Spock 5", major),
+		Detail:     fmt.Sprintf("Database runs PostgreSQL %s, which is compatible with Spock 5.", versionStr),
+		ObjectName: "pg_version",
+		Metadata:   map[string]any{"major": major, "version": versionStr},
+	}}
 }
 
 func sortedSupportedMajors() []int {
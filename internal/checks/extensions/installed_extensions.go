@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/pgEdge/mm-ready-go/internal/catalog"
 	"github.com/pgEdge/mm-ready-go/internal/check"
 	"github.com/pgEdge/mm-ready-go/internal/models"
 )
@@ -32,6 +33,7 @@ var knownIssues = map[string]string{
 	"citext":             "Supported. Ensure identical versions across nodes.",
 	"lo":                 "Large object helper — consider LOLOR instead for replication.",
 	"pg_stat_statements": "Monitoring extension. Node-local data only.",
+	"pgstattuple":        "Monitoring extension. Node-local data only.",
 	"dblink":             "Cross-database queries are node-local. Review usage.",
 	"postgres_fdw":       "Foreign data wrappers are node-local. Review usage.",
 	"file_fdw":           "Foreign data wrappers are node-local. Review usage.",
@@ -55,59 +57,63 @@ func (InstalledExtensionsCheck) Category() string { return "extensions" }
 // Mode returns when this check runs (scan, audit, or both).
 func (InstalledExtensionsCheck) Mode() string { return "scan" }
 
+// Effort hints how much work remediating one finding takes.
+func (InstalledExtensionsCheck) Effort() models.Effort { return models.EffortMedium }
+
 // Description returns a human-readable summary of this check.
 func (InstalledExtensionsCheck) Description() string {
 	return "Audit installed extensions for known Spock compatibility issues"
 }
 
-// Run executes the check against the database connection.
+// Run executes the check against the database connection. Like pg_version
+// it does not wait on the shared catalog, so that a server too old for the
+// catalog queries still gets its extensions audited.
 func (c InstalledExtensionsCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Finding, error) {
-	const query = `
-		SELECT extname, extversion, n.nspname AS schema_name
-		FROM pg_catalog.pg_extension e
-		JOIN pg_catalog.pg_namespace n ON n.oid = e.extnamespace
-		ORDER BY extname;
-	`
-	rows, err := conn.Query(ctx, query)
+	cat, err := catalog.LoadBasic(ctx, conn)
 	if err != nil {
 		return nil, fmt.Errorf("installed_extensions query failed: %w", err)
 	}
-	defer rows.Close()
+	return c.Inspect(cat), nil
+}
+
+// Inspect returns the findings for the schema in cat.
+func (c InstalledExtensionsCheck) Inspect(cat *catalog.Catalog) []models.Finding {
+	if len(cat.Extensions) == 0 {
+		return nil
+	}
 
 	var findings []models.Finding
 	var extList []string
-	rowCount := 0
 
-	for rows.Next() {
-		var extname, extversion, schemaName string
-		if err := rows.Scan(&extname, &extversion, &schemaName); err != nil {
-			return nil, fmt.Errorf("installed_extensions scan failed: %w", err)
+	for _, ext := range cat.Extensions {
+		// A schema dump does not record extension versions.
+		label, title := ext.Name, fmt.Sprintf("Extension '%s'", ext.Name)
+		if ext.Version != "" {
+			label = fmt.Sprintf("%s (%s)", ext.Name, ext.Version)
+			title = fmt.Sprintf("Extension '%s' v%s", ext.Name, ext.Version)
 		}
-		rowCount++
-		extList = append(extList, fmt.Sprintf("%s (%s)", extname, extversion))
-
-		note, known := knownIssues[extname]
-		if known {
-			sev := models.SeverityInfo
-			remediation := ""
-			if warnExtensions[extname] {
-				sev = models.SeverityWarning
-				remediation = note
-			}
-			findings = append(findings, models.Finding{
-				Severity:    sev,
-				CheckName:   c.Name(),
-				Category:    c.Category(),
-				Title:       fmt.Sprintf("Extension '%s' v%s", extname, extversion),
-				Detail:      note,
-				ObjectName:  extname,
-				Remediation: remediation,
-				Metadata:    map[string]any{"version": extversion, "schema": schemaName},
-			})
+		extList = append(extList, label)
+
+		note, known := knownIssues[ext.Name]
+		if !known {
+			continue
 		}
-	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("installed_extensions rows error: %w", err)
+		sev := models.SeverityInfo
+		remediation := ""
+		if warnExtensions[ext.Name] {
+			sev = models.SeverityWarning
+			remediation = note
+		}
+		findings = append(findings, models.Finding{
+			Severity:    sev,
+			CheckName:   c.Name(),
+			Category:    c.Category(),
+			Title:       title,
+			Detail:      note,
+			ObjectName:  ext.Name,
+			Remediation: remediation,
+			Metadata:    map[string]any{"version": ext.Version, "schema": ext.Schema},
+		})
 	}
 
 	// Summary finding.
@@ -115,11 +121,11 @@ func (c InstalledExtensionsCheck) Run(ctx context.Context, conn *pgx.Conn) ([]mo
 		Severity:    models.SeverityConsider,
 		CheckName:   c.Name(),
 		Category:    c.Category(),
-		Title:       fmt.Sprintf("Installed extensions: %d", rowCount),
+		Title:       fmt.Sprintf("Installed extensions: %d", len(cat.Extensions)),
 		Detail:      "Extensions: " + strings.Join(extList, ", "),
 		ObjectName:  "(extensions)",
 		Remediation: "Ensure all extensions are installed at identical versions on every node.",
 		Metadata:    map[string]any{"extensions": extList},
 	})
-	return findings, nil
+	return findings
 }
@@ -27,6 +27,9 @@ func (LolorCheck) Category() string { return "extensions" }
 // Mode returns when this check runs (scan, audit, or both).
 func (LolorCheck) Mode() string { return "scan" }
 
+// Effort hints how much work remediating one finding takes.
+func (LolorCheck) Effort() models.Effort { return models.EffortMedium }
+
 // Description returns a human-readable summary of this check.
 func (LolorCheck) Description() string {
 	return "LOLOR extension — required for replicating large objects"
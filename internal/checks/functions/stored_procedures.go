@@ -28,6 +28,9 @@ func (StoredProceduresCheck) Category() string { return "functions" }
 // Mode returns when this check runs (scan, audit, or both).
 func (StoredProceduresCheck) Mode() string { return "scan" }
 
+// Effort hints how much work remediating one finding takes.
+func (StoredProceduresCheck) Effort() models.Effort { return models.EffortMedium }
+
 // Description returns a human-readable summary of this check.
 func (StoredProceduresCheck) Description() string {
 	return "Audit stored procedures/functions for write operations and DDL"
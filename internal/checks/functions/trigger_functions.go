@@ -26,6 +26,9 @@ func (TriggerFunctionsCheck) Category() string { return "functions" }
 // Mode returns when this check runs (scan, audit, or both).
 func (TriggerFunctionsCheck) Mode() string { return "scan" }
 
+// Effort hints how much work remediating one finding takes.
+func (TriggerFunctionsCheck) Effort() models.Effort { return models.EffortMedium }
+
 // Description returns a human-readable summary of this check.
 func (TriggerFunctionsCheck) Description() string {
 	return "Triggers — ENABLE REPLICA and ENABLE ALWAYS both fire during Spock apply"
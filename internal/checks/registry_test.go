@@ -5,17 +5,18 @@ import (
 
 	"github.com/pgEdge/mm-ready-go/internal/check"
 	_ "github.com/pgEdge/mm-ready-go/internal/checks" // triggers all init() registrations
+	"github.com/pgEdge/mm-ready-go/internal/models"
 )
 
 func TestTotalCheckCount(t *testing.T) {
 	all := check.AllRegistered()
-	if len(all) != 57 {
+	if len(all) != 61 {
 		// List what we have for debugging
 		cats := make(map[string]int)
 		for _, c := range all {
 			cats[c.Category()]++
 		}
-		t.Errorf("expected 57 checks, got %d. By category: %v", len(all), cats)
+		t.Errorf("expected 61 checks, got %d. By category: %v", len(all), cats)
 	}
 }
 
@@ -34,6 +35,11 @@ func TestAllChecksHaveRequiredFields(t *testing.T) {
 		if mode != "scan" && mode != "audit" && mode != "both" {
 			t.Errorf("check %s has invalid mode %q", c.Name(), mode)
 		}
+		switch effort := check.EffortOf(c); effort {
+		case models.EffortSmall, models.EffortMedium, models.EffortLarge:
+		default:
+			t.Errorf("check %s has invalid effort %q", c.Name(), effort)
+		}
 	}
 }
 
@@ -54,8 +60,8 @@ func TestCategoryCounts(t *testing.T) {
 	}
 	expected := map[string]int{
 		"config":       8,
-		"replication":  12,
-		"schema":       22,
+		"replication":  14,
+		"schema":       24,
 		"extensions":   5,
 		"functions":    3,
 		"sequences":    2,
@@ -103,8 +109,8 @@ func TestGetChecksCategoryFilter(t *testing.T) {
 			t.Errorf("category filter returned check %s with category %q", c.Name(), c.Category())
 		}
 	}
-	if len(checks) != 22 {
-		t.Errorf("expected 22 schema checks, got %d", len(checks))
+	if len(checks) != 24 {
+		t.Errorf("expected 24 schema checks, got %d", len(checks))
 	}
 }
 
@@ -126,8 +132,8 @@ func TestGetChecksSorted(t *testing.T) {
 
 func TestGetChecksEmptyModeReturnsAll(t *testing.T) {
 	all := check.GetChecks("", nil, nil, nil)
-	if len(all) != 57 {
-		t.Errorf("empty mode should return all 57 checks, got %d", len(all))
+	if len(all) != 61 {
+		t.Errorf("empty mode should return all 61 checks, got %d", len(all))
 	}
 }
 
@@ -138,8 +144,8 @@ func TestGetChecksMultipleCategories(t *testing.T) {
 			t.Errorf("multi-category filter returned check %s with category %q", c.Name(), c.Category())
 		}
 	}
-	if len(checks) != 20 {
-		t.Errorf("expected 20 config+replication checks, got %d", len(checks))
+	if len(checks) != 22 {
+		t.Errorf("expected 22 config+replication checks, got %d", len(checks))
 	}
 }
 
@@ -163,8 +169,8 @@ func TestScanAndAuditCountsAddUp(t *testing.T) {
 	}
 
 	total := len(scan) + len(audit) - bothCount
-	if total != 57 {
-		t.Errorf("scan(%d) + audit(%d) - both(%d) = %d, want 57",
+	if total != 61 {
+		t.Errorf("scan(%d) + audit(%d) - both(%d) = %d, want 61",
 			len(scan), len(audit), bothCount, total)
 	}
 }
@@ -0,0 +1,359 @@
+package replication
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pgEdge/mm-ready-go/internal/check"
+	"github.com/pgEdge/mm-ready-go/internal/models"
+)
+
+// CollationConsistencyCheck inventories the collations that order indexed
+// text, and the library versions they depend on.
+type CollationConsistencyCheck struct{}
+
+func init() {
+	check.Register(&CollationConsistencyCheck{})
+}
+
+// Name returns the unique identifier for this check.
+func (c *CollationConsistencyCheck) Name() string { return "collation_consistency" }
+
+// Category returns the check category.
+func (c *CollationConsistencyCheck) Category() string { return "replication" }
+
+// Description returns a human-readable summary of this check.
+func (c *CollationConsistencyCheck) Description() string {
+	return "Collation providers and versions — all Spock nodes must sort text identically"
+}
+
+// Mode returns when this check runs (scan, audit, or both).
+func (c *CollationConsistencyCheck) Mode() string { return "scan" }
+
+// Effort hints how much work remediating one finding takes.
+func (c *CollationConsistencyCheck) Effort() models.Effort { return models.EffortLarge }
+
+// Prerequisites declares what this check needs in order to run.
+func (c *CollationConsistencyCheck) Prerequisites() check.Prerequisites {
+	// datlocprovider and pg_database_collation_actual_version() are new in
+	// PostgreSQL 15, the oldest release Spock 5 supports.
+	return check.Prerequisites{MinServerVersion: 150000}
+}
+
+// collationInfo describes the database default collation or one entry of
+// pg_collation.
+type collationInfo struct {
+	provider string
+	collate  string
+	ctype    string
+	locale   string
+	// version is the collation version recorded when the database or
+	// collation was created or last refreshed; actual is the version the
+	// library reports now. Either is nil when the provider has no versions.
+	version *string
+	actual  *string
+}
+
+// providerName spells out a pg_collation or pg_database provider code.
+func (ci collationInfo) providerName() string {
+	switch ci.provider {
+	case "c":
+		return "libc"
+	case "i":
+		return "icu"
+	case "b":
+		return "builtin"
+	case "d":
+		return "default"
+	default:
+		return ci.provider
+	}
+}
+
+// library returns the operating-system library whose version decides the
+// sort order, or "" when the order is fixed by PostgreSQL itself.
+func (ci collationInfo) library() string {
+	switch ci.provider {
+	case "i":
+		return "ICU"
+	case "c":
+		if isPlainLocale(ci.collate) && isPlainLocale(ci.ctype) {
+			return ""
+		}
+		return "glibc"
+	default:
+		return ""
+	}
+}
+
+// isPlainLocale reports whether a libc locale sorts by byte value.
+func isPlainLocale(name string) bool {
+	return name == "" || name == "C" || name == "POSIX" || strings.HasPrefix(name, "C.")
+}
+
+// mismatch reports whether the library version differs from the recorded one.
+func (ci collationInfo) mismatch() bool {
+	return ci.version != nil && ci.actual != nil && *ci.version != *ci.actual
+}
+
+// localeName names the locale the collation sorts by.
+func (ci collationInfo) localeName() string {
+	if ci.locale != "" {
+		return ci.locale
+	}
+	return ci.collate
+}
+
+func (ci collationInfo) severity() models.Severity {
+	switch {
+	case ci.mismatch():
+		return models.SeverityWarning
+	case ci.library() != "":
+		return models.SeverityConsider
+	default:
+		return models.SeverityInfo
+	}
+}
+
+func (ci collationInfo) metadata() map[string]any {
+	meta := map[string]any{
+		"provider": ci.providerName(),
+		"collate":  ci.collate,
+		"ctype":    ci.ctype,
+	}
+	if ci.locale != "" {
+		meta["locale"] = ci.locale
+	}
+	if ci.version != nil {
+		meta["collversion"] = *ci.version
+	}
+	if ci.actual != nil {
+		meta["actual_version"] = *ci.actual
+	}
+	return meta
+}
+
+// describe summarizes the provider, locale, and versions for a finding.
+func (ci collationInfo) describe() string {
+	s := fmt.Sprintf("provider %s, locale '%s'", ci.providerName(), ci.localeName())
+	if ci.ctype != "" && ci.ctype != ci.collate {
+		s += fmt.Sprintf(", ctype '%s'", ci.ctype)
+	}
+	if ci.version != nil {
+		s += fmt.Sprintf(", recorded version %s", *ci.version)
+	}
+	if ci.actual != nil {
+		s += fmt.Sprintf(", library version %s", *ci.actual)
+	}
+	return s
+}
+
+// consistencyNote explains why every node needs the same library.
+func (ci collationInfo) consistencyNote() string {
+	lib := ci.library()
+	if lib == "" {
+		return "This sort order is built into PostgreSQL and does not depend on " +
+			"the operating system."
+	}
+	return fmt.Sprintf(
+		"This sort order comes from %s, so every Spock node must use the same "+
+			"provider, locale, and %s version. A node whose library sorts "+
+			"differently orders index entries differently: after a failover, "+
+			"unique indexes on text columns can accept duplicates and lookups "+
+			"can miss rows, without any error.", lib, lib)
+}
+
+// Run executes the check against the database connection.
+func (c *CollationConsistencyCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Finding, error) {
+	// The ICU locale column is daticulocale in PostgreSQL 15 and 16 and
+	// datlocale from 17, so it is read through to_jsonb.
+	const dbQuery = `
+		SELECT
+			d.datname,
+			d.datlocprovider::text AS provider,
+			d.datcollate,
+			d.datctype,
+			COALESCE(to_jsonb(d) ->> 'datlocale', to_jsonb(d) ->> 'daticulocale', '') AS locale,
+			d.datcollversion,
+			pg_catalog.pg_database_collation_actual_version(d.oid) AS actual_version
+		FROM pg_catalog.pg_database d
+		WHERE d.datname = current_database();
+	`
+	var dbName string
+	var db collationInfo
+	err := conn.QueryRow(ctx, dbQuery).Scan(&dbName, &db.provider, &db.collate, &db.ctype,
+		&db.locale, &db.version, &db.actual)
+	if err != nil {
+		return nil, fmt.Errorf("collation_consistency database query failed: %w", err)
+	}
+
+	findings := []models.Finding{c.databaseFinding(dbName, db)}
+
+	// Collation 0 is none and 100 is the database default, which the
+	// finding above covers.
+	const collQuery = `
+		WITH uses AS (
+			SELECT
+				a.attcollation AS coll_oid,
+				'column' AS kind,
+				n.nspname || '.' || c.relname || '.' || a.attname AS object_name
+			FROM pg_catalog.pg_attribute a
+			JOIN pg_catalog.pg_class c ON c.oid = a.attrelid
+			JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+			WHERE c.relkind IN ('r', 'p', 'm')
+			  AND a.attnum > 0
+			  AND NOT a.attisdropped
+			  AND a.attcollation NOT IN (0, 100)
+			  AND n.nspname NOT IN ('pg_catalog', 'information_schema', 'spock', 'pg_toast')
+			UNION ALL
+			SELECT
+				k.coll_oid,
+				'index',
+				n.nspname || '.' || ic.relname
+			FROM pg_catalog.pg_index i
+			JOIN pg_catalog.pg_class ic ON ic.oid = i.indexrelid
+			JOIN pg_catalog.pg_namespace n ON n.oid = ic.relnamespace
+			CROSS JOIN LATERAL unnest(i.indcollation::oid[]) AS k(coll_oid)
+			WHERE k.coll_oid NOT IN (0, 100)
+			  AND n.nspname NOT IN ('pg_catalog', 'information_schema', 'spock', 'pg_toast')
+		)
+		SELECT
+			cn.nspname AS collation_schema,
+			co.collname,
+			co.collprovider::text AS provider,
+			COALESCE(co.collcollate, '') AS collcollate,
+			COALESCE(co.collctype, '') AS collctype,
+			COALESCE(to_jsonb(co) ->> 'colllocale', to_jsonb(co) ->> 'colliculocale', '') AS locale,
+			co.collversion,
+			pg_catalog.pg_collation_actual_version(co.oid) AS actual_version,
+			COALESCE(array_agg(DISTINCT u.object_name) FILTER (WHERE u.kind = 'column'), '{}') AS columns,
+			COALESCE(array_agg(DISTINCT u.object_name) FILTER (WHERE u.kind = 'index'), '{}') AS indexes
+		FROM uses u
+		JOIN pg_catalog.pg_collation co ON co.oid = u.coll_oid
+		JOIN pg_catalog.pg_namespace cn ON cn.oid = co.collnamespace
+		GROUP BY co.oid, cn.nspname, co.collname
+		ORDER BY cn.nspname, co.collname;
+	`
+	rows, err := conn.Query(ctx, collQuery)
+	if err != nil {
+		return nil, fmt.Errorf("collation_consistency query failed: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var schemaName, collName string
+		var ci collationInfo
+		var columns, indexes []string
+		if err := rows.Scan(&schemaName, &collName, &ci.provider, &ci.collate, &ci.ctype,
+			&ci.locale, &ci.version, &ci.actual, &columns, &indexes); err != nil {
+			return nil, fmt.Errorf("collation_consistency scan failed: %w", err)
+		}
+		findings = append(findings, c.collationFinding(schemaName, collName, ci, columns, indexes))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("collation_consistency rows iteration failed: %w", err)
+	}
+	return findings, nil
+}
+
+// databaseFinding reports the database default collation and ctype.
+func (c *CollationConsistencyCheck) databaseFinding(dbName string, db collationInfo) models.Finding {
+	f := models.Finding{
+		Severity:   db.severity(),
+		CheckName:  c.Name(),
+		Category:   c.Category(),
+		ObjectName: dbName,
+		Metadata:   db.metadata(),
+	}
+	switch {
+	case db.mismatch():
+		f.Title = fmt.Sprintf("Database '%s' collation version changed from %s to %s",
+			dbName, *db.version, *db.actual)
+		f.Detail = fmt.Sprintf(
+			"The default collation of database '%s' (%s) was recorded at version "+
+				"%s, but the library now reports %s. Indexes on text columns built "+
+				"under the old version may be out of order and must be rebuilt "+
+				"before this node joins a cluster. %s",
+			dbName, db.describe(), *db.version, *db.actual, db.consistencyNote())
+		quoted := pgx.Identifier{dbName}.Sanitize()
+		f.Remediation = fmt.Sprintf(
+			"Rebuild the indexes that use the default collation, then record the "+
+				"new version:\n"+
+				"  REINDEX DATABASE %s;\n"+
+				"  ALTER DATABASE %s REFRESH COLLATION VERSION;\n"+
+				"Make sure every node runs the same %s version.",
+			quoted, quoted, db.library())
+	case db.library() != "":
+		f.Title = fmt.Sprintf("Database '%s' default collation depends on %s (%s)",
+			dbName, db.library(), db.localeName())
+		f.Detail = fmt.Sprintf("Database '%s' uses %s. %s",
+			dbName, db.describe(), db.consistencyNote())
+		f.Remediation = fmt.Sprintf(
+			"Provision every node with the same collation provider and locale, and "+
+				"the same %s version, for example from the same OS image. Compare the "+
+				"collversion reported here on each node before adding it. The C "+
+				"locale or the builtin provider (PostgreSQL 17+) avoids the "+
+				"dependency but changes sort order.", db.library())
+	default:
+		f.Title = fmt.Sprintf("Database '%s' default collation: %s", dbName, db.localeName())
+		f.Detail = fmt.Sprintf("Database '%s' uses %s. %s",
+			dbName, db.describe(), db.consistencyNote())
+	}
+	return f
+}
+
+// collationFinding reports a non-default collation used by columns or indexes.
+func (c *CollationConsistencyCheck) collationFinding(schemaName, collName string, ci collationInfo, columns, indexes []string) models.Finding {
+	name := schemaName + "." + collName
+	var uses []string
+	if len(columns) > 0 {
+		uses = append(uses, fmt.Sprintf("columns %s", strings.Join(columns, ", ")))
+	}
+	if len(indexes) > 0 {
+		uses = append(uses, fmt.Sprintf("indexes %s", strings.Join(indexes, ", ")))
+	}
+	meta := ci.metadata()
+	meta["columns"] = columns
+	meta["indexes"] = indexes
+
+	f := models.Finding{
+		Severity:   ci.severity(),
+		CheckName:  c.Name(),
+		Category:   c.Category(),
+		ObjectName: name,
+		Metadata:   meta,
+	}
+	switch {
+	case ci.mismatch():
+		f.Title = fmt.Sprintf("Collation '%s' version changed from %s to %s", name, *ci.version, *ci.actual)
+		f.Detail = fmt.Sprintf(
+			"Collation '%s' (%s) is used by %s. It was recorded at version %s, "+
+				"but the library now reports %s, so indexes built under the old "+
+				"version may be out of order. %s",
+			name, ci.describe(), strings.Join(uses, " and "), *ci.version, *ci.actual,
+			ci.consistencyNote())
+		f.Remediation = fmt.Sprintf(
+			"Rebuild the indexes that use the collation, then record the new "+
+				"version:\n"+
+				"  REINDEX INDEX <index>;  -- for each index listed\n"+
+				"  ALTER COLLATION %s REFRESH VERSION;\n"+
+				"Make sure every node runs the same %s version.",
+			pgx.Identifier{schemaName, collName}.Sanitize(), ci.library())
+	case ci.library() != "":
+		f.Title = fmt.Sprintf("Collation '%s' depends on %s (%s)", name, ci.library(), ci.localeName())
+		f.Detail = fmt.Sprintf("Collation '%s' (%s) is used by %s. %s",
+			name, ci.describe(), strings.Join(uses, " and "), ci.consistencyNote())
+		f.Remediation = fmt.Sprintf(
+			"Create collation '%s' with the same provider and locale on every node, "+
+				"and run the same %s version everywhere. Compare the collversion "+
+				"reported here on each node before adding it.", name, ci.library())
+	default:
+		f.Title = fmt.Sprintf("Collation '%s' is used by %d column(s) and %d index(es)",
+			name, len(columns), len(indexes))
+		f.Detail = fmt.Sprintf("Collation '%s' (%s) is used by %s. %s",
+			name, ci.describe(), strings.Join(uses, " and "), ci.consistencyNote())
+	}
+	return f
+}
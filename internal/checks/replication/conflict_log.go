@@ -30,34 +30,13 @@ func (c *ConflictLogCheck) Description() string {
 // Mode returns when this check runs (scan, audit, or both).
 func (c *ConflictLogCheck) Mode() string { return "audit" }
 
+// Prerequisites declares what this check needs in order to run.
+func (c *ConflictLogCheck) Prerequisites() check.Prerequisites {
+	return check.Prerequisites{Relations: []string{"spock.conflict_history"}}
+}
+
 // Run executes the check against the database connection.
 func (c *ConflictLogCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Finding, error) {
-	// Check if spock.conflict_history table exists
-	var hasTable bool
-	err := conn.QueryRow(ctx, `
-		SELECT EXISTS (
-			SELECT 1
-			FROM pg_tables
-			WHERE schemaname = 'spock'
-			  AND tablename = 'conflict_history'
-		);
-	`).Scan(&hasTable)
-	if err != nil {
-		hasTable = false
-	}
-
-	if !hasTable {
-		return []models.Finding{{
-			Severity:  models.SeverityInfo,
-			CheckName: c.Name(),
-			Category:  c.Category(),
-			Title:     "No spock.conflict_history table found",
-			Detail: "The spock.conflict_history table does not exist. This is " +
-				"normal if Spock is not installed or conflict logging is not configured.",
-			ObjectName: "spock.conflict_history",
-		}}, nil
-	}
-
 	// Get conflict summary
 	query := `
 		SELECT
@@ -73,14 +52,7 @@ func (c *ConflictLogCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Fi
 	`
 	rows, err := conn.Query(ctx, query)
 	if err != nil {
-		return []models.Finding{{
-			Severity:   models.SeverityWarning,
-			CheckName:  c.Name(),
-			Category:   c.Category(),
-			Title:      "Could not query spock.conflict_history",
-			Detail:     fmt.Sprintf("Error querying conflict log: %v", err),
-			ObjectName: "spock.conflict_history",
-		}}, nil
+		return nil, fmt.Errorf("querying spock.conflict_history: %w", err)
 	}
 	defer rows.Close()
 
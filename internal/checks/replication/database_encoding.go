@@ -30,6 +30,9 @@ func (c *DatabaseEncodingCheck) Description() string {
 // Mode returns when this check runs (scan, audit, or both).
 func (c *DatabaseEncodingCheck) Mode() string { return "scan" }
 
+// Effort hints how much work remediating one finding takes.
+func (c *DatabaseEncodingCheck) Effort() models.Effort { return models.EffortLarge }
+
 // Run executes the check against the database connection.
 func (c *DatabaseEncodingCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Finding, error) {
 	query := `
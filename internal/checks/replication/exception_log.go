@@ -30,34 +30,13 @@ func (c *ExceptionLogCheck) Description() string {
 // Mode returns when this check runs (scan, audit, or both).
 func (c *ExceptionLogCheck) Mode() string { return "audit" }
 
+// Prerequisites declares what this check needs in order to run.
+func (c *ExceptionLogCheck) Prerequisites() check.Prerequisites {
+	return check.Prerequisites{Relations: []string{"spock.exception_log"}}
+}
+
 // Run executes the check against the database connection.
 func (c *ExceptionLogCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Finding, error) {
-	// Check if spock.exception_log table exists
-	var hasTable bool
-	err := conn.QueryRow(ctx, `
-		SELECT EXISTS (
-			SELECT 1
-			FROM pg_tables
-			WHERE schemaname = 'spock'
-			  AND tablename = 'exception_log'
-		);
-	`).Scan(&hasTable)
-	if err != nil {
-		hasTable = false
-	}
-
-	if !hasTable {
-		return []models.Finding{{
-			Severity:  models.SeverityInfo,
-			CheckName: c.Name(),
-			Category:  c.Category(),
-			Title:     "No spock.exception_log table found",
-			Detail: "The spock.exception_log table does not exist. This is " +
-				"normal if Spock is not installed or exception logging is not configured.",
-			ObjectName: "spock.exception_log",
-		}}, nil
-	}
-
 	// Get exception summary
 	query := `
 		SELECT
@@ -73,14 +52,7 @@ func (c *ExceptionLogCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.F
 	`
 	rows, err := conn.Query(ctx, query)
 	if err != nil {
-		return []models.Finding{{
-			Severity:   models.SeverityWarning,
-			CheckName:  c.Name(),
-			Category:   c.Category(),
-			Title:      "Could not query spock.exception_log",
-			Detail:     fmt.Sprintf("Error querying exception log: %v", err),
-			ObjectName: "spock.exception_log",
-		}}, nil
+		return nil, fmt.Errorf("querying spock.exception_log: %w", err)
 	}
 	defer rows.Close()
 
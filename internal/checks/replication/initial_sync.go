@@ -0,0 +1,214 @@
+package replication
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pgEdge/mm-ready-go/internal/catalog"
+	"github.com/pgEdge/mm-ready-go/internal/check"
+	"github.com/pgEdge/mm-ready-go/internal/models"
+)
+
+// largestTables is how many of the largest tables a sizing finding lists.
+const largestTables = 10
+
+// InitialSyncCheck sizes the data a new node copies during initial sync.
+type InitialSyncCheck struct{}
+
+func init() {
+	check.Register(&InitialSyncCheck{})
+}
+
+// Name returns the unique identifier for this check.
+func (c *InitialSyncCheck) Name() string { return models.SyncCheckName }
+
+// Category returns the check category.
+func (c *InitialSyncCheck) Category() string { return "replication" }
+
+// Description returns a human-readable summary of this check.
+func (c *InitialSyncCheck) Description() string {
+	return "Initial sync sizing — data copied when a node is added, per replication set"
+}
+
+// Mode returns when this check runs (scan, audit, or both).
+func (c *InitialSyncCheck) Mode() string { return "both" }
+
+// syncTable is one table's share of the initial sync.
+type syncTable struct {
+	name       string
+	dataBytes  int64
+	indexBytes int64
+}
+
+// syncGroup accumulates the tables of one replication set, or of the total.
+type syncGroup struct {
+	tables     []syncTable
+	dataBytes  int64
+	indexBytes int64
+}
+
+func (g *syncGroup) add(t syncTable) {
+	g.tables = append(g.tables, t)
+	g.dataBytes += t.dataBytes
+	g.indexBytes += t.indexBytes
+}
+
+// largest lists the biggest tables of the group with their data size.
+func (g *syncGroup) largest() []string {
+	var out []string
+	for i, t := range g.tables {
+		if i == largestTables {
+			break
+		}
+		out = append(out, fmt.Sprintf("%s (%s)", t.name, models.FormatBytes(t.dataBytes)))
+	}
+	return out
+}
+
+// metadata returns the sizing metadata read by ScanReport.SyncEstimate.
+func (g *syncGroup) metadata() map[string]any {
+	return map[string]any{
+		"tables":         len(g.tables),
+		"data_bytes":     g.dataBytes,
+		"index_bytes":    g.indexBytes,
+		"largest_tables": g.largest(),
+	}
+}
+
+// Run executes the check against the database connection.
+func (c *InitialSyncCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Finding, error) {
+	var hasSpock bool
+	err := conn.QueryRow(ctx, "SELECT to_regclass('spock.repset_table') IS NOT NULL").Scan(&hasSpock)
+	if err != nil {
+		return nil, fmt.Errorf("checking for spock.repset_table: %w", err)
+	}
+
+	// Without Spock every user table is expected to replicate; with it,
+	// only tables in a replication set are copied.
+	setsExpr := "'{}'::text[]"
+	if hasSpock {
+		setsExpr = `ARRAY(
+				SELECT rs.set_name::text
+				FROM spock.repset_table rt
+				JOIN spock.replication_set rs ON rs.set_id = rt.set_id
+				WHERE rt.set_reloid = c.oid
+				ORDER BY rs.set_name
+			)`
+	}
+	query := fmt.Sprintf(`
+		SELECT
+			n.nspname AS schema_name,
+			c.relname AS table_name,
+			%s + %s AS data_bytes,
+			pg_catalog.pg_indexes_size(c.oid) AS index_bytes,
+			%s AS replication_sets
+		FROM pg_catalog.pg_class c
+		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind = 'r'
+		  AND c.relpersistence = 'p'
+		  AND n.nspname NOT IN ('pg_catalog', 'information_schema', 'spock', 'pg_toast')
+		ORDER BY data_bytes DESC, n.nspname, c.relname;
+	`, catalog.HeapBytesSQL, catalog.ToastBytesSQL, setsExpr)
+
+	rows, err := conn.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("initial_sync query failed: %w", err)
+	}
+	defer rows.Close()
+
+	var total syncGroup
+	sets := make(map[string]*syncGroup)
+	unreplicated := 0
+	for rows.Next() {
+		var schemaName, tableName string
+		var t syncTable
+		var setNames []string
+		if err := rows.Scan(&schemaName, &tableName, &t.dataBytes, &t.indexBytes, &setNames); err != nil {
+			return nil, fmt.Errorf("initial_sync scan failed: %w", err)
+		}
+		t.name = schemaName + "." + tableName
+		if hasSpock && len(setNames) == 0 {
+			unreplicated++
+			continue
+		}
+		total.add(t)
+		for _, name := range setNames {
+			g, ok := sets[name]
+			if !ok {
+				g = &syncGroup{}
+				sets[name] = g
+			}
+			g.add(t)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("initial_sync rows iteration failed: %w", err)
+	}
+
+	if len(total.tables) == 0 {
+		return nil, nil
+	}
+
+	scope := "user table(s)"
+	if hasSpock {
+		scope = "replicated table(s)"
+	}
+	detail := fmt.Sprintf(
+		"A node added to the cluster first copies %d %s: about %s of table "+
+			"data (heap and TOAST), after which %s of indexes are rebuilt on "+
+			"the new node. Largest tables: %s.",
+		len(total.tables), scope, models.FormatBytes(total.dataBytes),
+		models.FormatBytes(total.indexBytes), strings.Join(total.largest(), ", "),
+	)
+	meta := total.metadata()
+	if hasSpock {
+		detail += fmt.Sprintf(" %d user table(s) are in no replication set and are not copied.", unreplicated)
+		meta["unreplicated_tables"] = unreplicated
+	}
+	// Sizes stay out of the titles so that fingerprints survive table growth;
+	// SyncEstimate reads them from the metadata.
+	findings := []models.Finding{{
+		Severity:   models.SeverityInfo,
+		CheckName:  c.Name(),
+		Category:   c.Category(),
+		Title:      fmt.Sprintf("Initial sync copies %d table(s)", len(total.tables)),
+		Detail:     detail,
+		ObjectName: "initial_sync",
+		Remediation: "Set initial_sync.throughput in mm-ready.yaml to the copy rate " +
+			"expected between the nodes to estimate the copy time in the report " +
+			"summary, and schedule the node-add window accordingly.",
+		Metadata: meta,
+	}}
+
+	names := make([]string, 0, len(sets))
+	for name := range sets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		g := sets[name]
+		meta := g.metadata()
+		meta["replication_set"] = name
+		findings = append(findings, models.Finding{
+			Severity:  models.SeverityInfo,
+			CheckName: c.Name(),
+			Category:  c.Category(),
+			Title:     fmt.Sprintf("Replication set '%s' copies %d table(s) during initial sync", name, len(g.tables)),
+			Detail: fmt.Sprintf(
+				"Replication set '%s' holds %d table(s) with about %s of table "+
+					"data and %s of indexes. Largest tables: %s.",
+				name, len(g.tables), models.FormatBytes(g.dataBytes),
+				models.FormatBytes(g.indexBytes), strings.Join(g.largest(), ", "),
+			),
+			ObjectName: name,
+			Remediation: "Tables can be synchronized in batches by replication set; " +
+				"sync the largest sets or tables separately to keep each batch " +
+				"within the maintenance window.",
+			Metadata: meta,
+		})
+	}
+	return findings, nil
+}
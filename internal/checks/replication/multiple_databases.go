@@ -31,6 +31,9 @@ func (c *MultipleDatabasesCheck) Description() string {
 // Mode returns when this check runs (scan, audit, or both).
 func (c *MultipleDatabasesCheck) Mode() string { return "scan" }
 
+// Effort hints how much work remediating one finding takes.
+func (c *MultipleDatabasesCheck) Effort() models.Effort { return models.EffortLarge }
+
 // Run executes the check against the database connection.
 func (c *MultipleDatabasesCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Finding, error) {
 	query := `
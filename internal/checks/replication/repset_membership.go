@@ -30,30 +30,13 @@ func (c *RepsetMembershipCheck) Description() string {
 // Mode returns when this check runs (scan, audit, or both).
 func (c *RepsetMembershipCheck) Mode() string { return "audit" }
 
+// Prerequisites declares what this check needs in order to run.
+func (c *RepsetMembershipCheck) Prerequisites() check.Prerequisites {
+	return check.Prerequisites{Relations: []string{"spock.repset_table"}}
+}
+
 // Run executes the check against the database connection.
 func (c *RepsetMembershipCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Finding, error) {
-	// Check if spock schema exists
-	var hasSpock bool
-	err := conn.QueryRow(ctx, `
-		SELECT EXISTS (
-			SELECT 1 FROM pg_namespace WHERE nspname = 'spock'
-		);
-	`).Scan(&hasSpock)
-	if err != nil {
-		hasSpock = false
-	}
-
-	if !hasSpock {
-		return []models.Finding{{
-			Severity:   models.SeverityInfo,
-			CheckName:  c.Name(),
-			Category:   c.Category(),
-			Title:      "Spock schema not found — skipping repset membership check",
-			Detail:     "The spock schema does not exist in this database.",
-			ObjectName: "spock",
-		}}, nil
-	}
-
 	// Find user tables not in any replication set
 	query := `
 		SELECT
@@ -72,14 +55,7 @@ func (c *RepsetMembershipCheck) Run(ctx context.Context, conn *pgx.Conn) ([]mode
 	`
 	rows, err := conn.Query(ctx, query)
 	if err != nil {
-		return []models.Finding{{
-			Severity:   models.SeverityWarning,
-			CheckName:  c.Name(),
-			Category:   c.Category(),
-			Title:      "Could not query spock.repset_table",
-			Detail:     fmt.Sprintf("Error querying replication set membership: %v", err),
-			ObjectName: "spock.repset_table",
-		}}, nil
+		return nil, fmt.Errorf("querying spock.repset_table: %w", err)
 	}
 	defer rows.Close()
 
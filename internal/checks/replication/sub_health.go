@@ -30,30 +30,13 @@ func (c *SubscriptionHealthCheck) Description() string {
 // Mode returns when this check runs (scan, audit, or both).
 func (c *SubscriptionHealthCheck) Mode() string { return "audit" }
 
+// Prerequisites declares what this check needs in order to run.
+func (c *SubscriptionHealthCheck) Prerequisites() check.Prerequisites {
+	return check.Prerequisites{Relations: []string{"spock.subscription"}}
+}
+
 // Run executes the check against the database connection.
 func (c *SubscriptionHealthCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Finding, error) {
-	// Check if spock schema exists
-	var hasSpock bool
-	err := conn.QueryRow(ctx, `
-		SELECT EXISTS (
-			SELECT 1 FROM pg_namespace WHERE nspname = 'spock'
-		);
-	`).Scan(&hasSpock)
-	if err != nil {
-		hasSpock = false
-	}
-
-	if !hasSpock {
-		return []models.Finding{{
-			Severity:   models.SeverityInfo,
-			CheckName:  c.Name(),
-			Category:   c.Category(),
-			Title:      "Spock schema not found — skipping subscription health check",
-			Detail:     "The spock schema does not exist in this database.",
-			ObjectName: "spock",
-		}}, nil
-	}
-
 	// Query subscription status
 	query := `
 		SELECT
@@ -67,14 +50,7 @@ func (c *SubscriptionHealthCheck) Run(ctx context.Context, conn *pgx.Conn) ([]mo
 	`
 	rows, err := conn.Query(ctx, query)
 	if err != nil {
-		return []models.Finding{{
-			Severity:   models.SeverityWarning,
-			CheckName:  c.Name(),
-			Category:   c.Category(),
-			Title:      "Could not query spock.subscription",
-			Detail:     fmt.Sprintf("Error querying subscriptions: %v", err),
-			ObjectName: "spock.subscription",
-		}}, nil
+		return nil, fmt.Errorf("querying spock.subscription: %w", err)
 	}
 	defer rows.Close()
 
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/pgEdge/mm-ready-go/internal/catalog"
 	"github.com/pgEdge/mm-ready-go/internal/check"
 	"github.com/pgEdge/mm-ready-go/internal/models"
 )
@@ -28,6 +29,9 @@ func (ColumnDefaultsCheck) Category() string { return "schema" }
 // Mode returns when this check runs (scan, audit, or both).
 func (ColumnDefaultsCheck) Mode() string { return "scan" }
 
+// Effort hints how much work remediating one finding takes.
+func (ColumnDefaultsCheck) Effort() models.Effort { return models.EffortMedium }
+
 // Description returns a human-readable summary of this check.
 func (ColumnDefaultsCheck) Description() string {
 	return "Volatile column defaults (now(), random(), etc.) — may differ across nodes"
@@ -43,80 +47,58 @@ var volatilePatterns = []string{
 
 // Run executes the check against the database connection.
 func (c ColumnDefaultsCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Finding, error) {
-	const sqlQuery = `
-		SELECT
-			n.nspname AS schema_name,
-			c.relname AS table_name,
-			a.attname AS column_name,
-			pg_get_expr(d.adbin, d.adrelid) AS default_expr
-		FROM pg_catalog.pg_attrdef d
-		JOIN pg_catalog.pg_attribute a ON a.attrelid = d.adrelid AND a.attnum = d.adnum
-		JOIN pg_catalog.pg_class c ON c.oid = d.adrelid
-		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
-		WHERE c.relkind = 'r'
-		  AND NOT a.attisdropped
-		  AND a.attgenerated = ''
-		  AND n.nspname NOT IN ('pg_catalog', 'information_schema', 'spock', 'pg_toast')
-		ORDER BY n.nspname, c.relname, a.attname;
-	`
-
-	rows, err := conn.Query(ctx, sqlQuery)
-	if err != nil {
-		return nil, fmt.Errorf("column_defaults query failed: %w", err)
-	}
-	defer rows.Close()
+	return check.RunStructural(ctx, conn, c)
+}
+
+// Inspect returns the findings for the schema in cat.
+func (c ColumnDefaultsCheck) Inspect(cat *catalog.Catalog) []models.Finding {
 
 	var findings []models.Finding
-	for rows.Next() {
-		var schemaName, tableName, colName string
-		var defaultExpr *string
-		if err := rows.Scan(&schemaName, &tableName, &colName, &defaultExpr); err != nil {
-			return nil, fmt.Errorf("column_defaults scan failed: %w", err)
-		}
-		if defaultExpr == nil {
-			continue
-		}
-		exprLower := strings.ToLower(*defaultExpr)
+	for _, t := range cat.Tables() {
+		for _, col := range t.ColumnsByName() {
+			if col.Default == "" {
+				continue
+			}
+			defaultExpr, colName := col.Default, col.Name
+			exprLower := strings.ToLower(defaultExpr)
 
-		// Skip nextval — handled by sequence_pks check
-		if strings.Contains(exprLower, "nextval(") {
-			continue
-		}
+			// Skip nextval — handled by sequence_pks check
+			if strings.Contains(exprLower, "nextval(") {
+				continue
+			}
 
-		matched := false
-		for _, p := range volatilePatterns {
-			if strings.Contains(exprLower, p) {
-				matched = true
-				break
+			matched := false
+			for _, p := range volatilePatterns {
+				if strings.Contains(exprLower, p) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				continue
 			}
-		}
-		if !matched {
-			continue
-		}
 
-		fqn := schemaName + "." + tableName
-		findings = append(findings, models.Finding{
-			Severity:  models.SeverityConsider,
-			CheckName: c.Name(),
-			Category:  c.Category(),
-			Title:     fmt.Sprintf("Volatile default on '%s.%s'", fqn, colName),
-			Detail: fmt.Sprintf(
-				"Column '%s' on table '%s' has a volatile default: "+
-					"%s. In multi-master replication, if a row is inserted "+
-					"without specifying this column, each node could compute a different "+
-					"default value. However, Spock replicates the actual inserted value, "+
-					"so this is only an issue if the same row is independently inserted "+
-					"on multiple nodes.",
-				colName, fqn, *defaultExpr,
-			),
-			ObjectName: fmt.Sprintf("%s.%s", fqn, colName),
-			Remediation: "Ensure the application always provides an explicit value for this column, " +
-				"or accept that conflict resolution may be needed for concurrent inserts.",
-			Metadata: map[string]any{"default_expr": *defaultExpr},
-		})
-	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("column_defaults rows iteration failed: %w", err)
+			fqn := t.QualifiedName()
+			findings = append(findings, models.Finding{
+				Severity:  models.SeverityConsider,
+				CheckName: c.Name(),
+				Category:  c.Category(),
+				Title:     fmt.Sprintf("Volatile default on '%s.%s'", fqn, colName),
+				Detail: fmt.Sprintf(
+					"Column '%s' on table '%s' has a volatile default: "+
+						"%s. In multi-master replication, if a row is inserted "+
+						"without specifying this column, each node could compute a different "+
+						"default value. However, Spock replicates the actual inserted value, "+
+						"so this is only an issue if the same row is independently inserted "+
+						"on multiple nodes.",
+					colName, fqn, defaultExpr,
+				),
+				ObjectName: fmt.Sprintf("%s.%s", fqn, colName),
+				Remediation: "Ensure the application always provides an explicit value for this column, " +
+					"or accept that conflict resolution may be needed for concurrent inserts.",
+				Metadata: map[string]any{"default_expr": defaultExpr},
+			})
+		}
 	}
-	return findings, nil
+	return findings
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/pgEdge/mm-ready-go/internal/catalog"
 	"github.com/pgEdge/mm-ready-go/internal/check"
 	"github.com/pgEdge/mm-ready-go/internal/models"
 )
@@ -26,6 +27,9 @@ func (DeferrableConstraintsCheck) Category() string { return "schema" }
 // Mode returns when this check runs (scan, audit, or both).
 func (DeferrableConstraintsCheck) Mode() string { return "scan" }
 
+// Effort hints how much work remediating one finding takes.
+func (DeferrableConstraintsCheck) Effort() models.Effort { return models.EffortMedium }
+
 // Description returns a human-readable summary of this check.
 func (DeferrableConstraintsCheck) Description() string {
 	return "Deferrable unique/PK constraints — silently skipped by Spock conflict resolution"
@@ -33,54 +37,25 @@ func (DeferrableConstraintsCheck) Description() string {
 
 // Run executes the check against the database connection.
 func (c DeferrableConstraintsCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Finding, error) {
-	const sqlQuery = `
-		SELECT
-			n.nspname AS schema_name,
-			c.relname AS table_name,
-			con.conname AS constraint_name,
-			con.contype::text AS constraint_type,
-			con.condeferrable AS is_deferrable,
-			con.condeferred AS is_deferred
-		FROM pg_catalog.pg_constraint con
-		JOIN pg_catalog.pg_class c ON c.oid = con.conrelid
-		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
-		WHERE con.contype IN ('p', 'u')
-		  AND con.condeferrable = true
-		  AND n.nspname NOT IN ('pg_catalog', 'information_schema', 'spock', 'pg_toast')
-		ORDER BY n.nspname, c.relname, con.conname;
-	`
-
-	typeLabels := map[string]string{
-		"p": "PRIMARY KEY",
-		"u": "UNIQUE",
-	}
-
-	rows, err := conn.Query(ctx, sqlQuery)
-	if err != nil {
-		return nil, fmt.Errorf("deferrable_constraints query failed: %w", err)
-	}
-	defer rows.Close()
+	return check.RunStructural(ctx, conn, c)
+}
 
+// Inspect returns the findings for the schema in cat.
+func (c DeferrableConstraintsCheck) Inspect(cat *catalog.Catalog) []models.Finding {
 	var findings []models.Finding
-	for rows.Next() {
-		var schemaName, tableName, conName, conType string
-		var isDeferrable, isDeferred bool
-		if err := rows.Scan(&schemaName, &tableName, &conName, &conType, &isDeferrable, &isDeferred); err != nil {
-			return nil, fmt.Errorf("deferrable_constraints scan failed: %w", err)
-		}
-		fqn := schemaName + "." + tableName
-		conLabel := typeLabels[conType]
-		if conLabel == "" {
-			conLabel = conType
+	for _, con := range cat.Constraints {
+		if !con.Deferrable || (con.Type != catalog.PrimaryKey && con.Type != catalog.Unique) {
+			continue
 		}
+		fqn := con.Schema + "." + con.Table
 
 		severity := models.SeverityWarning
-		if conType == "p" {
+		if con.Type == catalog.PrimaryKey {
 			severity = models.SeverityCritical
 		}
 
 		initiallyStr := "IMMEDIATE"
-		if isDeferred {
+		if con.InitiallyDeferred {
 			initiallyStr = "DEFERRED"
 		}
 
@@ -88,7 +63,7 @@ func (c DeferrableConstraintsCheck) Run(ctx context.Context, conn *pgx.Conn) ([]
 			Severity:  severity,
 			CheckName: c.Name(),
 			Category:  c.Category(),
-			Title:     fmt.Sprintf("Deferrable %s '%s' on '%s'", conLabel, conName, fqn),
+			Title:     fmt.Sprintf("Deferrable %s '%s' on '%s'", con.Type, con.Name, fqn),
 			Detail: fmt.Sprintf(
 				"Table '%s' has a DEFERRABLE %s constraint "+
 					"'%s' (initially %s). "+
@@ -97,24 +72,21 @@ func (c DeferrableConstraintsCheck) Run(ctx context.Context, conn *pgx.Conn) ([]
 					"indexes. This means conflicts on this constraint will NOT be "+
 					"detected during replication apply, potentially causing "+
 					"duplicate key violations or data inconsistencies.",
-				fqn, conLabel, conName, initiallyStr,
+				fqn, con.Type, con.Name, initiallyStr,
 			),
-			ObjectName: fmt.Sprintf("%s.%s", fqn, conName),
+			ObjectName: fmt.Sprintf("%s.%s", fqn, con.Name),
 			Remediation: fmt.Sprintf(
 				"If possible, make the constraint non-deferrable:\n"+
 					"  ALTER TABLE %s ALTER CONSTRAINT %s NOT DEFERRABLE;\n"+
 					"If deferral is required by the application, be aware that Spock "+
 					"will not use this constraint for conflict detection.",
-				fqn, conName,
+				fqn, con.Name,
 			),
 			Metadata: map[string]any{
-				"constraint_type":    conLabel,
-				"initially_deferred": isDeferred,
+				"constraint_type":    con.Type,
+				"initially_deferred": con.InitiallyDeferred,
 			},
 		})
 	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("deferrable_constraints rows iteration failed: %w", err)
-	}
-	return findings, nil
+	return findings
 }
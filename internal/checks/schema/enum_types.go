@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/pgEdge/mm-ready-go/internal/catalog"
 	"github.com/pgEdge/mm-ready-go/internal/check"
 	"github.com/pgEdge/mm-ready-go/internal/models"
 )
@@ -34,33 +35,18 @@ func (EnumTypesCheck) Description() string {
 
 // Run executes the check against the database connection.
 func (c EnumTypesCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Finding, error) {
-	const sqlQuery = `
-		SELECT
-			n.nspname AS schema_name,
-			t.typname AS type_name,
-			array_agg(e.enumlabel ORDER BY e.enumsortorder) AS labels
-		FROM pg_catalog.pg_type t
-		JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace
-		JOIN pg_catalog.pg_enum e ON e.enumtypid = t.oid
-		WHERE n.nspname NOT IN ('pg_catalog', 'information_schema', 'spock', 'pg_toast')
-		GROUP BY n.nspname, t.typname
-		ORDER BY n.nspname, t.typname;
-	`
-
-	rows, err := conn.Query(ctx, sqlQuery)
-	if err != nil {
-		return nil, fmt.Errorf("enum_types query failed: %w", err)
-	}
-	defer rows.Close()
+	return check.RunStructural(ctx, conn, c)
+}
 
+// Inspect returns the findings for the schema in cat.
+func (c EnumTypesCheck) Inspect(cat *catalog.Catalog) []models.Finding {
 	var findings []models.Finding
-	for rows.Next() {
-		var schemaName, typeName string
-		var labels []string
-		if err := rows.Scan(&schemaName, &typeName, &labels); err != nil {
-			return nil, fmt.Errorf("enum_types scan failed: %w", err)
+	for _, ty := range cat.Types {
+		if ty.Kind != "e" {
+			continue
 		}
-		fqn := schemaName + "." + typeName
+		fqn := ty.Schema + "." + ty.Name
+		labels := ty.Labels
 		labelCount := len(labels)
 
 		displayLabels := labels
@@ -97,8 +83,5 @@ func (c EnumTypesCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Findi
 			Metadata: map[string]any{"label_count": labelCount, "labels": metaLabels},
 		})
 	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("enum_types rows iteration failed: %w", err)
-	}
-	return findings, nil
+	return findings
 }
@@ -26,6 +26,9 @@ func (EventTriggersCheck) Category() string { return "schema" }
 // Mode returns when this check runs (scan, audit, or both).
 func (EventTriggersCheck) Mode() string { return "scan" }
 
+// Effort hints how much work remediating one finding takes.
+func (EventTriggersCheck) Effort() models.Effort { return models.EffortMedium }
+
 // Description returns a human-readable summary of this check.
 func (EventTriggersCheck) Description() string {
 	return "Event triggers — fire on DDL events, may interact with Spock DDL replication"
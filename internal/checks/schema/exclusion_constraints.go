@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/pgEdge/mm-ready-go/internal/catalog"
 	"github.com/pgEdge/mm-ready-go/internal/check"
 	"github.com/pgEdge/mm-ready-go/internal/models"
 )
@@ -26,6 +27,9 @@ func (ExclusionConstraintsCheck) Category() string { return "schema" }
 // Mode returns when this check runs (scan, audit, or both).
 func (ExclusionConstraintsCheck) Mode() string { return "scan" }
 
+// Effort hints how much work remediating one finding takes.
+func (ExclusionConstraintsCheck) Effort() models.Effort { return models.EffortLarge }
+
 // Description returns a human-readable summary of this check.
 func (ExclusionConstraintsCheck) Description() string {
 	return "Exclusion constraints — not enforceable across Spock nodes"
@@ -33,53 +37,32 @@ func (ExclusionConstraintsCheck) Description() string {
 
 // Run executes the check against the database connection.
 func (c ExclusionConstraintsCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Finding, error) {
-	const sqlQuery = `
-		SELECT
-			n.nspname AS schema_name,
-			c.relname AS table_name,
-			con.conname AS constraint_name
-		FROM pg_catalog.pg_constraint con
-		JOIN pg_catalog.pg_class c ON c.oid = con.conrelid
-		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
-		WHERE con.contype = 'x'
-		  AND n.nspname NOT IN ('pg_catalog', 'information_schema', 'spock', 'pg_toast')
-		ORDER BY n.nspname, c.relname, con.conname;
-	`
-
-	rows, err := conn.Query(ctx, sqlQuery)
-	if err != nil {
-		return nil, fmt.Errorf("exclusion_constraints query failed: %w", err)
-	}
-	defer rows.Close()
+	return check.RunStructural(ctx, conn, c)
+}
 
+// Inspect returns the findings for the schema in cat.
+func (c ExclusionConstraintsCheck) Inspect(cat *catalog.Catalog) []models.Finding {
 	var findings []models.Finding
-	for rows.Next() {
-		var schemaName, tableName, constraintName string
-		if err := rows.Scan(&schemaName, &tableName, &constraintName); err != nil {
-			return nil, fmt.Errorf("exclusion_constraints scan failed: %w", err)
-		}
-		fqn := schemaName + "." + tableName
+	for _, con := range cat.ConstraintsOfType(catalog.Exclude) {
+		fqn := con.Schema + "." + con.Table
 		findings = append(findings, models.Finding{
 			Severity:  models.SeverityWarning,
 			CheckName: c.Name(),
 			Category:  c.Category(),
-			Title:     fmt.Sprintf("Exclusion constraint '%s' on '%s'", constraintName, fqn),
+			Title:     fmt.Sprintf("Exclusion constraint '%s' on '%s'", con.Name, fqn),
 			Detail: fmt.Sprintf(
 				"Table '%s' has exclusion constraint '%s'. "+
 					"Exclusion constraints are evaluated locally on each node. In a "+
 					"multi-master topology, two nodes could independently accept rows "+
 					"that would violate the exclusion constraint if evaluated globally, "+
 					"leading to replication conflicts or data inconsistencies.",
-				fqn, constraintName,
+				fqn, con.Name,
 			),
-			ObjectName: fmt.Sprintf("%s.%s", fqn, constraintName),
+			ObjectName: fmt.Sprintf("%s.%s", fqn, con.Name),
 			Remediation: "Review whether this exclusion constraint can be replaced with " +
 				"application-level logic, or ensure that only one node writes data " +
 				"that could conflict under this constraint.",
 		})
 	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("exclusion_constraints rows iteration failed: %w", err)
-	}
-	return findings, nil
+	return findings
 }
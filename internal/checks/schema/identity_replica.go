@@ -26,6 +26,9 @@ func (UpdateDeleteNoPkCheck) Category() string { return "schema" }
 // Mode returns when this check runs (scan, audit, or both).
 func (UpdateDeleteNoPkCheck) Mode() string { return "scan" }
 
+// Effort hints how much work remediating one finding takes.
+func (UpdateDeleteNoPkCheck) Effort() models.Effort { return models.EffortMedium }
+
 // Description returns a human-readable summary of this check.
 func (UpdateDeleteNoPkCheck) Description() string {
 	return "Tables without primary keys that have UPDATE/DELETE activity — " +
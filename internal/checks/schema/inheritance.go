@@ -4,8 +4,10 @@ package schema
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/pgEdge/mm-ready-go/internal/catalog"
 	"github.com/pgEdge/mm-ready-go/internal/check"
 	"github.com/pgEdge/mm-ready-go/internal/models"
 )
@@ -26,6 +28,9 @@ func (InheritanceCheck) Category() string { return "schema" }
 // Mode returns when this check runs (scan, audit, or both).
 func (InheritanceCheck) Mode() string { return "scan" }
 
+// Effort hints how much work remediating one finding takes.
+func (InheritanceCheck) Effort() models.Effort { return models.EffortLarge }
+
 // Description returns a human-readable summary of this check.
 func (InheritanceCheck) Description() string {
 	return "Table inheritance (non-partition) — not well supported in logical replication"
@@ -33,58 +38,40 @@ func (InheritanceCheck) Description() string {
 
 // Run executes the check against the database connection.
 func (c InheritanceCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Finding, error) {
-	const sqlQuery = `
-		SELECT
-			pn.nspname AS parent_schema,
-			pc.relname AS parent_table,
-			cn.nspname AS child_schema,
-			cc.relname AS child_table
-		FROM pg_catalog.pg_inherits i
-		JOIN pg_catalog.pg_class pc ON pc.oid = i.inhparent
-		JOIN pg_catalog.pg_namespace pn ON pn.oid = pc.relnamespace
-		JOIN pg_catalog.pg_class cc ON cc.oid = i.inhrelid
-		JOIN pg_catalog.pg_namespace cn ON cn.oid = cc.relnamespace
-		WHERE pc.relkind = 'r'  -- exclude partitioned tables (relkind='p')
-		  AND cc.relkind = 'r'
-		  AND pn.nspname NOT IN ('pg_catalog', 'information_schema', 'spock', 'pg_toast')
-		ORDER BY pn.nspname, pc.relname, cn.nspname, cc.relname;
-	`
-
-	rows, err := conn.Query(ctx, sqlQuery)
-	if err != nil {
-		return nil, fmt.Errorf("inheritance query failed: %w", err)
-	}
-	defer rows.Close()
+	return check.RunStructural(ctx, conn, c)
+}
 
+// Inspect returns the findings for the schema in cat.
+func (c InheritanceCheck) Inspect(cat *catalog.Catalog) []models.Finding {
 	var findings []models.Finding
-	for rows.Next() {
-		var parentSchema, parentTable, childSchema, childTable string
-		if err := rows.Scan(&parentSchema, &parentTable, &childSchema, &childTable); err != nil {
-			return nil, fmt.Errorf("inheritance scan failed: %w", err)
+	for _, t := range cat.Tables() {
+		childFQN := t.QualifiedName()
+		for _, parentFQN := range t.Inherits {
+			// Only inheritance between ordinary tables; partitioning is
+			// covered by partitioned_tables.
+			parentSchema, parentTable, _ := strings.Cut(parentFQN, ".")
+			if p := cat.Relation(parentSchema, parentTable); p == nil || p.Kind != catalog.KindTable {
+				continue
+			}
+			findings = append(findings, models.Finding{
+				Severity:  models.SeverityWarning,
+				CheckName: c.Name(),
+				Category:  c.Category(),
+				Title:     fmt.Sprintf("Table inheritance: '%s' inherits from '%s'", childFQN, parentFQN),
+				Detail: fmt.Sprintf(
+					"Table '%s' uses traditional table inheritance from "+
+						"'%s'. Logical replication does not replicate through "+
+						"inheritance hierarchies — each table is replicated independently. "+
+						"Queries against the parent that include child data via inheritance "+
+						"may behave differently across nodes.",
+					childFQN, parentFQN,
+				),
+				ObjectName: childFQN,
+				Remediation: "Consider migrating from table inheritance to declarative partitioning " +
+					"(if appropriate) or separate standalone tables.",
+				Metadata: map[string]any{"parent": parentFQN},
+			})
 		}
-		parentFQN := parentSchema + "." + parentTable
-		childFQN := childSchema + "." + childTable
-		findings = append(findings, models.Finding{
-			Severity:  models.SeverityWarning,
-			CheckName: c.Name(),
-			Category:  c.Category(),
-			Title:     fmt.Sprintf("Table inheritance: '%s' inherits from '%s'", childFQN, parentFQN),
-			Detail: fmt.Sprintf(
-				"Table '%s' uses traditional table inheritance from "+
-					"'%s'. Logical replication does not replicate through "+
-					"inheritance hierarchies — each table is replicated independently. "+
-					"Queries against the parent that include child data via inheritance "+
-					"may behave differently across nodes.",
-				childFQN, parentFQN,
-			),
-			ObjectName: childFQN,
-			Remediation: "Consider migrating from table inheritance to declarative partitioning " +
-				"(if appropriate) or separate standalone tables.",
-			Metadata: map[string]any{"parent": parentFQN},
-		})
-	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("inheritance rows iteration failed: %w", err)
 	}
-	return findings, nil
+	return findings
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/pgEdge/mm-ready-go/internal/catalog"
 	"github.com/pgEdge/mm-ready-go/internal/check"
 	"github.com/pgEdge/mm-ready-go/internal/models"
 )
@@ -26,6 +27,9 @@ func (LargeObjectsCheck) Category() string { return "schema" }
 // Mode returns when this check runs (scan, audit, or both).
 func (LargeObjectsCheck) Mode() string { return "scan" }
 
+// Effort hints how much work remediating one finding takes.
+func (LargeObjectsCheck) Effort() models.Effort { return models.EffortMedium }
+
 // Description returns a human-readable summary of this check.
 func (LargeObjectsCheck) Description() string {
 	return "Large object (LOB) usage — logical decoding does not support them"
@@ -33,16 +37,14 @@ func (LargeObjectsCheck) Description() string {
 
 // Run executes the check against the database connection.
 func (c LargeObjectsCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Finding, error) {
-	var findings []models.Finding
+	return check.RunStructural(ctx, conn, c)
+}
 
-	// Check if any large objects exist
-	var lobCount int
-	err := conn.QueryRow(ctx, "SELECT count(*) FROM pg_catalog.pg_largeobject_metadata;").Scan(&lobCount)
-	if err != nil {
-		return nil, fmt.Errorf("large_objects count query failed: %w", err)
-	}
+// Inspect returns the findings for the schema in cat.
+func (c LargeObjectsCheck) Inspect(cat *catalog.Catalog) []models.Finding {
+	var findings []models.Finding
 
-	if lobCount > 0 {
+	if lobCount := cat.LargeObjects; lobCount > 0 {
 		findings = append(findings, models.Finding{
 			Severity:  models.SeverityWarning,
 			CheckName: c.Name(),
@@ -68,54 +70,30 @@ func (c LargeObjectsCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Fi
 	}
 
 	// Also check for columns using OID type (commonly used with large objects)
-	const oidQuery = `
-		SELECT
-			n.nspname AS schema_name,
-			c.relname AS table_name,
-			a.attname AS column_name
-		FROM pg_catalog.pg_attribute a
-		JOIN pg_catalog.pg_class c ON c.oid = a.attrelid
-		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
-		WHERE c.relkind = 'r'
-		  AND a.attnum > 0
-		  AND NOT a.attisdropped
-		  AND n.nspname NOT IN ('pg_catalog', 'information_schema', 'spock', 'pg_toast')
-		  AND a.atttypid = 'oid'::regtype
-		ORDER BY n.nspname, c.relname, a.attname;
-	`
-
-	rows, err := conn.Query(ctx, oidQuery)
-	if err != nil {
-		return nil, fmt.Errorf("large_objects oid query failed: %w", err)
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var schemaName, tableName, colName string
-		if err := rows.Scan(&schemaName, &tableName, &colName); err != nil {
-			return nil, fmt.Errorf("large_objects oid scan failed: %w", err)
+	for _, t := range cat.Tables() {
+		fqn := t.QualifiedName()
+		for _, col := range t.ColumnsByName() {
+			if col.BaseType() != "oid" {
+				continue
+			}
+			findings = append(findings, models.Finding{
+				Severity:  models.SeverityWarning,
+				CheckName: c.Name(),
+				Category:  c.Category(),
+				Title:     fmt.Sprintf("OID column '%s.%s' may reference large objects", fqn, col.Name),
+				Detail: fmt.Sprintf(
+					"Column '%s' on table '%s' uses the OID data type, "+
+						"which is commonly used to reference large objects. If used for LOB "+
+						"references, these will not replicate through logical decoding.",
+					col.Name, fqn,
+				),
+				ObjectName: fmt.Sprintf("%s.%s", fqn, col.Name),
+				Remediation: "If this column references large objects, migrate to LOLOR or " +
+					"BYTEA. LOLOR requires lolor.node to be set uniquely per node " +
+					"and its tables added to a replication set. " +
+					"If the column is used for other purposes, this finding can be ignored.",
+			})
 		}
-		fqn := schemaName + "." + tableName
-		findings = append(findings, models.Finding{
-			Severity:  models.SeverityWarning,
-			CheckName: c.Name(),
-			Category:  c.Category(),
-			Title:     fmt.Sprintf("OID column '%s.%s' may reference large objects", fqn, colName),
-			Detail: fmt.Sprintf(
-				"Column '%s' on table '%s' uses the OID data type, "+
-					"which is commonly used to reference large objects. If used for LOB "+
-					"references, these will not replicate through logical decoding.",
-				colName, fqn,
-			),
-			ObjectName: fmt.Sprintf("%s.%s", fqn, colName),
-			Remediation: "If this column references large objects, migrate to LOLOR or " +
-				"BYTEA. LOLOR requires lolor.node to be set uniquely per node " +
-				"and its tables added to a replication set. " +
-				"If the column is used for other purposes, this finding can be ignored.",
-		})
-	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("large_objects oid rows iteration failed: %w", err)
 	}
-	return findings, nil
+	return findings
 }
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/pgEdge/mm-ready-go/internal/catalog"
 	"github.com/pgEdge/mm-ready-go/internal/check"
 	"github.com/pgEdge/mm-ready-go/internal/models"
 )
@@ -34,46 +35,19 @@ func (MissingFKIndexesCheck) Description() string {
 
 // Run executes the check against the database connection.
 func (c MissingFKIndexesCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Finding, error) {
-	const sqlQuery = `
-		SELECT
-			cn.nspname AS schema_name,
-			cc.relname AS table_name,
-			co.conname AS constraint_name,
-			array_agg(a.attname ORDER BY x.ordinality) AS fk_columns
-		FROM pg_catalog.pg_constraint co
-		JOIN pg_catalog.pg_class cc ON cc.oid = co.conrelid
-		JOIN pg_catalog.pg_namespace cn ON cn.oid = cc.relnamespace
-		// CROSS is the c r o s s value.
-		CROSS JOIN LATERAL unnest(co.conkey) WITH ORDINALITY AS x(attnum, ordinality)
-		JOIN pg_catalog.pg_attribute a
-		  ON a.attrelid = co.conrelid AND a.attnum = x.attnum
-		WHERE co.contype = 'f'
-		  AND cn.nspname NOT IN ('pg_catalog', 'information_schema', 'spock', 'pg_toast')
-		  AND NOT EXISTS (
-			  SELECT 1
-			  FROM pg_catalog.pg_index i
-			  WHERE i.indrelid = co.conrelid
-				AND (i.indkey::int2[])[0:array_length(co.conkey, 1)-1]
-					= co.conkey
-		  )
-		GROUP BY cn.nspname, cc.relname, co.conname
-		ORDER BY cn.nspname, cc.relname, co.conname;
-	`
+	return check.RunStructural(ctx, conn, c)
+}
 
-	rows, err := conn.Query(ctx, sqlQuery)
-	if err != nil {
-		return nil, fmt.Errorf("missing_fk_indexes query failed: %w", err)
-	}
-	defer rows.Close()
+// Inspect returns the findings for the schema in cat.
+func (c MissingFKIndexesCheck) Inspect(cat *catalog.Catalog) []models.Finding {
 
 	var findings []models.Finding
-	for rows.Next() {
-		var schemaName, tableName, conName string
-		var fkCols []string
-		if err := rows.Scan(&schemaName, &tableName, &conName, &fkCols); err != nil {
-			return nil, fmt.Errorf("missing_fk_indexes scan failed: %w", err)
+	for _, con := range cat.ConstraintsOfType(catalog.ForeignKey) {
+		if hasLeadingIndex(cat.IndexesFor(con.Schema, con.Table), con.Columns) {
+			continue
 		}
-		fqn := schemaName + "." + tableName
+		conName, fkCols := con.Name, con.Columns
+		fqn := con.Schema + "." + con.Table
 		colList := strings.Join(fkCols, ", ")
 		findings = append(findings, models.Finding{
 			Severity:  models.SeverityWarning,
@@ -98,8 +72,26 @@ func (c MissingFKIndexesCheck) Run(ctx context.Context, conn *pgx.Conn) ([]model
 			Metadata: map[string]any{"constraint": conName, "columns": fkCols},
 		})
 	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("missing_fk_indexes rows iteration failed: %w", err)
+	return findings
+}
+
+// hasLeadingIndex reports whether any index has cols as the leading
+// columns of its key, in order.
+func hasLeadingIndex(indexes []catalog.Index, cols []string) bool {
+	for _, idx := range indexes {
+		if len(idx.Columns) < len(cols) {
+			continue
+		}
+		match := true
+		for i, col := range cols {
+			if idx.Columns[i] != col {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
 	}
-	return findings, nil
+	return false
 }
@@ -4,9 +4,11 @@ package schema
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/pgEdge/mm-ready-go/internal/catalog"
 	"github.com/pgEdge/mm-ready-go/internal/check"
 	"github.com/pgEdge/mm-ready-go/internal/models"
 )
@@ -27,6 +29,9 @@ func (MultipleUniqueIndexesCheck) Category() string { return "schema" }
 // Mode returns when this check runs (scan, audit, or both).
 func (MultipleUniqueIndexesCheck) Mode() string { return "scan" }
 
+// Effort hints how much work remediating one finding takes.
+func (MultipleUniqueIndexesCheck) Effort() models.Effort { return models.EffortMedium }
+
 // Description returns a human-readable summary of this check.
 func (MultipleUniqueIndexesCheck) Description() string {
 	return "Tables with multiple unique indexes — affects Spock conflict resolution"
@@ -34,39 +39,37 @@ func (MultipleUniqueIndexesCheck) Description() string {
 
 // Run executes the check against the database connection.
 func (c MultipleUniqueIndexesCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Finding, error) {
-	const sqlQuery = `
-		SELECT
-			n.nspname AS schema_name,
-			c.relname AS table_name,
-			count(*) AS unique_idx_count,
-			array_agg(i.relname ORDER BY i.relname) AS index_names
-		FROM pg_catalog.pg_index ix
-		JOIN pg_catalog.pg_class c ON c.oid = ix.indrelid
-		JOIN pg_catalog.pg_class i ON i.oid = ix.indexrelid
-		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
-		WHERE ix.indisunique
-		  AND c.relkind = 'r'
-		  AND n.nspname NOT IN ('pg_catalog', 'information_schema', 'spock', 'pg_toast')
-		GROUP BY n.nspname, c.relname
-		HAVING count(*) > 1
-		ORDER BY count(*) DESC, n.nspname, c.relname;
-	`
+	return check.RunStructural(ctx, conn, c)
+}
 
-	rows, err := conn.Query(ctx, sqlQuery)
-	if err != nil {
-		return nil, fmt.Errorf("multiple_unique_indexes query failed: %w", err)
+// Inspect returns the findings for the schema in cat.
+func (c MultipleUniqueIndexesCheck) Inspect(cat *catalog.Catalog) []models.Finding {
+	type tableIndexes struct {
+		fqn        string
+		indexNames []string
 	}
-	defer rows.Close()
+	var tables []tableIndexes
+	for _, t := range cat.Tables() {
+		var names []string
+		for _, idx := range cat.IndexesFor(t.Schema, t.Name) {
+			if idx.Unique {
+				names = append(names, idx.Name)
+			}
+		}
+		if len(names) > 1 {
+			sort.Strings(names)
+			tables = append(tables, tableIndexes{t.QualifiedName(), names})
+		}
+	}
+	// Tables with the most unique indexes first.
+	sort.SliceStable(tables, func(i, j int) bool {
+		return len(tables[i].indexNames) > len(tables[j].indexNames)
+	})
 
 	var findings []models.Finding
-	for rows.Next() {
-		var schemaName, tableName string
-		var idxCount int
-		var indexNames []string
-		if err := rows.Scan(&schemaName, &tableName, &idxCount, &indexNames); err != nil {
-			return nil, fmt.Errorf("multiple_unique_indexes scan failed: %w", err)
-		}
-		fqn := schemaName + "." + tableName
+	for _, t := range tables {
+		fqn, indexNames := t.fqn, t.indexNames
+		idxCount := len(indexNames)
 		findings = append(findings, models.Finding{
 			Severity:  models.SeverityConsider,
 			CheckName: c.Name(),
@@ -89,8 +92,5 @@ func (c MultipleUniqueIndexesCheck) Run(ctx context.Context, conn *pgx.Conn) ([]
 			Metadata: map[string]any{"unique_index_count": idxCount, "indexes": indexNames},
 		})
 	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("multiple_unique_indexes rows iteration failed: %w", err)
-	}
-	return findings, nil
+	return findings
 }
@@ -26,6 +26,9 @@ func (NotifyListenCheck) Category() string { return "schema" }
 // Mode returns when this check runs (scan, audit, or both).
 func (NotifyListenCheck) Mode() string { return "scan" }
 
+// Effort hints how much work remediating one finding takes.
+func (NotifyListenCheck) Effort() models.Effort { return models.EffortLarge }
+
 // Description returns a human-readable summary of this check.
 func (NotifyListenCheck) Description() string {
 	return "LISTEN/NOTIFY usage — notifications are not replicated by Spock"
@@ -26,6 +26,9 @@ func (PartitionedTablesCheck) Category() string { return "schema" }
 // Mode returns when this check runs (scan, audit, or both).
 func (PartitionedTablesCheck) Mode() string { return "scan" }
 
+// Effort hints how much work remediating one finding takes.
+func (PartitionedTablesCheck) Effort() models.Effort { return models.EffortMedium }
+
 // Description returns a human-readable summary of this check.
 func (PartitionedTablesCheck) Description() string {
 	return "Partitioned tables — review partition strategy for Spock compatibility"
@@ -4,8 +4,12 @@ package schema
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/pgEdge/mm-ready-go/internal/catalog"
 	"github.com/pgEdge/mm-ready-go/internal/check"
 	"github.com/pgEdge/mm-ready-go/internal/models"
 )
@@ -26,6 +30,9 @@ func (PrimaryKeysCheck) Category() string { return "schema" }
 // Mode returns when this check runs (scan, audit, or both).
 func (PrimaryKeysCheck) Mode() string { return "scan" }
 
+// Effort hints how much work remediating one finding takes.
+func (PrimaryKeysCheck) Effort() models.Effort { return models.EffortMedium }
+
 // Description returns a human-readable summary of this check.
 func (PrimaryKeysCheck) Description() string {
 	return "Tables without primary keys — affects Spock replication behaviour"
@@ -33,37 +40,18 @@ func (PrimaryKeysCheck) Description() string {
 
 // Run executes the check against the database connection.
 func (c PrimaryKeysCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Finding, error) {
-	const sqlQuery = `
-		SELECT
-			n.nspname AS schema_name,
-			c.relname AS table_name
-		FROM pg_catalog.pg_class c
-		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
-		WHERE c.relkind = 'r'
-		  AND n.nspname NOT IN ('pg_catalog', 'information_schema', 'spock', 'pg_toast')
-		  AND NOT EXISTS (
-			  SELECT 1
-			  FROM pg_catalog.pg_constraint con
-			  WHERE con.conrelid = c.oid
-				AND con.contype = 'p'
-		  )
-		ORDER BY n.nspname, c.relname;
-	`
-
-	rows, err := conn.Query(ctx, sqlQuery)
-	if err != nil {
-		return nil, fmt.Errorf("primary_keys query failed: %w", err)
-	}
-	defer rows.Close()
+	return check.RunStructural(ctx, conn, c)
+}
 
+// Inspect returns the findings for the schema in cat.
+func (c PrimaryKeysCheck) Inspect(cat *catalog.Catalog) []models.Finding {
 	var findings []models.Finding
-	for rows.Next() {
-		var schemaName, tableName string
-		if err := rows.Scan(&schemaName, &tableName); err != nil {
-			return nil, fmt.Errorf("primary_keys scan failed: %w", err)
+	for _, t := range cat.Tables() {
+		if len(cat.ConstraintsFor(t.Schema, t.Name, catalog.PrimaryKey)) > 0 {
+			continue
 		}
-		fqn := schemaName + "." + tableName
-		findings = append(findings, models.Finding{
+		fqn := t.QualifiedName()
+		finding := models.Finding{
 			Severity:  models.SeverityWarning,
 			CheckName: c.Name(),
 			Category:  c.Category(),
@@ -84,10 +72,224 @@ func (c PrimaryKeysCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Fin
 					"default_insert_only replication set.",
 				fqn,
 			),
-		})
+			Metadata: map[string]any{"table_pages": t.Pages},
+		}
+
+		// A valid REPLICA IDENTITY USING INDEX lets Spock replicate UPDATE
+		// and DELETE without a primary key; replica_identity reports it.
+		if idx := replicaIdentityIndex(cat, t); t.ReplicaIdentity == "i" && idx != nil && idx.Valid {
+			finding.Severity = models.SeverityConsider
+			finding.Detail = fmt.Sprintf(
+				"Table '%s' lacks a primary key, but its REPLICA IDENTITY USING "+
+					"INDEX %s identifies rows, so Spock replicates UPDATE and DELETE "+
+					"through that index (see the replica_identity check). A primary "+
+					"key is still preferred, as the identity is lost if the index is "+
+					"dropped or rebuilt.",
+				fqn, idx.Name,
+			)
+			finding.Metadata["replica_identity_index"] = idx.Name
+		}
+
+		if cand := pkCandidateFor(cat, t); cand != nil {
+			stmt, cost, level := cand.plan(cat, t)
+			finding.Detail += fmt.Sprintf(" Candidate key: %s.", cand.describe())
+			finding.Remediation = fmt.Sprintf(
+				"%s\n\nEstimated cost: %s If the table is genuinely insert-only, "+
+					"no action is required.",
+				stmt, cost,
+			)
+			finding.Metadata["candidate_source"] = cand.Source
+			finding.Metadata["candidate_columns"] = cand.Columns
+			if cand.Index != "" {
+				finding.Metadata["candidate_index"] = cand.Index
+			}
+			finding.Metadata["lock_cost"] = level
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+// Candidate key sources, best first.
+const (
+	pkFromUniqueIndex = "unique_index"
+	pkFromIdentity    = "identity"
+	pkFromSerial      = "serial"
+	pkFromName        = "column_name"
+)
+
+// blockSize is PostgreSQL's default page size, used to turn relpages into bytes.
+const blockSize = 8192
+
+// largeTablePages is the size, 1 GB in pages, above which building a primary
+// key index under an ACCESS EXCLUSIVE lock is likely to block writers for long.
+const largeTablePages = 1 << 30 / blockSize
+
+// pkCandidate is an existing index or column that could become a table's
+// primary key.
+type pkCandidate struct {
+	Source  string   // One of the pkFrom constants
+	Index   string   // Unique index to promote, for pkFromUniqueIndex
+	Columns []string // Key columns
+	// Nullable reports whether a key column still allows NULL.
+	Nullable bool
+}
+
+// pkCandidateFor looks for a primary key candidate for t: its replica
+// identity index, a unique index on NOT NULL columns, then an identity column, a serial column, and
+// finally a column named id or <table>_id. It returns nil when there is none.
+func pkCandidateFor(cat *catalog.Catalog, t *catalog.Relation) *pkCandidate {
+	if idx := replicaIdentityIndex(cat, t); t.ReplicaIdentity == "i" && idx != nil && idx.Valid {
+		return &pkCandidate{Source: pkFromUniqueIndex, Index: idx.Name, Columns: idx.Columns}
+	}
+	if idx := identityCandidate(cat, t); idx != nil {
+		return &pkCandidate{Source: pkFromUniqueIndex, Index: idx.Name, Columns: idx.Columns}
+	}
+	for _, col := range t.Columns {
+		if col.Identity != "" {
+			return &pkCandidate{Source: pkFromIdentity, Columns: []string{col.Name}}
+		}
+	}
+	for _, col := range t.Columns {
+		if strings.Contains(col.Default, "nextval(") {
+			return &pkCandidate{Source: pkFromSerial, Columns: []string{col.Name}, Nullable: !col.NotNull}
+		}
+	}
+	names := []string{"id", t.Name + "_id"}
+	if singular := strings.TrimSuffix(t.Name, "s"); singular != t.Name {
+		names = append(names, singular+"_id")
+	}
+	for _, name := range names {
+		if col := t.Column(name); col != nil {
+			return &pkCandidate{Source: pkFromName, Columns: []string{col.Name}, Nullable: !col.NotNull}
+		}
+	}
+	return nil
+}
+
+// describe explains where the candidate came from.
+func (p *pkCandidate) describe() string {
+	cols := strings.Join(p.Columns, ", ")
+	switch p.Source {
+	case pkFromUniqueIndex:
+		return fmt.Sprintf("unique index %s on NOT NULL column(s) %s", p.Index, cols)
+	case pkFromIdentity:
+		return fmt.Sprintf("identity column %s", cols)
+	case pkFromSerial:
+		return fmt.Sprintf("serial column %s", cols)
+	default:
+		return fmt.Sprintf("column %s, by its name", cols)
+	}
+}
+
+// plan returns the statement that adds the primary key, a sentence on the
+// lock and rewrite cost estimated from the table's relpages, and the cost
+// level: minimal, low, high, or unknown.
+func (p *pkCandidate) plan(cat *catalog.Catalog, t *catalog.Relation) (stmt, cost, level string) {
+	table := quoteQualified(t.Schema, t.Name)
+	if p.Source == pkFromUniqueIndex {
+		return fmt.Sprintf("ALTER TABLE %s ADD PRIMARY KEY USING INDEX %s;", table, quoteIdent(p.Index)),
+			"minimal. The existing index is reused and its columns are already " +
+				"NOT NULL, so there is no table scan or rewrite, and the ACCESS " +
+				"EXCLUSIVE lock is held only briefly.",
+			"minimal"
+	}
+
+	quoted := make([]string, len(p.Columns))
+	for i, col := range p.Columns {
+		quoted[i] = quoteIdent(col)
+	}
+	cols := strings.Join(quoted, ", ")
+	var checks []string
+	if p.Source == pkFromName || p.Nullable {
+		header := "-- Check the key is unique first:"
+		if p.Nullable {
+			header = "-- Check the key is unique and not NULL first:"
+		}
+		checks = append(checks,
+			header,
+			fmt.Sprintf("-- SELECT %s, count(*) FROM %s GROUP BY %s HAVING count(*) > 1;", cols, table, cols),
+		)
+	}
+	if p.Nullable {
+		checks = append(checks, fmt.Sprintf("-- SELECT count(*) FROM %s WHERE %s IS NULL;", table, cols))
+	}
+
+	const build = "ADD PRIMARY KEY reads the whole table to build the index " +
+		"while holding an ACCESS EXCLUSIVE lock, but does not rewrite it."
+	switch {
+	case t.Pages >= largeTablePages:
+		level = "high"
+		idx := quoteIdent(pkIndexName(cat, t))
+		stmt = fmt.Sprintf(
+			"CREATE UNIQUE INDEX CONCURRENTLY %s ON %s (%s);\n"+
+				"ALTER TABLE %s ADD PRIMARY KEY USING INDEX %s;",
+			idx, table, cols, table, idx,
+		)
+		cost = fmt.Sprintf(
+			"high. The table is about %s; building the index CONCURRENTLY "+
+				"avoids blocking writes for the whole build, and the ALTER TABLE "+
+				"then needs only a brief ACCESS EXCLUSIVE lock.",
+			models.FormatBytes(t.Pages*blockSize),
+		)
+	case t.Pages > 0:
+		level = "low"
+		stmt = fmt.Sprintf("ALTER TABLE %s ADD PRIMARY KEY (%s);", table, cols)
+		cost = fmt.Sprintf("low. The table is about %s. %s", models.FormatBytes(t.Pages*blockSize), build)
+	default:
+		level = "unknown"
+		stmt = fmt.Sprintf("ALTER TABLE %s ADD PRIMARY KEY (%s);", table, cols)
+		cost = "unknown, as the table size is not known (pg_class.relpages is 0). " + build
+	}
+	if p.Nullable {
+		cost += " The key column allows NULL, so it is also scanned to set NOT NULL."
+	}
+	if len(checks) > 0 {
+		stmt = strings.Join(checks, "\n") + "\n" + stmt
+	}
+	return stmt, cost, level
+}
+
+// maxIdentifierBytes is NAMEDATALEN - 1, the longest identifier PostgreSQL
+// keeps.
+const maxIdentifierBytes = 63
+
+// pkIndexName names a new primary key index the way PostgreSQL's
+// ChooseRelationName does: <table>_pkey, with the table name cut so the whole
+// fits in maxIdentifierBytes, and a number appended while the name is taken
+// by another relation or index in the schema.
+func pkIndexName(cat *catalog.Catalog, t *catalog.Relation) string {
+	taken := make(map[string]bool)
+	for _, r := range cat.Relations {
+		if r.Schema == t.Schema {
+			taken[r.Name] = true
+		}
+	}
+	for _, idx := range cat.Indexes {
+		if idx.Schema == t.Schema {
+			taken[idx.Name] = true
+		}
+	}
+	for pass := 0; ; pass++ {
+		suffix := "_pkey"
+		if pass > 0 {
+			suffix += strconv.Itoa(pass)
+		}
+		name := clipIdentifier(t.Name, maxIdentifierBytes-len(suffix)) + suffix
+		if !taken[name] {
+			return name
+		}
+	}
+}
+
+// clipIdentifier cuts name to at most n bytes without splitting a UTF-8
+// character, as pg_mbcliplen does.
+func clipIdentifier(name string, n int) string {
+	if len(name) <= n {
+		return name
 	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("primary_keys rows iteration failed: %w", err)
+	for n > 0 && !utf8.RuneStart(name[n]) {
+		n--
 	}
-	return findings, nil
+	return name[:n]
 }
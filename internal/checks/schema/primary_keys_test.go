@@ -0,0 +1,103 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pgEdge/mm-ready-go/internal/catalog"
+	"github.com/pgEdge/mm-ready-go/internal/models"
+)
+
+func TestPrimaryKeysReplicaIdentityIndex(t *testing.T) {
+	cat := &catalog.Catalog{
+		Relations: []catalog.Relation{
+			{Schema: "public", Name: "events", Kind: catalog.KindTable, ReplicaIdentity: "i", Columns: []catalog.Column{
+				{Name: "event_id", Num: 1, DataType: "uuid", NotNull: true},
+			}},
+		},
+		Indexes: []catalog.Index{
+			{Name: "events_event_id_key", Schema: "public", Table: "events", Unique: true, Valid: true,
+				ReplicaIdentity: true, Columns: []string{"event_id"}},
+		},
+	}
+
+	findings := PrimaryKeysCheck{}.Inspect(cat)
+	if len(findings) != 1 {
+		t.Fatalf("got %d findings, want 1", len(findings))
+	}
+	f := findings[0]
+	if f.Severity != models.SeverityConsider {
+		t.Errorf("severity = %s, want CONSIDER", f.Severity)
+	}
+	if strings.Contains(f.Detail, "silently filtered") || !strings.Contains(f.Detail, "replica_identity") {
+		t.Errorf("detail should defer to replica_identity: %s", f.Detail)
+	}
+	if want := `ADD PRIMARY KEY USING INDEX "events_event_id_key";`; !strings.Contains(f.Remediation, want) {
+		t.Errorf("remediation missing %s:\n%s", want, f.Remediation)
+	}
+}
+
+func TestPrimaryKeysQuotesIdentifiers(t *testing.T) {
+	cat := &catalog.Catalog{
+		Relations: []catalog.Relation{
+			{Schema: "Sales", Name: "user", Kind: catalog.KindTable, Pages: 10, Columns: []catalog.Column{
+				{Name: "Id", Num: 1, DataType: "bigint", NotNull: true, Identity: "ALWAYS"},
+			}},
+		},
+	}
+
+	f := PrimaryKeysCheck{}.Inspect(cat)[0]
+	if want := `ALTER TABLE "Sales"."user" ADD PRIMARY KEY ("Id");`; !strings.Contains(f.Remediation, want) {
+		t.Errorf("remediation missing %s:\n%s", want, f.Remediation)
+	}
+}
+
+func TestPKIndexName(t *testing.T) {
+	long := strings.Repeat("t", 70)
+	cat := &catalog.Catalog{
+		Relations: []catalog.Relation{
+			{Schema: "public", Name: long, Kind: catalog.KindTable},
+			{Schema: "public", Name: "orders", Kind: catalog.KindTable},
+			{Schema: "public", Name: "orders_pkey", Kind: catalog.KindTable},
+		},
+		Indexes: []catalog.Index{
+			{Name: "orders_pkey1", Schema: "public", Table: "orders"},
+		},
+	}
+
+	name := pkIndexName(cat, &cat.Relations[0])
+	if want := strings.Repeat("t", 58) + "_pkey"; name != want {
+		t.Errorf("long table: got %s (%d bytes), want %s", name, len(name), want)
+	}
+	if name := pkIndexName(cat, &cat.Relations[1]); name != "orders_pkey2" {
+		t.Errorf("taken names: got %s, want orders_pkey2", name)
+	}
+	if got := clipIdentifier("ab\u00e9", 3); got != "ab" {
+		t.Errorf("clipIdentifier split a character: %q", got)
+	}
+}
+
+func TestPrimaryKeysSkipsDeferrableAndExpressionIndexes(t *testing.T) {
+	cat := &catalog.Catalog{
+		Relations: []catalog.Relation{
+			{Schema: "public", Name: "slots", Kind: catalog.KindTable, Columns: []catalog.Column{
+				{Name: "code", Num: 1, DataType: "text", NotNull: true},
+			}},
+		},
+		Indexes: []catalog.Index{
+			{Name: "slots_code_key", Schema: "public", Table: "slots", Unique: true, Valid: true,
+				Deferrable: true, Columns: []string{"code"}},
+			{Name: "slots_lower_code_idx", Schema: "public", Table: "slots", Unique: true, Valid: true,
+				Expressions: true, Columns: []string{"lower(code)"}},
+		},
+	}
+
+	if idx := identityCandidate(cat, &cat.Relations[0]); idx != nil {
+		t.Fatalf("identityCandidate = %s, want nil", idx.Name)
+	}
+	for _, f := range (PrimaryKeysCheck{}).Inspect(cat) {
+		if strings.Contains(f.Remediation, "USING INDEX") {
+			t.Errorf("remediation uses an index PostgreSQL would refuse:\n%s", f.Remediation)
+		}
+	}
+}
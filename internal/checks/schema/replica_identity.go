@@ -0,0 +1,253 @@
+// Check REPLICA IDENTITY settings that change how Spock replicates UPDATE and DELETE.
+package schema
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pgEdge/mm-ready-go/internal/catalog"
+	"github.com/pgEdge/mm-ready-go/internal/check"
+	"github.com/pgEdge/mm-ready-go/internal/models"
+)
+
+// ReplicaIdentityCheck inspects each table's REPLICA IDENTITY and its index.
+type ReplicaIdentityCheck struct{}
+
+func init() {
+	check.Register(ReplicaIdentityCheck{})
+}
+
+// Name returns the unique identifier for this check.
+func (ReplicaIdentityCheck) Name() string { return "replica_identity" }
+
+// Category returns the check category.
+func (ReplicaIdentityCheck) Category() string { return "schema" }
+
+// Mode returns when this check runs (scan, audit, or both).
+func (ReplicaIdentityCheck) Mode() string { return "scan" }
+
+// Description returns a human-readable summary of this check.
+func (ReplicaIdentityCheck) Description() string {
+	return "REPLICA IDENTITY FULL, NOTHING, and USING INDEX — how UPDATE/DELETE rows are identified"
+}
+
+// Run executes the check against the database connection.
+func (c ReplicaIdentityCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Finding, error) {
+	return check.RunStructural(ctx, conn, c)
+}
+
+// Inspect returns the findings for the schema in cat.
+func (c ReplicaIdentityCheck) Inspect(cat *catalog.Catalog) []models.Finding {
+	var findings []models.Finding
+	for _, t := range cat.Tables() {
+		fqn := t.QualifiedName()
+		hasPK := len(cat.ConstraintsFor(t.Schema, t.Name, catalog.PrimaryKey)) > 0
+		candidate := identityCandidate(cat, t)
+
+		finding := models.Finding{
+			CheckName:  c.Name(),
+			Category:   c.Category(),
+			ObjectName: fqn,
+			Metadata:   map[string]any{"has_primary_key": hasPK},
+		}
+		if candidate != nil {
+			finding.Metadata["candidate_index"] = candidate.Name
+			finding.Metadata["candidate_columns"] = candidate.Columns
+		}
+
+		switch t.ReplicaIdentity {
+		case "n":
+			finding.Severity = models.SeverityWarning
+			finding.Title = fmt.Sprintf("Table '%s' has REPLICA IDENTITY NOTHING", fqn)
+			finding.Detail = fmt.Sprintf(
+				"Table '%s' is set to REPLICA IDENTITY NOTHING, so no old-row key "+
+					"is written to WAL for UPDATE and DELETE. Spock cannot find the "+
+					"row to change on the subscriber and places the table in the "+
+					"'default_insert_only' replication set, where UPDATE and DELETE "+
+					"are not replicated, even if the table has a primary key.",
+				fqn,
+			)
+			finding.Remediation = identityRemediation(t, hasPK, candidate)
+			finding.Metadata["replica_identity"] = "nothing"
+
+		case "f":
+			finding.Metadata["replica_identity"] = "full"
+			if hasPK {
+				finding.Severity = models.SeverityConsider
+				finding.Title = fmt.Sprintf("Table '%s' has REPLICA IDENTITY FULL and a primary key", fqn)
+				finding.Detail = fmt.Sprintf(
+					"Table '%s' is set to REPLICA IDENTITY FULL although it has a "+
+						"primary key. Every UPDATE and DELETE writes the whole old row "+
+						"to WAL, which increases WAL volume and replication traffic "+
+						"without helping Spock, which identifies rows by the primary key.",
+					fqn,
+				)
+				finding.Remediation = identityRemediation(t, true, nil)
+			} else {
+				finding.Severity = models.SeverityWarning
+				finding.Title = fmt.Sprintf("Table '%s' relies on REPLICA IDENTITY FULL without a primary key", fqn)
+				finding.Detail = fmt.Sprintf(
+					"Table '%s' has no primary key and is set to REPLICA IDENTITY "+
+						"FULL. This is not a substitute for a primary key in Spock: "+
+						"get_replication_identity() returns InvalidOid for FULL, so the "+
+						"table is placed in the 'default_insert_only' replication set "+
+						"and its UPDATE and DELETE operations are not replicated.",
+					fqn,
+				)
+				finding.Remediation = identityRemediation(t, false, candidate)
+			}
+
+		case "i":
+			finding.Metadata["replica_identity"] = "index"
+			idx := replicaIdentityIndex(cat, t)
+			switch {
+			case idx == nil:
+				finding.Severity = models.SeverityWarning
+				finding.Title = fmt.Sprintf("Replica identity index of '%s' was dropped", fqn)
+				finding.Detail = fmt.Sprintf(
+					"Table '%s' is set to REPLICA IDENTITY USING INDEX, but the index "+
+						"it named no longer exists. PostgreSQL then behaves as for "+
+						"REPLICA IDENTITY NOTHING: no old-row key is written to WAL, so "+
+						"UPDATE and DELETE on the table are not replicated.",
+					fqn,
+				)
+				finding.Remediation = identityRemediation(t, hasPK, candidate)
+			case !idx.Valid:
+				finding.Severity = models.SeverityWarning
+				finding.Title = fmt.Sprintf("Replica identity index '%s' on '%s' is not valid", idx.Name, fqn)
+				finding.Detail = fmt.Sprintf(
+					"Table '%s' is set to REPLICA IDENTITY USING INDEX, but the index "+
+						"'%s' is not valid, for example after a failed CREATE INDEX "+
+						"CONCURRENTLY or REINDEX CONCURRENTLY. An invalid index cannot "+
+						"identify rows, so UPDATE and DELETE on the table cannot be "+
+						"replicated reliably.",
+					fqn, idx.Name,
+				)
+				finding.Remediation = fmt.Sprintf(
+					"Rebuild the index:\n"+
+						"  REINDEX INDEX CONCURRENTLY %s;\n"+
+						"or choose another identity: %s",
+					quoteQualified(t.Schema, idx.Name), identityRemediation(t, hasPK, candidate),
+				)
+				finding.Metadata["index"] = idx.Name
+			case !hasPK:
+				finding.Severity = models.SeverityConsider
+				finding.Title = fmt.Sprintf("Table '%s' uses index '%s' as replica identity instead of a primary key", fqn, idx.Name)
+				finding.Detail = fmt.Sprintf(
+					"Table '%s' has no primary key but is set to REPLICA IDENTITY "+
+						"USING INDEX %s, a unique index on NOT NULL columns (%s). "+
+						"Spock uses this index to identify rows, so UPDATE and DELETE "+
+						"are replicated. Promoting it to the primary key makes the "+
+						"identity explicit and survives the index being dropped or "+
+						"rebuilt.",
+					fqn, idx.Name, strings.Join(idx.Columns, ", "),
+				)
+				finding.Remediation = fmt.Sprintf("ALTER TABLE %s ADD PRIMARY KEY USING INDEX %s;",
+					quoteQualified(t.Schema, t.Name), quoteIdent(idx.Name))
+				finding.Metadata["index"] = idx.Name
+			default:
+				continue
+			}
+
+		default:
+			if hasPK || candidate == nil {
+				continue
+			}
+			finding.Severity = models.SeverityConsider
+			finding.Title = fmt.Sprintf("Table '%s' has no primary key but unique index '%s' could identify rows", fqn, candidate.Name)
+			finding.Detail = fmt.Sprintf(
+				"Table '%s' has no primary key and uses the default replica "+
+					"identity, so it has none. Its unique index %s covers only NOT "+
+					"NULL columns (%s) and could be promoted to the primary key, or "+
+					"used as the replica identity, so that Spock replicates UPDATE "+
+					"and DELETE.",
+				fqn, candidate.Name, strings.Join(candidate.Columns, ", "),
+			)
+			finding.Remediation = identityRemediation(t, false, candidate)
+			finding.Metadata["replica_identity"] = "default"
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+// identityRemediation suggests how to give a table a usable replica identity.
+func identityRemediation(t *catalog.Relation, hasPK bool, candidate *catalog.Index) string {
+	table := quoteQualified(t.Schema, t.Name)
+	switch {
+	case hasPK:
+		return fmt.Sprintf("ALTER TABLE %s REPLICA IDENTITY DEFAULT;", table)
+	case candidate != nil:
+		index := quoteIdent(candidate.Name)
+		return fmt.Sprintf(
+			"Promote the unique index to the primary key:\n"+
+				"  ALTER TABLE %s ADD PRIMARY KEY USING INDEX %s;\n"+
+				"or use it as the replica identity:\n"+
+				"  ALTER TABLE %s REPLICA IDENTITY USING INDEX %s;",
+			table, index, table, index,
+		)
+	default:
+		return fmt.Sprintf("Add a primary key to '%s', then set REPLICA IDENTITY DEFAULT.", t.QualifiedName())
+	}
+}
+
+// quoteIdent quotes an identifier for use in suggested SQL, so that
+// mixed-case names and reserved words are pasted as written.
+func quoteIdent(name string) string {
+	return pgx.Identifier{name}.Sanitize()
+}
+
+// quoteQualified quotes a schema-qualified name for use in suggested SQL.
+func quoteQualified(schema, name string) string {
+	return pgx.Identifier{schema, name}.Sanitize()
+}
+
+// replicaIdentityIndex returns the index named by the table's REPLICA
+// IDENTITY USING INDEX, or nil if the catalog has none.
+func replicaIdentityIndex(cat *catalog.Catalog, t *catalog.Relation) *catalog.Index {
+	for _, idx := range cat.IndexesFor(t.Schema, t.Name) {
+		if idx.ReplicaIdentity {
+			return &idx
+		}
+	}
+	return nil
+}
+
+// identityCandidate returns the index that could serve as the table's
+// primary key or replica identity: a valid, non-partial, non-deferrable
+// unique index whose keys are all NOT NULL columns, with no expressions.
+// PostgreSQL refuses any other index for ADD PRIMARY KEY USING INDEX and
+// REPLICA IDENTITY USING INDEX. The index with the fewest columns wins, then
+// the first by name. It returns nil when there is none.
+func identityCandidate(cat *catalog.Catalog, t *catalog.Relation) *catalog.Index {
+	var candidates []catalog.Index
+	for _, idx := range cat.IndexesFor(t.Schema, t.Name) {
+		if !idx.Unique || idx.Primary || !idx.Valid || idx.Partial || idx.Deferrable ||
+			idx.Expressions || len(idx.Columns) == 0 {
+			continue
+		}
+		usable := true
+		for _, name := range idx.Columns {
+			if col := t.Column(name); col == nil || !col.NotNull {
+				usable = false
+				break
+			}
+		}
+		if usable {
+			candidates = append(candidates, idx)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if len(candidates[i].Columns) != len(candidates[j].Columns) {
+			return len(candidates[i].Columns) < len(candidates[j].Columns)
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+	return &candidates[0]
+}
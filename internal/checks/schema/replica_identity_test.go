@@ -0,0 +1,59 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pgEdge/mm-ready-go/internal/catalog"
+	"github.com/pgEdge/mm-ready-go/internal/models"
+)
+
+func TestReplicaIdentityDroppedIndex(t *testing.T) {
+	cat := &catalog.Catalog{
+		Relations: []catalog.Relation{
+			{Schema: "public", Name: "Order", Kind: catalog.KindTable, ReplicaIdentity: "i", Columns: []catalog.Column{
+				{Name: "id", Num: 1, DataType: "bigint", NotNull: true},
+			}},
+		},
+		Indexes: []catalog.Index{
+			{Name: "Order_id_key", Schema: "public", Table: "Order", Unique: true, Valid: true, Columns: []string{"id"}},
+		},
+	}
+
+	findings := ReplicaIdentityCheck{}.Inspect(cat)
+	if len(findings) != 1 {
+		t.Fatalf("got %d findings, want 1: %+v", len(findings), findings)
+	}
+	f := findings[0]
+	if f.Severity != models.SeverityWarning || f.Title != "Replica identity index of 'public.Order' was dropped" {
+		t.Errorf("finding = %s %q", f.Severity, f.Title)
+	}
+	if strings.Contains(f.Remediation, "REINDEX") || strings.Contains(f.Remediation, "(missing)") {
+		t.Errorf("remediation should not rebuild a dropped index:\n%s", f.Remediation)
+	}
+	want := `ALTER TABLE "public"."Order" ADD PRIMARY KEY USING INDEX "Order_id_key";`
+	if !strings.Contains(f.Remediation, want) {
+		t.Errorf("remediation missing %s:\n%s", want, f.Remediation)
+	}
+}
+
+func TestReplicaIdentityInvalidIndex(t *testing.T) {
+	cat := &catalog.Catalog{
+		Relations: []catalog.Relation{
+			{Schema: "public", Name: "user", Kind: catalog.KindTable, ReplicaIdentity: "i", Columns: []catalog.Column{
+				{Name: "id", Num: 1, DataType: "bigint", NotNull: true},
+			}},
+		},
+		Indexes: []catalog.Index{
+			{Name: "user_id_key", Schema: "public", Table: "user", Unique: true, ReplicaIdentity: true, Columns: []string{"id"}},
+		},
+	}
+
+	findings := ReplicaIdentityCheck{}.Inspect(cat)
+	if len(findings) != 1 {
+		t.Fatalf("got %d findings, want 1: %+v", len(findings), findings)
+	}
+	if want := `REINDEX INDEX CONCURRENTLY "public"."user_id_key";`; !strings.Contains(findings[0].Remediation, want) {
+		t.Errorf("remediation missing %s:\n%s", want, findings[0].Remediation)
+	}
+}
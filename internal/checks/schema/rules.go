@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/pgEdge/mm-ready-go/internal/catalog"
 	"github.com/pgEdge/mm-ready-go/internal/check"
 	"github.com/pgEdge/mm-ready-go/internal/models"
 )
@@ -26,6 +27,9 @@ func (RulesCheck) Category() string { return "schema" }
 // Mode returns when this check runs (scan, audit, or both).
 func (RulesCheck) Mode() string { return "scan" }
 
+// Effort hints how much work remediating one finding takes.
+func (RulesCheck) Effort() models.Effort { return models.EffortMedium }
+
 // Description returns a human-readable summary of this check.
 func (RulesCheck) Description() string {
 	return "Rules on tables — can cause unexpected behaviour with logical replication"
@@ -33,47 +37,18 @@ func (RulesCheck) Description() string {
 
 // Run executes the check against the database connection.
 func (c RulesCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Finding, error) {
-	const sqlQuery = `
-		SELECT
-			n.nspname AS schema_name,
-			c.relname AS table_name,
-			r.rulename AS rule_name,
-			r.ev_type::text AS event_type,
-			r.is_instead AS is_instead
-		FROM pg_catalog.pg_rewrite r
-		JOIN pg_catalog.pg_class c ON c.oid = r.ev_class
-		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
-		WHERE c.relkind = 'r'
-		  AND r.rulename != '_RETURN'
-		  AND n.nspname NOT IN ('pg_catalog', 'information_schema', 'spock', 'pg_toast')
-		ORDER BY n.nspname, c.relname, r.rulename;
-	`
-
-	eventLabels := map[string]string{
-		"1": "SELECT",
-		"2": "UPDATE",
-		"3": "INSERT",
-		"4": "DELETE",
-	}
-
-	rows, err := conn.Query(ctx, sqlQuery)
-	if err != nil {
-		return nil, fmt.Errorf("rules query failed: %w", err)
-	}
-	defer rows.Close()
+	return check.RunStructural(ctx, conn, c)
+}
 
+// Inspect returns the findings for the schema in cat.
+func (c RulesCheck) Inspect(cat *catalog.Catalog) []models.Finding {
 	var findings []models.Finding
-	for rows.Next() {
-		var schemaName, tableName, ruleName, eventType string
-		var isInstead bool
-		if err := rows.Scan(&schemaName, &tableName, &ruleName, &eventType, &isInstead); err != nil {
-			return nil, fmt.Errorf("rules scan failed: %w", err)
-		}
-		fqn := schemaName + "." + tableName
-		event := eventLabels[eventType]
-		if event == "" {
-			event = eventType
+	for _, r := range cat.Rules {
+		if t := cat.Relation(r.Schema, r.Table); t == nil || t.Kind != catalog.KindTable {
+			continue
 		}
+		fqn := r.Schema + "." + r.Table
+		ruleName, event, isInstead := r.Name, r.Event, r.Instead
 
 		severity := models.SeverityConsider
 		if isInstead {
@@ -120,8 +95,5 @@ func (c RulesCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Finding,
 			Metadata: map[string]any{"event": event, "is_instead": isInstead},
 		})
 	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("rules rows iteration failed: %w", err)
-	}
-	return findings, nil
+	return findings
 }
@@ -4,8 +4,12 @@ package schema
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/pgEdge/mm-ready-go/internal/catalog"
 	"github.com/pgEdge/mm-ready-go/internal/check"
 	"github.com/pgEdge/mm-ready-go/internal/models"
 )
@@ -26,6 +30,9 @@ func (SequencePKsCheck) Category() string { return "schema" }
 // Mode returns when this check runs (scan, audit, or both).
 func (SequencePKsCheck) Mode() string { return "scan" }
 
+// Effort hints how much work remediating one finding takes.
+func (SequencePKsCheck) Effort() models.Effort { return models.EffortLarge }
+
 // Description returns a human-readable summary of this check.
 func (SequencePKsCheck) Description() string {
 	return "Primary keys using standard sequences — must migrate to pgEdge snowflake"
@@ -33,66 +40,79 @@ func (SequencePKsCheck) Description() string {
 
 // Run executes the check against the database connection.
 func (c SequencePKsCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Finding, error) {
-	const sqlQuery = `
-		SELECT
-			n.nspname AS schema_name,
-			c.relname AS table_name,
-			a.attname AS column_name,
-			pg_get_serial_sequence(quote_ident(n.nspname) || '.' || quote_ident(c.relname), a.attname) AS seq_name
-		FROM pg_catalog.pg_class c
-		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
-		JOIN pg_catalog.pg_constraint con ON con.conrelid = c.oid AND con.contype = 'p'
-		JOIN pg_catalog.pg_attribute a ON a.attrelid = c.oid AND a.attnum = ANY(con.conkey)
-		WHERE c.relkind = 'r'
-		  AND n.nspname NOT IN ('pg_catalog', 'information_schema', 'spock', 'pg_toast')
-		  AND (
-			  pg_get_serial_sequence(quote_ident(n.nspname) || '.' || quote_ident(c.relname), a.attname) IS NOT NULL
-			  OR a.attidentity != ''
-		  )
-		ORDER BY n.nspname, c.relname, a.attname;
-	`
+	return check.RunStructural(ctx, conn, c)
+}
 
-	rows, err := conn.Query(ctx, sqlQuery)
-	if err != nil {
-		return nil, fmt.Errorf("sequence_pks query failed: %w", err)
-	}
-	defer rows.Close()
+// Inspect returns the findings for the schema in cat.
+func (c SequencePKsCheck) Inspect(cat *catalog.Catalog) []models.Finding {
 
 	var findings []models.Finding
-	for rows.Next() {
-		var schemaName, tableName, colName string
-		var seqName *string
-		if err := rows.Scan(&schemaName, &tableName, &colName, &seqName); err != nil {
-			return nil, fmt.Errorf("sequence_pks scan failed: %w", err)
+	for _, t := range cat.Tables() {
+		var pkCols []string
+		for _, con := range cat.ConstraintsFor(t.Schema, t.Name, catalog.PrimaryKey) {
+			pkCols = append(pkCols, con.Columns...)
 		}
-		fqn := schemaName + "." + tableName
-		seqDisplay := "identity column"
-		if seqName != nil {
-			seqDisplay = *seqName
+		sort.Strings(pkCols)
+
+		for _, colName := range pkCols {
+			seqDisplay, seqName, ok := pkSequence(cat, t, colName)
+			if !ok {
+				continue
+			}
+			fqn := t.QualifiedName()
+			findings = append(findings, models.Finding{
+				Severity:  models.SeverityCritical,
+				CheckName: c.Name(),
+				Category:  c.Category(),
+				Title:     fmt.Sprintf("PK column '%s.%s' uses a standard sequence", fqn, colName),
+				Detail: fmt.Sprintf(
+					"Primary key column '%s' on table '%s' is backed by "+
+						"sequence '%s'. In a multi-master setup, "+
+						"standard sequences will produce conflicting values across nodes. "+
+						"Must migrate to pgEdge snowflake sequences.",
+					colName, fqn, seqDisplay,
+				),
+				ObjectName: fqn,
+				Remediation: fmt.Sprintf(
+					"Convert '%s.%s' to use the pgEdge snowflake extension "+
+						"for globally unique ID generation. See: pgEdge snowflake documentation.",
+					fqn, colName,
+				),
+				Metadata: map[string]any{"column": colName, "sequence": seqName},
+			})
 		}
-		findings = append(findings, models.Finding{
-			Severity:  models.SeverityCritical,
-			CheckName: c.Name(),
-			Category:  c.Category(),
-			Title:     fmt.Sprintf("PK column '%s.%s' uses a standard sequence", fqn, colName),
-			Detail: fmt.Sprintf(
-				"Primary key column '%s' on table '%s' is backed by "+
-					"sequence '%s'. In a multi-master setup, "+
-					"standard sequences will produce conflicting values across nodes. "+
-					"Must migrate to pgEdge snowflake sequences.",
-				colName, fqn, seqDisplay,
-			),
-			ObjectName: fqn,
-			Remediation: fmt.Sprintf(
-				"Convert '%s.%s' to use the pgEdge snowflake extension "+
-					"for globally unique ID generation. See: pgEdge snowflake documentation.",
-				fqn, colName,
-			),
-			Metadata: map[string]any{"column": colName, "sequence": seqName},
-		})
 	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("sequence_pks rows iteration failed: %w", err)
+	return findings
+}
+
+// nextvalSequence extracts the sequence name from a nextval() default.
+var nextvalSequence = regexp.MustCompile(`(?i)nextval\('([^']+)'`)
+
+// pkSequence reports whether a primary key column takes its values from a
+// standard sequence: one it owns (serial or identity), or one its default
+// calls nextval() on. It returns the sequence for display and for metadata
+// (nil when unnamed). Defaults that go through pgEdge snowflake are already
+// globally unique and do not count.
+func pkSequence(cat *catalog.Catalog, t *catalog.Relation, colName string) (string, any, bool) {
+	col := t.Column(colName)
+	defaultLower := ""
+	if col != nil {
+		defaultLower = strings.ToLower(col.Default)
+	}
+	if strings.Contains(defaultLower, "snowflake") {
+		return "", nil, false
+	}
+	if seq := cat.SequenceOwnedBy(t.Schema, t.Name, colName); seq != nil {
+		return seq.QualifiedName(), seq.QualifiedName(), true
+	}
+	if col == nil {
+		return "", nil, false
+	}
+	if col.Identity != "" {
+		return "identity column", nil, true
+	}
+	if m := nextvalSequence.FindStringSubmatch(col.Default); m != nil {
+		return m[1], m[1], true
 	}
-	return findings, nil
+	return "", nil, false
 }
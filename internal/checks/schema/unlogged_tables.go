@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/pgEdge/mm-ready-go/internal/catalog"
 	"github.com/pgEdge/mm-ready-go/internal/check"
 	"github.com/pgEdge/mm-ready-go/internal/models"
 )
@@ -33,31 +34,17 @@ func (UnloggedTablesCheck) Description() string {
 
 // Run executes the check against the database connection.
 func (c UnloggedTablesCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Finding, error) {
-	const sqlQuery = `
-		SELECT
-			n.nspname AS schema_name,
-			c.relname AS table_name
-		FROM pg_catalog.pg_class c
-		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
-		WHERE c.relpersistence = 'u'
-		  AND c.relkind = 'r'
-		  AND n.nspname NOT IN ('pg_catalog', 'information_schema', 'spock', 'pg_toast')
-		ORDER BY n.nspname, c.relname;
-	`
-
-	rows, err := conn.Query(ctx, sqlQuery)
-	if err != nil {
-		return nil, fmt.Errorf("unlogged_tables query failed: %w", err)
-	}
-	defer rows.Close()
+	return check.RunStructural(ctx, conn, c)
+}
 
+// Inspect returns the findings for the schema in cat.
+func (c UnloggedTablesCheck) Inspect(cat *catalog.Catalog) []models.Finding {
 	var findings []models.Finding
-	for rows.Next() {
-		var schemaName, tableName string
-		if err := rows.Scan(&schemaName, &tableName); err != nil {
-			return nil, fmt.Errorf("unlogged_tables scan failed: %w", err)
+	for _, t := range cat.Tables() {
+		if t.Persistence != "u" {
+			continue
 		}
-		fqn := schemaName + "." + tableName
+		fqn := t.QualifiedName()
 		findings = append(findings, models.Finding{
 			Severity:  models.SeverityWarning,
 			CheckName: c.Name(),
@@ -77,8 +64,5 @@ func (c UnloggedTablesCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.
 			),
 		})
 	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("unlogged_tables rows iteration failed: %w", err)
-	}
-	return findings, nil
+	return findings
 }
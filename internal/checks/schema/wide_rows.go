@@ -0,0 +1,208 @@
+// Check for TOAST-heavy tables and very wide rows that slow apply and initial sync.
+package schema
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pgEdge/mm-ready-go/internal/catalog"
+	"github.com/pgEdge/mm-ready-go/internal/check"
+	"github.com/pgEdge/mm-ready-go/internal/models"
+)
+
+// Thresholds for the wide_rows check.
+const (
+	// wideRowBytes is the average row width, per pg_stats, above which
+	// PostgreSQL starts moving values out of line (TOAST_TUPLE_THRESHOLD is
+	// about 2 kB).
+	wideRowBytes = 2048
+	// wideColumnBytes is the average width above which a column is listed as
+	// a contributor.
+	wideColumnBytes = 1024
+	// toastHeavyBytes is the TOAST size above which a table whose TOAST is
+	// larger than its heap is reported.
+	toastHeavyBytes = 1 << 30
+	// toastWarnBytes is the TOAST size above which the finding is a WARNING.
+	toastWarnBytes = 10 << 30
+)
+
+// WideRowsCheck finds tables whose rows are wide or mostly stored in TOAST.
+type WideRowsCheck struct{}
+
+func init() {
+	check.Register(WideRowsCheck{})
+}
+
+// Name returns the unique identifier for this check.
+func (WideRowsCheck) Name() string { return "wide_rows" }
+
+// Category returns the check category.
+func (WideRowsCheck) Category() string { return "schema" }
+
+// Mode returns when this check runs (scan, audit, or both).
+func (WideRowsCheck) Mode() string { return "scan" }
+
+// Description returns a human-readable summary of this check.
+func (WideRowsCheck) Description() string {
+	return "TOAST-heavy tables and very wide rows — slow apply throughput and initial sync"
+}
+
+// Run executes the check against the database connection.
+func (c WideRowsCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Finding, error) {
+	// avg_width in pg_stats is measured on the stored, possibly compressed
+	// or out-of-line value, so the TOAST size is read separately.
+	const sqlQuery = `
+		SELECT
+			n.nspname AS schema_name,
+			c.relname AS table_name,
+			c.reltuples::float8 AS row_estimate,
+			` + catalog.HeapBytesSQL + ` AS heap_bytes,
+			` + catalog.ToastBytesSQL + ` AS toast_bytes,
+			w.row_width,
+			COALESCE(w.wide_columns, '{}') AS wide_columns,
+			COALESCE(w.wide_widths, '{}') AS wide_widths
+		FROM pg_catalog.pg_class c
+		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		LEFT JOIN LATERAL (
+			SELECT
+				sum(s.avg_width)::bigint AS row_width,
+				array_agg(s.attname::text ORDER BY s.avg_width DESC, s.attname)
+					FILTER (WHERE s.avg_width >= $1 AND t.typname IN
+					        ('bytea', 'jsonb', 'json', 'text', 'xml', 'varchar', 'bpchar'))
+					AS wide_columns,
+				array_agg(s.avg_width::bigint ORDER BY s.avg_width DESC, s.attname)
+					FILTER (WHERE s.avg_width >= $1 AND t.typname IN
+					        ('bytea', 'jsonb', 'json', 'text', 'xml', 'varchar', 'bpchar'))
+					AS wide_widths
+			FROM pg_catalog.pg_stats s
+			JOIN pg_catalog.pg_attribute a
+				ON a.attrelid = c.oid AND a.attname = s.attname AND NOT a.attisdropped
+			JOIN pg_catalog.pg_type t ON t.oid = a.atttypid
+			WHERE s.schemaname = n.nspname
+			  AND s.tablename = c.relname
+			  AND NOT s.inherited
+		) w ON true
+		WHERE c.relkind = 'r'
+		  AND c.relpersistence = 'p'
+		  AND n.nspname NOT IN ('pg_catalog', 'information_schema', 'spock', 'pg_toast')
+		ORDER BY n.nspname, c.relname;
+	`
+
+	rows, err := conn.Query(ctx, sqlQuery, wideColumnBytes)
+	if err != nil {
+		return nil, fmt.Errorf("wide_rows query failed: %w", err)
+	}
+	defer rows.Close()
+
+	var findings []models.Finding
+	for rows.Next() {
+		var wt wideTable
+		if err := rows.Scan(&wt.schema, &wt.name, &wt.rowEstimate, &wt.heapBytes, &wt.toastBytes,
+			&wt.rowWidth, &wt.wideColumns, &wt.wideWidths); err != nil {
+			return nil, fmt.Errorf("wide_rows scan failed: %w", err)
+		}
+		if f, ok := c.finding(wt); ok {
+			findings = append(findings, f)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("wide_rows rows iteration failed: %w", err)
+	}
+	return findings, nil
+}
+
+// wideTable is one table's row width and storage, as read by Run.
+type wideTable struct {
+	schema, name string
+	rowEstimate  float64
+	heapBytes    int64
+	toastBytes   int64
+	// rowWidth is the sum of the columns' pg_stats.avg_width, nil without
+	// statistics.
+	rowWidth    *int64
+	wideColumns []string
+	wideWidths  []int64
+}
+
+// finding reports wt if its rows are wide or mostly stored in TOAST. Sizes
+// go in the detail and metadata only, so that the title, and with it the
+// fingerprint, stays the same as the table grows.
+func (c WideRowsCheck) finding(wt wideTable) (models.Finding, bool) {
+	wide := wt.rowWidth != nil && *wt.rowWidth >= wideRowBytes
+	toastHeavy := wt.toastBytes >= toastHeavyBytes && wt.toastBytes >= wt.heapBytes
+	if !wide && !toastHeavy {
+		return models.Finding{}, false
+	}
+	fqn := wt.schema + "." + wt.name
+	syncBytes := wt.heapBytes + wt.toastBytes
+
+	var reasons []string
+	if wide {
+		reasons = append(reasons, fmt.Sprintf("an average row width of %s", models.FormatBytes(*wt.rowWidth)))
+	}
+	if toastHeavy {
+		reasons = append(reasons, fmt.Sprintf(
+			"%s of TOAST data against a %s heap", models.FormatBytes(wt.toastBytes), models.FormatBytes(wt.heapBytes),
+		))
+	}
+	var columns []string
+	for i, name := range wt.wideColumns {
+		if i < len(wt.wideWidths) {
+			columns = append(columns, fmt.Sprintf("%s (avg %s)", name, models.FormatBytes(wt.wideWidths[i])))
+		}
+	}
+	contributors := ""
+	if len(columns) > 0 {
+		contributors = fmt.Sprintf(" The widest columns are %s.", strings.Join(columns, ", "))
+	}
+
+	severity := models.SeverityConsider
+	if wt.toastBytes >= toastWarnBytes {
+		severity = models.SeverityWarning
+	}
+
+	meta := map[string]any{
+		"heap_bytes":  wt.heapBytes,
+		"toast_bytes": wt.toastBytes,
+		"sync_bytes":  syncBytes,
+	}
+	if wt.rowEstimate >= 0 {
+		meta["row_estimate"] = int64(wt.rowEstimate)
+	}
+	if wt.rowWidth != nil {
+		meta["avg_row_width"] = *wt.rowWidth
+	}
+	if len(wt.wideColumns) > 0 {
+		meta["wide_columns"] = wt.wideColumns
+	}
+
+	return models.Finding{
+		Severity:  severity,
+		CheckName: c.Name(),
+		Category:  c.Category(),
+		Title:     fmt.Sprintf("Table '%s' has large rows that slow apply and initial sync", fqn),
+		Detail: fmt.Sprintf(
+			"Table '%s' has %s.%s Every replicated INSERT, and every UPDATE "+
+				"that changes a TOASTed value, carries the full value through "+
+				"logical decoding, the network, and the apply worker, which "+
+				"lowers apply throughput. When a node is added, the initial "+
+				"COPY of this table moves about %s (heap plus TOAST), and more "+
+				"once compressed values are expanded.",
+			fqn, strings.Join(reasons, " and "), contributors, models.FormatBytes(syncBytes),
+		),
+		ObjectName: fqn,
+		Remediation: fmt.Sprintf(
+			"Allow for about %s of initial sync for '%s' when planning the "+
+				"node-add window. To reduce it, purge or archive rows that do not "+
+				"need to replicate, move large documents or blobs into a separate "+
+				"table that can be synced on its own schedule, or use lz4 TOAST "+
+				"compression (ALTER TABLE ... ALTER COLUMN ... SET COMPRESSION "+
+				"lz4, PostgreSQL 14+). Avoid REPLICA IDENTITY FULL on this table, "+
+				"as it logs the whole old row on every UPDATE and DELETE.",
+			models.FormatBytes(syncBytes), fqn,
+		),
+		Metadata: meta,
+	}, true
+}
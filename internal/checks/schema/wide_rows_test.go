@@ -0,0 +1,33 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/pgEdge/mm-ready-go/internal/models"
+)
+
+func TestWideRowsFingerprintSurvivesGrowth(t *testing.T) {
+	width := int64(4096)
+	small := wideTable{schema: "public", name: "documents", heapBytes: 900 << 20, toastBytes: 2 << 20, rowWidth: &width}
+	large := small
+	large.heapBytes, large.toastBytes = 3<<30, 12<<30
+
+	var result models.CheckResult
+	for _, wt := range []wideTable{small, large} {
+		f, ok := WideRowsCheck{}.finding(wt)
+		if !ok {
+			t.Fatalf("%+v: no finding", wt)
+		}
+		result.Findings = append(result.Findings, f)
+	}
+	result.SetFingerprints()
+
+	before, after := result.Findings[0], result.Findings[1]
+	if before.Fingerprint != after.Fingerprint {
+		t.Errorf("fingerprint changed from %s to %s as the table grew from MB to GB:\n%q\n%q",
+			before.Fingerprint, after.Fingerprint, before.Title, after.Title)
+	}
+	if after.Severity != models.SeverityWarning || after.Metadata["sync_bytes"] != int64(15<<30) {
+		t.Errorf("large table: severity %s, sync_bytes %v", after.Severity, after.Metadata["sync_bytes"])
+	}
+}
@@ -5,8 +5,10 @@ package sequences
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/pgEdge/mm-ready-go/internal/catalog"
 	"github.com/pgEdge/mm-ready-go/internal/check"
 	"github.com/pgEdge/mm-ready-go/internal/models"
 )
@@ -27,6 +29,9 @@ func (SequenceAuditCheck) Category() string { return "sequences" }
 // Mode returns when this check runs (scan, audit, or both).
 func (SequenceAuditCheck) Mode() string { return "scan" }
 
+// Effort hints how much work remediating one finding takes.
+func (SequenceAuditCheck) Effort() models.Effort { return models.EffortMedium }
+
 // Description returns a human-readable summary of this check.
 func (SequenceAuditCheck) Description() string {
 	return "All sequences, types, and ownership — need snowflake migration plan"
@@ -34,65 +39,26 @@ func (SequenceAuditCheck) Description() string {
 
 // Run executes the check against the database connection.
 func (c SequenceAuditCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Finding, error) {
-	const query = `
-		SELECT
-			n.nspname AS schema_name,
-			c.relname AS sequence_name,
-			s.seqtypid::regtype::text AS data_type,
-			s.seqstart AS start_value,
-			s.seqincrement AS increment,
-			s.seqmin AS min_value,
-			s.seqmax AS max_value,
-			s.seqcycle AS is_cycle,
-			d.refobjid IS NOT NULL AS is_owned,
-			CASE WHEN d.refobjid IS NOT NULL THEN
-				(SELECT relname FROM pg_class WHERE oid = d.refobjid)
-			ELSE NULL END AS owner_table,
-			CASE WHEN d.refobjid IS NOT NULL THEN
-				(SELECT attname FROM pg_attribute
-				 WHERE attrelid = d.refobjid AND attnum = d.refobjsubid)
-			ELSE NULL END AS owner_column
-		FROM pg_catalog.pg_sequence s
-		JOIN pg_catalog.pg_class c ON c.oid = s.seqrelid
-		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
-		LEFT JOIN pg_catalog.pg_depend d
-			ON d.objid = s.seqrelid
-			AND d.deptype = 'a'
-			AND d.classid = 'pg_class'::regclass
-		WHERE n.nspname NOT IN ('pg_catalog', 'information_schema', 'spock', 'pg_toast')
-		ORDER BY n.nspname, c.relname;
-	`
-	rows, err := conn.Query(ctx, query)
-	if err != nil {
-		return nil, fmt.Errorf("sequence_audit query failed: %w", err)
-	}
-	defer rows.Close()
+	return check.RunStructural(ctx, conn, c)
+}
 
+// Inspect returns the findings for the schema in cat.
+func (c SequenceAuditCheck) Inspect(cat *catalog.Catalog) []models.Finding {
 	var findings []models.Finding
-	for rows.Next() {
-		var (
-			schemaName, seqName, dataType string
-			startVal, increment           int64
-			minVal, maxVal                int64
-			isCycle, isOwned              bool
-			ownerTable, ownerColumn       *string
-		)
-		if err := rows.Scan(
-			&schemaName, &seqName, &dataType,
-			&startVal, &increment, &minVal, &maxVal,
-			&isCycle, &isOwned, &ownerTable, &ownerColumn,
-		); err != nil {
-			return nil, fmt.Errorf("sequence_audit scan failed: %w", err)
-		}
+	for i := range cat.Sequences {
+		seq := &cat.Sequences[i]
+		fqn := seq.QualifiedName()
+		dataType := seq.DataType
 
-		fqn := fmt.Sprintf("%s.%s", schemaName, seqName)
 		ownership := "not owned by any column"
-		if isOwned && ownerTable != nil && ownerColumn != nil {
-			ownership = fmt.Sprintf("owned by %s.%s", *ownerTable, *ownerColumn)
+		var ownerTable, ownerColumn any
+		if seq.OwnedByTable != "" {
+			ownership = fmt.Sprintf("owned by %s.%s", seq.OwnedByTable, seq.OwnedByColumn)
+			ownerTable, ownerColumn = seq.OwnedByTable, seq.OwnedByColumn
 		}
 
 		cycleStr := "no"
-		if isCycle {
+		if seq.Cycle {
 			cycleStr = "yes"
 		}
 
@@ -102,11 +68,11 @@ func (c SequenceAuditCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.F
 			Category:  c.Category(),
 			Title:     fmt.Sprintf("Sequence '%s' (%s, %s)", fqn, dataType, ownership),
 			Detail: fmt.Sprintf(
-				"Sequence '%s': type=%s, start=%d, increment=%d, cycle=%s, %s. "+
+				"Sequence '%s': type=%s, start=%s, increment=%s, cycle=%s, %s. "+
 					"Standard sequences produce overlapping values in multi-master setups. "+
 					"Must migrate to pgEdge snowflake sequences or implement another "+
 					"globally-unique ID strategy.",
-				fqn, dataType, startVal, increment, cycleStr, ownership,
+				fqn, dataType, formatOption(seq.Start), formatOption(seq.Increment), cycleStr, ownership,
 			),
 			ObjectName: fqn,
 			Remediation: fmt.Sprintf(
@@ -115,17 +81,29 @@ func (c SequenceAuditCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.F
 			),
 			Metadata: map[string]any{
 				"data_type":    dataType,
-				"start":        startVal,
-				"increment":    increment,
-				"cycle":        isCycle,
+				"start":        optionValue(seq.Start),
+				"increment":    optionValue(seq.Increment),
+				"cycle":        seq.Cycle,
 				"owner_table":  ownerTable,
 				"owner_column": ownerColumn,
 			},
 		})
 	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("sequence_audit rows error: %w", err)
+	return findings
+}
+
+// formatOption renders a sequence option, or "default" when it is unknown.
+func formatOption(v *int64) string {
+	if v == nil {
+		return "default"
 	}
+	return strconv.FormatInt(*v, 10)
+}
 
-	return findings, nil
+// optionValue returns a sequence option for metadata, or nil when unknown.
+func optionValue(v *int64) any {
+	if v == nil {
+		return nil
+	}
+	return *v
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/pgEdge/mm-ready-go/internal/catalog"
 	"github.com/pgEdge/mm-ready-go/internal/check"
 	"github.com/pgEdge/mm-ready-go/internal/models"
 )
@@ -26,6 +27,9 @@ func (SequenceDataTypesCheck) Category() string { return "sequences" }
 // Mode returns when this check runs (scan, audit, or both).
 func (SequenceDataTypesCheck) Mode() string { return "scan" }
 
+// Effort hints how much work remediating one finding takes.
+func (SequenceDataTypesCheck) Effort() models.Effort { return models.EffortMedium }
+
 // Description returns a human-readable summary of this check.
 func (SequenceDataTypesCheck) Description() string {
 	return "Sequence data types — smallint/integer may overflow faster in multi-master"
@@ -33,48 +37,30 @@ func (SequenceDataTypesCheck) Description() string {
 
 // Run executes the check against the database connection.
 func (c SequenceDataTypesCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.Finding, error) {
-	const query = `
-		SELECT
-			n.nspname AS schema_name,
-			c.relname AS seq_name,
-			s.seqtypid::regtype::text AS data_type,
-			s.seqmax AS max_value,
-			s.seqstart AS start_value,
-			s.seqincrement AS increment
-		FROM pg_catalog.pg_sequence s
-		JOIN pg_catalog.pg_class c ON c.oid = s.seqrelid
-		JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
-		WHERE n.nspname NOT IN ('pg_catalog', 'information_schema', 'spock', 'pg_toast')
-		ORDER BY n.nspname, c.relname;
-	`
-	rows, err := conn.Query(ctx, query)
-	if err != nil {
-		return nil, fmt.Errorf("sequence_data_types query failed: %w", err)
-	}
-	defer rows.Close()
+	return check.RunStructural(ctx, conn, c)
+}
 
+// Inspect returns the findings for the schema in cat.
+func (c SequenceDataTypesCheck) Inspect(cat *catalog.Catalog) []models.Finding {
 	var findings []models.Finding
-	for rows.Next() {
-		var (
-			schemaName, seqName, dataType string
-			maxValue, startValue          int64
-			increment                     int64
-		)
-		if err := rows.Scan(&schemaName, &seqName, &dataType, &maxValue, &startValue, &increment); err != nil {
-			return nil, fmt.Errorf("sequence_data_types scan failed: %w", err)
-		}
-
+	for i := range cat.Sequences {
+		seq := &cat.Sequences[i]
+		dataType := seq.DataType
 		if dataType != "smallint" && dataType != "integer" {
 			continue
 		}
 
-		fqn := fmt.Sprintf("%s.%s", schemaName, seqName)
+		fqn := seq.QualifiedName()
 		var typeMax int64
 		if dataType == "smallint" {
 			typeMax = 32767
 		} else {
 			typeMax = 2147483647
 		}
+		maxValue := typeMax
+		if seq.MaxValue != nil {
+			maxValue = *seq.MaxValue
+		}
 
 		findings = append(findings, models.Finding{
 			Severity:  models.SeverityWarning,
@@ -96,13 +82,9 @@ func (c SequenceDataTypesCheck) Run(ctx context.Context, conn *pgx.Conn) ([]mode
 			Metadata: map[string]any{
 				"data_type": dataType,
 				"max_value": maxValue,
-				"increment": increment,
+				"increment": optionValue(seq.Increment),
 			},
 		})
 	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("sequence_data_types rows error: %w", err)
-	}
-
-	return findings, nil
+	return findings
 }
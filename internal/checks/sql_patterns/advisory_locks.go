@@ -27,6 +27,12 @@ func (AdvisoryLocksCheck) Category() string { return "sql_patterns" }
 // Mode returns when this check runs (scan, audit, or both).
 func (AdvisoryLocksCheck) Mode() string { return "scan" }
 
+// Effort hints how much work remediating one finding takes.
+func (AdvisoryLocksCheck) Effort() models.Effort { return models.EffortLarge }
+
+// Prerequisites declares what this check needs in order to run.
+func (AdvisoryLocksCheck) Prerequisites() check.Prerequisites { return pgStatStatements }
+
 // Description returns a human-readable summary of this check.
 func (AdvisoryLocksCheck) Description() string {
 	return "Advisory lock usage — locks are node-local, not replicated"
@@ -42,8 +48,7 @@ func (c AdvisoryLocksCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.F
 	`
 	rows, err := conn.Query(ctx, query)
 	if err != nil {
-		// pg_stat_statements not available — return empty.
-		return nil, nil
+		return nil, fmt.Errorf("querying pg_stat_statements: %w", err)
 	}
 	defer rows.Close()
 
@@ -27,6 +27,9 @@ func (ConcurrentIndexesCheck) Category() string { return "sql_patterns" }
 // Mode returns when this check runs (scan, audit, or both).
 func (ConcurrentIndexesCheck) Mode() string { return "scan" }
 
+// Prerequisites declares what this check needs in order to run.
+func (ConcurrentIndexesCheck) Prerequisites() check.Prerequisites { return pgStatStatements }
+
 // Description returns a human-readable summary of this check.
 func (ConcurrentIndexesCheck) Description() string {
 	return "CREATE INDEX CONCURRENTLY — must be created manually on each node"
@@ -42,8 +45,7 @@ func (c ConcurrentIndexesCheck) Run(ctx context.Context, conn *pgx.Conn) ([]mode
 	`
 	rows, err := conn.Query(ctx, query)
 	if err != nil {
-		// pg_stat_statements not available — return empty.
-		return nil, nil
+		return nil, fmt.Errorf("querying pg_stat_statements: %w", err)
 	}
 	defer rows.Close()
 
@@ -27,6 +27,12 @@ func (DdlStatementsCheck) Category() string { return "sql_patterns" }
 // Mode returns when this check runs (scan, audit, or both).
 func (DdlStatementsCheck) Mode() string { return "scan" }
 
+// Effort hints how much work remediating one finding takes.
+func (DdlStatementsCheck) Effort() models.Effort { return models.EffortMedium }
+
+// Prerequisites declares what this check needs in order to run.
+func (DdlStatementsCheck) Prerequisites() check.Prerequisites { return pgStatStatements }
+
 // Description returns a human-readable summary of this check.
 func (DdlStatementsCheck) Description() string {
 	return "DDL statements — must use Spock DDL replication or manual coordination"
@@ -56,15 +62,7 @@ func (c DdlStatementsCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models.F
 		LIMIT 50;
 	`, pattern)
 	if err != nil {
-		// pg_stat_statements not available.
-		return []models.Finding{{
-			Severity:   models.SeverityInfo,
-			CheckName:  c.Name(),
-			Category:   c.Category(),
-			Title:      "Cannot check DDL patterns — pg_stat_statements unavailable",
-			Detail:     "pg_stat_statements is not available.",
-			ObjectName: "pg_stat_statements",
-		}}, nil
+		return nil, fmt.Errorf("querying pg_stat_statements: %w", err)
 	}
 	defer rows.Close()
 
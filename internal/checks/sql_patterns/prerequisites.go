@@ -0,0 +1,11 @@
+package sql_patterns
+
+import "github.com/pgEdge/mm-ready-go/internal/check"
+
+// pgStatStatements is the prerequisite shared by every check in this
+// package. The extension must be installed and its library preloaded;
+// pg_stat_statements.max is only defined once the library is loaded.
+var pgStatStatements = check.Prerequisites{
+	Extensions: []string{"pg_stat_statements"},
+	GUCs:       []string{"pg_stat_statements.max"},
+}
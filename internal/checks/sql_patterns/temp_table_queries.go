@@ -27,6 +27,12 @@ func (TempTableQueriesCheck) Category() string { return "sql_patterns" }
 // Mode returns when this check runs (scan, audit, or both).
 func (TempTableQueriesCheck) Mode() string { return "scan" }
 
+// Effort hints how much work remediating one finding takes.
+func (TempTableQueriesCheck) Effort() models.Effort { return models.EffortMedium }
+
+// Prerequisites declares what this check needs in order to run.
+func (TempTableQueriesCheck) Prerequisites() check.Prerequisites { return pgStatStatements }
+
 // Description returns a human-readable summary of this check.
 func (TempTableQueriesCheck) Description() string {
 	return "CREATE TEMP TABLE in SQL — session-local, not replicated"
@@ -42,8 +48,7 @@ func (c TempTableQueriesCheck) Run(ctx context.Context, conn *pgx.Conn) ([]model
 	`
 	rows, err := conn.Query(ctx, query)
 	if err != nil {
-		// pg_stat_statements not available — return empty.
-		return nil, nil
+		return nil, fmt.Errorf("querying pg_stat_statements: %w", err)
 	}
 	defer rows.Close()
 
@@ -26,6 +26,12 @@ func (TruncateCascadeCheck) Category() string { return "sql_patterns" }
 // Mode returns when this check runs (scan, audit, or both).
 func (TruncateCascadeCheck) Mode() string { return "scan" }
 
+// Effort hints how much work remediating one finding takes.
+func (TruncateCascadeCheck) Effort() models.Effort { return models.EffortMedium }
+
+// Prerequisites declares what this check needs in order to run.
+func (TruncateCascadeCheck) Prerequisites() check.Prerequisites { return pgStatStatements }
+
 // Description returns a human-readable summary of this check.
 func (TruncateCascadeCheck) Description() string {
 	return "TRUNCATE ... CASCADE and RESTART IDENTITY — replication behaviour caveats"
@@ -41,15 +47,7 @@ func (c TruncateCascadeCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models
 		ORDER BY calls DESC;
 	`)
 	if err != nil {
-		// pg_stat_statements not available.
-		return []models.Finding{{
-			Severity:   models.SeverityInfo,
-			CheckName:  c.Name(),
-			Category:   c.Category(),
-			Title:      "Cannot check TRUNCATE patterns — pg_stat_statements unavailable",
-			Detail:     "pg_stat_statements is not available. Cannot check for TRUNCATE CASCADE or RESTART IDENTITY usage.",
-			ObjectName: "pg_stat_statements",
-		}}, nil
+		return nil, fmt.Errorf("querying pg_stat_statements: %w", err)
 	}
 	defer cascadeRows.Close()
 
@@ -106,7 +104,7 @@ func (c TruncateCascadeCheck) Run(ctx context.Context, conn *pgx.Conn) ([]models
 		ORDER BY calls DESC;
 	`)
 	if err != nil {
-		return findings, nil
+		return nil, fmt.Errorf("querying pg_stat_statements: %w", err)
 	}
 	defer restartRows.Close()
 
@@ -3,7 +3,6 @@ package cmd
 import (
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/pgEdge/mm-ready-go/internal/analyzer"
@@ -15,6 +14,8 @@ import (
 
 var analyzeFile string
 var analyzeOut outputFlags
+var analyzeBaseline baselineFlags
+var analyzeFailOn string
 var analyzeCategories string
 var analyzeExclude string
 var analyzeIncludeOnly string
@@ -33,6 +34,8 @@ func init() {
 	addOutputFlags(analyzeCmd, &analyzeOut)
 	addConfigFlags(analyzeCmd)
 	addReportFlags(analyzeCmd)
+	addBaselineFlags(analyzeCmd, &analyzeBaseline)
+	addFailOnFlag(analyzeCmd, &analyzeFailOn)
 	analyzeCmd.Flags().StringVar(&analyzeCategories, "categories", "", "Comma-separated list of check categories to run")
 	analyzeCmd.Flags().StringVar(&analyzeExclude, "exclude", "", "Comma-separated list of check names to skip")
 	analyzeCmd.Flags().StringVar(&analyzeIncludeOnly, "include-only", "", "Comma-separated list of check names to run (whitelist)")
@@ -40,6 +43,13 @@ func init() {
 }
 
 func runAnalyze(cmd *cobra.Command, args []string) error {
+	if err := validateFailOn(analyzeFailOn); err != nil {
+		return err
+	}
+	if _, err := validateOutput(analyzeOut); err != nil {
+		return err
+	}
+
 	// Check if file exists
 	if _, err := os.Stat(analyzeFile); os.IsNotExist(err) {
 		return fmt.Errorf("file not found: %s", analyzeFile)
@@ -98,38 +108,23 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("analyze: %w", err)
 	}
 
+	config.ApplySuppressions(report, cfg.Suppressions)
+	report.Weights = cfg.ScoreWeights()
+	if err := applyBaseline(report, analyzeBaseline); err != nil {
+		return err
+	}
+
 	// Render report
 	reportOpts := reporter.ReportOptions{
 		TodoList:            reportCfg.TodoList,
 		TodoIncludeConsider: reportCfg.TodoIncludeConsider,
 	}
-	output, err := reporter.Render(report, analyzeOut.Format, reportOpts)
-	if err != nil {
-		return fmt.Errorf("render report: %w", err)
-	}
-
 	// Write output
-	return writeAnalyzeOutput(output, analyzeOut, report.Database)
-}
-
-func writeAnalyzeOutput(output string, of outputFlags, dbname string) error {
-	var path string
-	if of.Output != "" {
-		path = MakeOutputPath(of.Output, of.Format, dbname)
-	} else {
-		path = MakeDefaultOutputPath(of.Format, dbname)
+	render := func(format string) (string, error) {
+		return reporter.Render(report, format, reportOpts)
 	}
-
-	dir := filepath.Dir(path)
-	if dir != "" {
-		if err := os.MkdirAll(dir, 0o755); err != nil {
-			return fmt.Errorf("create output directory: %w", err)
-		}
-	}
-
-	if err := os.WriteFile(path, []byte(output), 0o644); err != nil {
-		return fmt.Errorf("write report: %w", err)
+	if err := writeOutput(analyzeOut, report.Database, render); err != nil {
+		return err
 	}
-	fmt.Fprintf(os.Stderr, "Report written to %s\n", path)
-	return nil
+	return checkFailOn(report, analyzeFailOn)
 }
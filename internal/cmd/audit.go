@@ -1,13 +1,21 @@
 package cmd
 
-import "github.com/spf13/cobra"
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+)
 
 var auditConn connFlags
 var auditOut outputFlags
+var auditBaseline baselineFlags
+var auditFailOn string
 var auditCategories string
 var auditExclude string
 var auditIncludeOnly string
 var auditVerbose bool
+var auditParallel int
+var auditCheckTimeout time.Duration
 
 var auditCmd = &cobra.Command{
 	Use:   "audit",
@@ -20,12 +28,16 @@ func init() {
 	addOutputFlags(auditCmd, &auditOut)
 	addConfigFlags(auditCmd)
 	addReportFlags(auditCmd)
+	addBaselineFlags(auditCmd, &auditBaseline)
+	addFailOnFlag(auditCmd, &auditFailOn)
 	auditCmd.Flags().StringVar(&auditCategories, "categories", "", "Comma-separated list of check categories to run")
 	auditCmd.Flags().StringVar(&auditExclude, "exclude", "", "Comma-separated list of check names to skip")
 	auditCmd.Flags().StringVar(&auditIncludeOnly, "include-only", "", "Comma-separated list of check names to run (whitelist)")
 	auditCmd.Flags().BoolVarP(&auditVerbose, "verbose", "v", false, "Print progress")
+	auditCmd.Flags().IntVar(&auditParallel, "parallel", 1, "Number of checks to run concurrently (each on its own connection)")
+	auditCmd.Flags().DurationVar(&auditCheckTimeout, "check-timeout", 0, "Time limit per check, e.g. 30s (default: from config, else 5m)")
 }
 
 func runAudit(cmd *cobra.Command, args []string) error {
-	return runMode(auditConn, auditOut, auditCategories, auditExclude, auditIncludeOnly, auditVerbose, "audit")
+	return runMode(auditConn, auditOut, auditBaseline, auditFailOn, auditCategories, auditExclude, auditIncludeOnly, auditVerbose, auditParallel, auditCheckTimeout, "audit")
 }
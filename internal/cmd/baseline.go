@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/pgEdge/mm-ready-go/internal/models"
+	"github.com/pgEdge/mm-ready-go/internal/reporter"
+	"github.com/spf13/cobra"
+)
+
+// Baseline flags shared by scan, audit, and analyze commands.
+type baselineFlags struct {
+	// Baseline is the path of a previous JSON report whose findings are known.
+	Baseline string
+	// WriteBaseline is the path to write this run's JSON report to as a baseline.
+	WriteBaseline string
+}
+
+func addBaselineFlags(cmd *cobra.Command, f *baselineFlags) {
+	cmd.Flags().StringVar(&f.Baseline, "baseline", "", "Previous JSON report; matching findings are marked known")
+	cmd.Flags().StringVar(&f.WriteBaseline, "write-baseline", "", "Write this run's findings to a baseline file (JSON)")
+}
+
+// applyBaseline marks the report's findings that appear in the baseline,
+// then writes the report as a new baseline if one was requested.
+func applyBaseline(report *models.ScanReport, bf baselineFlags) error {
+	if bf.Baseline != "" {
+		known, err := reporter.LoadBaseline(bf.Baseline)
+		if err != nil {
+			return err
+		}
+		report.ApplyBaseline(bf.Baseline, known)
+	}
+	if bf.WriteBaseline == "" {
+		return nil
+	}
+
+	if dir := filepath.Dir(bf.WriteBaseline); dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("create baseline directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(bf.WriteBaseline, []byte(reporter.RenderJSON(report)), 0o644); err != nil {
+		return fmt.Errorf("write baseline: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Baseline written to %s\n", bf.WriteBaseline)
+	return nil
+}
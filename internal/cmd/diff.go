@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pgEdge/mm-ready-go/internal/diff"
+	"github.com/pgEdge/mm-ready-go/internal/reporter"
+	"github.com/spf13/cobra"
+)
+
+var diffOut outputFlags
+
+var diffCmd = &cobra.Command{
+	Use:   "diff OLD.json NEW.json",
+	Short: "Compare two JSON reports",
+	Long: "Compare two JSON reports of the same database and list resolved, new, and " +
+		"severity-changed findings, and checks that started or stopped erroring.",
+	Args: cobra.ExactArgs(2),
+	RunE: runDiff,
+}
+
+func init() {
+	addOutputFlags(diffCmd, &diffOut)
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	if err := validateDiffOutput(diffOut); err != nil {
+		return err
+	}
+	oldReport, err := reporter.LoadJSON(args[0])
+	if err != nil {
+		return err
+	}
+	newReport, err := reporter.LoadJSON(args[1])
+	if err != nil {
+		return err
+	}
+
+	d := diff.Compare(oldReport, newReport)
+	return writeOutput(diffOut, newReport.Database+"_diff", func(format string) (string, error) {
+		return reporter.RenderDiff(d, format)
+	})
+}
+
+// validateDiffOutput checks the output flags of diff, which writes fewer
+// formats than a scan report.
+func validateDiffOutput(of outputFlags) error {
+	formats, err := validateOutput(of)
+	if err != nil {
+		return err
+	}
+	for _, f := range formats {
+		if !slices.Contains(reporter.DiffFormats, f) {
+			return fmt.Errorf("diff cannot write %s output; supported formats: %s",
+				f, strings.Join(reporter.DiffFormats, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pgEdge/mm-ready-go/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// Exit codes returned by the mm-ready-go binary.
+const (
+	// ExitOK means the run completed and nothing crossed the --fail-on threshold.
+	ExitOK = 0
+	// ExitError means the run failed: bad flags, a config or I/O error, or an
+	// interrupted scan.
+	ExitError = 1
+	// ExitFindings means findings at or above the --fail-on severity were found.
+	ExitFindings = 2
+	// ExitCheckErrors means one or more checks failed to run and --fail-on was
+	// given.
+	ExitCheckErrors = 3
+	// ExitConnection means the database connection could not be established.
+	ExitConnection = 4
+)
+
+// exitError is an error that carries the process exit code for main.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+
+func (e *exitError) Unwrap() error { return e.err }
+
+// ExitCode returns the process exit code for an error returned by Execute.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var ee *exitError
+	if errors.As(err, &ee) {
+		return ee.code
+	}
+	return ExitError
+}
+
+// failOnSeverities maps --fail-on values to the lowest severity that fails
+// the run.
+var failOnSeverities = map[string]models.Severity{
+	"critical": models.SeverityCritical,
+	"warning":  models.SeverityWarning,
+	"consider": models.SeverityConsider,
+}
+
+func addFailOnFlag(cmd *cobra.Command, failOn *string) {
+	cmd.Flags().StringVar(failOn, "fail-on", "", "Exit 2 on findings at or above this severity (critical, warning, consider), "+
+		"else 3 if a check failed to run; without it, findings and check errors exit 0")
+}
+
+// validateFailOn rejects an unknown --fail-on value before any work is done.
+func validateFailOn(failOn string) error {
+	if failOn == "" {
+		return nil
+	}
+	if _, ok := failOnSeverities[failOn]; !ok {
+		return fmt.Errorf("invalid --fail-on %q: must be critical, warning, or consider", failOn)
+	}
+	return nil
+}
+
+// checkFailOn returns an exitError when failOn is set and the report has
+// new findings at or above its severity, or checks that failed to run.
+// Known and suppressed findings never fail the run, and findings take
+// precedence over check errors. Without failOn, check errors are only
+// reported, so an ungated run keeps exiting 0 once its report is written.
+func checkFailOn(report *models.ScanReport, failOn string) error {
+	if failOn == "" {
+		return nil
+	}
+	threshold := failOnSeverities[failOn]
+
+	failing := 0
+	var errored []string
+	for _, r := range report.Results {
+		if r.Error != "" {
+			errored = append(errored, r.CheckName)
+		}
+		for _, f := range r.Findings {
+			if f.Severity <= threshold && !f.Known {
+				failing++
+			}
+		}
+	}
+
+	switch {
+	case failing > 0:
+		return &exitError{ExitFindings, fmt.Errorf("%d finding(s) at or above %s (--fail-on %s)", failing, threshold, failOn)}
+	case len(errored) > 0:
+		return &exitError{ExitCheckErrors, fmt.Errorf("%d check(s) failed to run: %s", len(errored), strings.Join(errored, ", "))}
+	}
+	return nil
+}
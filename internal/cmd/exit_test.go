@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/pgEdge/mm-ready-go/internal/models"
+)
+
+func failOnReport() *models.ScanReport {
+	return &models.ScanReport{Results: []models.CheckResult{
+		{CheckName: "wal_level", Findings: []models.Finding{
+			{Severity: models.SeverityWarning, Title: "new warning"},
+			{Severity: models.SeverityCritical, Title: "known critical", Known: true},
+		}},
+		{CheckName: "enum_types", Findings: []models.Finding{{Severity: models.SeverityConsider}}},
+		{CheckName: "pg_version", Findings: []models.Finding{{Severity: models.SeverityInfo}}},
+	}}
+}
+
+func TestCheckFailOn(t *testing.T) {
+	report := failOnReport()
+	tests := []struct {
+		failOn string
+		code   int
+	}{
+		{"", ExitOK},
+		{"critical", ExitOK}, // the only CRITICAL finding is known
+		{"warning", ExitFindings},
+		{"consider", ExitFindings},
+	}
+	for _, tt := range tests {
+		if got := ExitCode(checkFailOn(report, tt.failOn)); got != tt.code {
+			t.Errorf("--fail-on %q: exit %d, want %d", tt.failOn, got, tt.code)
+		}
+	}
+}
+
+func TestCheckFailOnCheckErrors(t *testing.T) {
+	report := failOnReport()
+	report.Results = append(report.Results, models.CheckResult{CheckName: "hba_config", Error: "permission denied"})
+
+	if got := ExitCode(checkFailOn(report, "critical")); got != ExitCheckErrors {
+		t.Errorf("check error: exit %d, want %d", got, ExitCheckErrors)
+	}
+	if got := ExitCode(checkFailOn(report, "warning")); got != ExitFindings {
+		t.Errorf("findings should take precedence over check errors: exit %d", got)
+	}
+}
+
+func TestCheckFailOnCheckErrorsWithoutFailOn(t *testing.T) {
+	report := &models.ScanReport{Results: []models.CheckResult{
+		{CheckName: "hba_config", Error: "permission denied"},
+		{CheckName: "wal_level", Findings: []models.Finding{{Severity: models.SeverityCritical}}},
+	}}
+	// Exit code 3 is part of --fail-on gating: an ungated run exits 0.
+	if err := checkFailOn(report, ""); err != nil {
+		t.Errorf("check errors without --fail-on: got %v (exit %d), want nil", err, ExitCode(err))
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	if got := ExitCode(errors.New("boom")); got != ExitError {
+		t.Errorf("plain error: exit %d, want %d", got, ExitError)
+	}
+	conn := formatConnError(errors.New("connection refused"), connFlags{})
+	if got := ExitCode(fmt.Errorf("scan: %w", conn)); got != ExitConnection {
+		t.Errorf("connection error: exit %d, want %d", got, ExitConnection)
+	}
+}
+
+func TestValidateFailOn(t *testing.T) {
+	for _, v := range []string{"", "critical", "warning", "consider"} {
+		if err := validateFailOn(v); err != nil {
+			t.Errorf("validateFailOn(%q): %v", v, err)
+		}
+	}
+	for _, v := range []string{"info", "CRITICAL", "high"} {
+		if err := validateFailOn(v); err == nil {
+			t.Errorf("validateFailOn(%q): expected error", v)
+		}
+	}
+}
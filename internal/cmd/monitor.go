@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/pgEdge/mm-ready-go/internal/config"
 	"github.com/pgEdge/mm-ready-go/internal/connection"
@@ -19,6 +22,8 @@ var monitorLogFile string
 var monitorExclude string
 var monitorIncludeOnly string
 var monitorVerbose bool
+var monitorFailOn string
+var monitorCheckTimeout time.Duration
 
 var monitorCmd = &cobra.Command{
 	Use:   "monitor",
@@ -30,15 +35,25 @@ func init() {
 	addConnFlags(monitorCmd, &monitorConn)
 	addOutputFlags(monitorCmd, &monitorOut)
 	addConfigFlags(monitorCmd)
+	addFailOnFlag(monitorCmd, &monitorFailOn)
 	monitorCmd.Flags().IntVar(&monitorDuration, "duration", 3600, "Observation duration in seconds")
 	monitorCmd.Flags().StringVar(&monitorLogFile, "log-file", "", "Path to PostgreSQL log file")
 	monitorCmd.Flags().StringVar(&monitorExclude, "exclude", "", "Comma-separated list of check names to skip")
 	monitorCmd.Flags().StringVar(&monitorIncludeOnly, "include-only", "", "Comma-separated list of check names to run (whitelist)")
 	monitorCmd.Flags().BoolVarP(&monitorVerbose, "verbose", "v", false, "Print progress")
+	monitorCmd.Flags().DurationVar(&monitorCheckTimeout, "check-timeout", 0, "Time limit per check, e.g. 30s (default: from config, else 5m)")
 }
 
 func runMonitor(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	if err := validateFailOn(monitorFailOn); err != nil {
+		return err
+	}
+	if _, err := validateOutput(monitorOut); err != nil {
+		return err
+	}
+	// Ctrl-C stops the run; whatever completed is still reported.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	conn, err := connection.Connect(ctx, connection.Config{
 		Host:        monitorConn.Host,
@@ -55,7 +70,7 @@ func runMonitor(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return formatConnError(err, monitorConn)
 	}
-	defer conn.Close(ctx)
+	defer conn.Close(context.Background())
 
 	// Load config
 	var cfg config.Config
@@ -84,6 +99,9 @@ func runMonitor(cmd *cobra.Command, args []string) error {
 	}
 
 	checkCfg, _ := config.MergeCLI(cfg, "monitor", splitComma(monitorExclude), splitComma(monitorIncludeOnly), false, false)
+	if monitorCheckTimeout > 0 {
+		cfg.Timeouts.Default = monitorCheckTimeout
+	}
 
 	report, err := monitor.RunMonitor(ctx, conn, monitor.Options{
 		Host:        monitorConn.Host,
@@ -94,15 +112,25 @@ func runMonitor(cmd *cobra.Command, args []string) error {
 		Verbose:     monitorVerbose,
 		Exclude:     checkCfg.Exclude,
 		IncludeOnly: checkCfg.IncludeOnly,
+		Timeouts:    cfg.Timeouts,
 	})
 	if err != nil {
 		return err
 	}
+	stop()
 
-	output, err := reporter.Render(report, monitorOut.Format, reporter.DefaultReportOptions())
-	if err != nil {
+	config.ApplySuppressions(report, cfg.Suppressions)
+	report.Weights = cfg.ScoreWeights()
+	report.SyncThroughput = cfg.InitialSync.Throughput
+
+	render := func(format string) (string, error) {
+		return reporter.Render(report, format, reporter.DefaultReportOptions())
+	}
+	if err := writeOutput(monitorOut, report.Database, render); err != nil {
 		return err
 	}
-
-	return writeOutput(output, monitorOut, report.Database)
+	if report.Partial {
+		return fmt.Errorf("monitor interrupted: partial report contains %d completed checks", len(report.Results))
+	}
+	return checkFailOn(report, monitorFailOn)
 }
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -12,11 +14,79 @@ var formatExt = map[string]string{
 	"json":     ".json",
 	"markdown": ".md",
 	"html":     ".html",
+	"sarif":    ".sarif",
+	"junit":    ".xml",
+	"csv":      ".csv",
+	"tsv":      ".tsv",
+}
+
+// stdoutPath is the --output value that writes the report to stdout.
+const stdoutPath = "-"
+
+// stdout receives reports written with --output -; tests replace it.
+var stdout io.Writer = os.Stdout
+
+// validateOutput checks the output flags before any work is done and
+// returns the formats to write. Writing to stdout allows a single format.
+func validateOutput(of outputFlags) ([]string, error) {
+	formats, err := parseFormats(of.Format)
+	if err != nil {
+		return nil, err
+	}
+	if of.Output == stdoutPath && len(formats) > 1 {
+		return nil, fmt.Errorf("--output - writes a single format, got %d", len(formats))
+	}
+	return formats, nil
+}
+
+// parseFormats splits a comma-separated --format value, dropping duplicates
+// and rejecting unknown formats.
+func parseFormats(s string) ([]string, error) {
+	var formats []string
+	seen := make(map[string]bool)
+	for _, f := range splitComma(s) {
+		if _, ok := formatExt[f]; !ok {
+			return nil, fmt.Errorf("unknown format: %s", f)
+		}
+		if !seen[f] {
+			seen[f] = true
+			formats = append(formats, f)
+		}
+	}
+	if len(formats) == 0 {
+		return nil, fmt.Errorf("no output format given")
+	}
+	return formats, nil
+}
+
+// MakeOutputPaths returns one output path per format, all sharing one
+// timestamp. With more than one format, an extension on userPath is replaced
+// by each format's own, so that the files are siblings.
+func MakeOutputPaths(userPath string, formats []string, dbname string) []string {
+	now := time.Now()
+	if len(formats) > 1 && userPath != "" {
+		if info, err := os.Stat(userPath); err != nil || !info.IsDir() {
+			userPath = strings.TrimSuffix(userPath, filepath.Ext(userPath))
+		}
+	}
+	paths := make([]string, len(formats))
+	for i, format := range formats {
+		if userPath != "" {
+			paths[i] = makeOutputPath(userPath, format, dbname, now)
+		} else {
+			paths[i] = makeDefaultOutputPath(format, dbname, now)
+		}
+	}
+	return paths
 }
 
 // MakeDefaultOutputPath generates a default output path: ./reports/<dbname>_<timestamp>.<ext>.
 func MakeDefaultOutputPath(format, dbname string) string {
-	ts := time.Now().Format("20060102_150405")
+	return makeDefaultOutputPath(format, dbname, time.Now())
+}
+
+func makeDefaultOutputPath(format, dbname string, now time.Time) string {
+	ts := now.Format("20060102_150405")
 	ext := formatExt[format]
 	name := dbname
 	if name == "" {
@@ -29,7 +99,11 @@ func MakeDefaultOutputPath(format, dbname string) string {
 // If the user provides "report.html", the result is "report_20260127_131504.html".
 // If they provide a directory, the file is placed there with an auto-generated name.
 func MakeOutputPath(userPath, format, dbname string) string {
-	ts := time.Now().Format("20060102_150405")
+	return makeOutputPath(userPath, format, dbname, time.Now())
+}
+
+func makeOutputPath(userPath, format, dbname string, now time.Time) string {
+	ts := now.Format("20060102_150405")
 	ext := formatExt[format]
 	name := dbname
 	if name == "" {
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bytes"
 	"os"
 	"regexp"
 	"strings"
@@ -102,3 +103,112 @@ func TestOutputPathPreservesUserExtension(t *testing.T) {
 		t.Errorf("path %q should end with .txt", path)
 	}
 }
+
+// -- MakeOutputPaths ----------------------------------------------------------
+
+func TestOutputPathsShareTimestamp(t *testing.T) {
+	paths := MakeOutputPaths("out/report.html", []string{"html", "json", "markdown"}, "db")
+	want := []string{".html", ".json", ".md"}
+	if len(paths) != len(want) {
+		t.Fatalf("got %d paths, want %d", len(paths), len(want))
+	}
+	ts := tsPattern.FindString(paths[0])
+	for i, path := range paths {
+		if !strings.HasPrefix(path, "out/report_") || !strings.HasSuffix(path, want[i]) {
+			t.Errorf("path %q should be out/report_<ts>%s", path, want[i])
+		}
+		if tsPattern.FindString(path) != ts {
+			t.Errorf("path %q should share timestamp %s", path, ts)
+		}
+	}
+}
+
+func TestOutputPathsDefault(t *testing.T) {
+	paths := MakeOutputPaths("", []string{"json", "csv"}, "mydb")
+	if !strings.HasSuffix(paths[0], ".json") || !strings.HasSuffix(paths[1], ".csv") {
+		t.Errorf("paths = %v", paths)
+	}
+	if strings.TrimSuffix(paths[0], ".json") != strings.TrimSuffix(paths[1], ".csv") {
+		t.Errorf("paths %v should be siblings", paths)
+	}
+}
+
+func TestOutputPathsSingleFormatKeepsExtension(t *testing.T) {
+	paths := MakeOutputPaths("output.txt", []string{"html"}, "db")
+	if !strings.HasSuffix(paths[0], ".txt") {
+		t.Errorf("path %q should end with .txt", paths[0])
+	}
+}
+
+// -- parseFormats -------------------------------------------------------------
+
+func TestParseFormats(t *testing.T) {
+	formats, err := parseFormats("html, json,html,markdown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(formats, ",") != "html,json,markdown" {
+		t.Errorf("formats = %v", formats)
+	}
+	if _, err := parseFormats("html,pdf"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+	if _, err := parseFormats(" , "); err == nil {
+		t.Error("expected error for empty format list")
+	}
+}
+
+// -- writeOutput --------------------------------------------------------------
+
+func TestWriteOutputStdout(t *testing.T) {
+	var buf bytes.Buffer
+	stdout = &buf
+	defer func() { stdout = os.Stdout }()
+
+	render := func(format string) (string, error) { return `{"format":"` + format + `"}`, nil }
+	if err := writeOutput(outputFlags{Format: "json", Output: "-"}, "db", render); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != `{"format":"json"}`+"\n" {
+		t.Errorf("stdout = %q", got)
+	}
+
+	err := writeOutput(outputFlags{Format: "json,html", Output: "-"}, "db", render)
+	if err == nil {
+		t.Error("expected error for several formats on stdout")
+	}
+}
+
+func TestWriteOutputFiles(t *testing.T) {
+	dir := t.TempDir()
+	render := func(format string) (string, error) { return format, nil }
+	if err := writeOutput(outputFlags{Format: "json,markdown", Output: dir}, "db", render); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("wrote %d files, want 2", len(entries))
+	}
+	for _, e := range entries {
+		data, _ := os.ReadFile(dir + "/" + e.Name())
+		if ext := formatExt[string(data)]; !strings.HasSuffix(e.Name(), ext) {
+			t.Errorf("%s holds %s output", e.Name(), data)
+		}
+	}
+}
+
+// -- validateDiffOutput -------------------------------------------------------
+
+func TestValidateDiffOutput(t *testing.T) {
+	for format := range formatExt {
+		if err := validateDiffOutput(outputFlags{Format: format}); err != nil {
+			t.Errorf("diff rejects %s output: %v", format, err)
+		}
+	}
+	if err := validateDiffOutput(outputFlags{Format: "pdf"}); err == nil {
+		t.Error("expected error for unknown diff format")
+	}
+}
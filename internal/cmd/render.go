@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"github.com/pgEdge/mm-ready-go/internal/reporter"
+	"github.com/spf13/cobra"
+)
+
+var renderInput string
+var renderOut outputFlags
+
+var renderCmd = &cobra.Command{
+	Use:   "render",
+	Short: "Render a JSON report in another format",
+	Long: "Load a report written with --format json and render it again, for example " +
+		"as HTML from a JSON report kept by CI.",
+	RunE: runRender,
+}
+
+func init() {
+	renderCmd.Flags().StringVar(&renderInput, "input", "", "Path to a JSON report (required)")
+	_ = renderCmd.MarkFlagRequired("input")
+	addOutputFlags(renderCmd, &renderOut)
+	addReportFlags(renderCmd)
+}
+
+func runRender(cmd *cobra.Command, args []string) error {
+	report, err := reporter.LoadJSON(renderInput)
+	if err != nil {
+		return err
+	}
+
+	reportOpts := reporter.DefaultReportOptions()
+	if noTodo {
+		reportOpts.TodoList = false
+	}
+	if todoIncludeConsider {
+		reportOpts.TodoIncludeConsider = true
+	}
+
+	return writeOutput(renderOut, report.Database, func(format string) (string, error) {
+		return reporter.Render(report, format, reportOpts)
+	})
+}
@@ -19,6 +19,12 @@ var rootCmd = &cobra.Command{
 		_ = cmd.Help()
 		os.Exit(1)
 	},
+	// Errors are printed by main, and usage only for flag errors: by the time
+	// a command runs its flags have parsed.
+	SilenceErrors: true,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		cmd.SilenceUsage = true
+	},
 }
 
 func init() {
@@ -29,6 +35,8 @@ func init() {
 	rootCmd.AddCommand(monitorCmd)
 	rootCmd.AddCommand(listChecksCmd)
 	rootCmd.AddCommand(analyzeCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(renderCmd)
 }
 
 // Execute runs the root command. Called from main().
@@ -38,7 +46,7 @@ func Execute() error {
 		firstArg := os.Args[1]
 		knownCommands := map[string]bool{
 			"scan": true, "audit": true, "monitor": true, "list-checks": true,
-			"analyze": true, "help": true, "completion": true,
+			"analyze": true, "diff": true, "render": true, "help": true, "completion": true,
 		}
 		if !knownCommands[firstArg] && firstArg != "--version" && firstArg != "--help" && firstArg != "-h" && firstArg != "-v" {
 			// Prepend "scan" to args
@@ -74,7 +82,7 @@ type connFlags struct {
 
 // Output flags shared by scan, audit, and monitor commands.
 type outputFlags struct {
-	// Format is the output format (json, markdown, html).
+	// Format is the output format (json, markdown, html, sarif, junit, csv, tsv).
 	Format string
 	// Output is the output file path.
 	Output string
@@ -94,8 +102,8 @@ func addConnFlags(cmd *cobra.Command, f *connFlags) {
 }
 
 func addOutputFlags(cmd *cobra.Command, f *outputFlags) {
-	cmd.Flags().StringVarP(&f.Format, "format", "f", "html", "Report format (json, markdown, html)")
-	cmd.Flags().StringVarP(&f.Output, "output", "o", "", "Output file path (default: ./reports/<dbname>_<timestamp>.<ext>)")
+	cmd.Flags().StringVarP(&f.Format, "format", "f", "html", "Report format (json, markdown, html, sarif, junit, csv, tsv); comma-separate to write several")
+	cmd.Flags().StringVarP(&f.Output, "output", "o", "", "Output file path, or - for stdout (default: ./reports/<dbname>_<timestamp>.<ext>)")
 }
 
 var configPath string
@@ -3,12 +3,17 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/pgEdge/mm-ready-go/internal/config"
 	"github.com/pgEdge/mm-ready-go/internal/connection"
+	"github.com/pgEdge/mm-ready-go/internal/models"
 	"github.com/pgEdge/mm-ready-go/internal/reporter"
 	"github.com/pgEdge/mm-ready-go/internal/scanner"
 	"github.com/spf13/cobra"
@@ -16,10 +21,14 @@ import (
 
 var scanConn connFlags
 var scanOut outputFlags
+var scanBaseline baselineFlags
+var scanFailOn string
 var scanCategories string
 var scanExclude string
 var scanIncludeOnly string
 var scanVerbose bool
+var scanParallel int
+var scanCheckTimeout time.Duration
 
 var scanCmd = &cobra.Command{
 	Use:   "scan",
@@ -32,18 +41,31 @@ func init() {
 	addOutputFlags(scanCmd, &scanOut)
 	addConfigFlags(scanCmd)
 	addReportFlags(scanCmd)
+	addBaselineFlags(scanCmd, &scanBaseline)
+	addFailOnFlag(scanCmd, &scanFailOn)
 	scanCmd.Flags().StringVar(&scanCategories, "categories", "", "Comma-separated list of check categories to run")
 	scanCmd.Flags().StringVar(&scanExclude, "exclude", "", "Comma-separated list of check names to skip")
 	scanCmd.Flags().StringVar(&scanIncludeOnly, "include-only", "", "Comma-separated list of check names to run (whitelist)")
 	scanCmd.Flags().BoolVarP(&scanVerbose, "verbose", "v", false, "Print progress")
+	scanCmd.Flags().IntVar(&scanParallel, "parallel", 1, "Number of checks to run concurrently (each on its own connection)")
+	scanCmd.Flags().DurationVar(&scanCheckTimeout, "check-timeout", 0, "Time limit per check, e.g. 30s (default: from config, else 5m)")
 }
 
 func runScan(cmd *cobra.Command, args []string) error {
-	return runMode(scanConn, scanOut, scanCategories, scanExclude, scanIncludeOnly, scanVerbose, "scan")
+	return runMode(scanConn, scanOut, scanBaseline, scanFailOn, scanCategories, scanExclude, scanIncludeOnly, scanVerbose, scanParallel, scanCheckTimeout, "scan")
 }
 
-func runMode(cf connFlags, of outputFlags, categories string, exclude string, includeOnly string, verbose bool, mode string) error {
-	ctx := context.Background()
+func runMode(cf connFlags, of outputFlags, bf baselineFlags, failOn string, categories string, exclude string, includeOnly string, verbose bool, parallel int, checkTimeout time.Duration, mode string) error {
+	if err := validateFailOn(failOn); err != nil {
+		return err
+	}
+	if _, err := validateOutput(of); err != nil {
+		return err
+	}
+
+	// Ctrl-C cancels the running checks; whatever completed is still reported.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	// Load config
 	var cfg config.Config
@@ -74,8 +96,11 @@ func runMode(cf connFlags, of outputFlags, categories string, exclude string, in
 	}
 
 	checkCfg, reportCfg := config.MergeCLI(cfg, mode, splitComma(exclude), splitComma(includeOnly), noTodo, todoIncludeConsider)
+	if checkTimeout > 0 {
+		cfg.Timeouts.Default = checkTimeout
+	}
 
-	conn, err := connection.Connect(ctx, connection.Config{
+	connCfg := connection.Config{
 		Host:        cf.Host,
 		Port:        cf.Port,
 		DBName:      cf.DBName,
@@ -86,18 +111,14 @@ func runMode(cf connFlags, of outputFlags, categories string, exclude string, in
 		SSLCert:     cf.SSLCert,
 		SSLKey:      cf.SSLKey,
 		SSLRootCert: cf.SSLRootCert,
-	})
-	if err != nil {
-		return formatConnError(err, cf)
 	}
-	defer conn.Close(ctx)
 
 	var cats []string
 	if categories != "" {
 		cats = splitComma(categories)
 	}
 
-	report, err := scanner.RunScan(ctx, conn, scanner.Options{
+	scanOpts := scanner.Options{
 		Host:        cf.Host,
 		Port:        cf.Port,
 		DBName:      cf.DBName,
@@ -106,8 +127,42 @@ func runMode(cf connFlags, of outputFlags, categories string, exclude string, in
 		IncludeOnly: checkCfg.IncludeOnly,
 		Mode:        mode,
 		Verbose:     verbose,
-	})
-	if err != nil {
+		Parallel:    parallel,
+		Timeouts:    cfg.Timeouts,
+	}
+
+	var report *models.ScanReport
+	if parallel > 1 {
+		// One extra connection holds the snapshot the workers import.
+		pool, err := connection.ConnectPool(ctx, connCfg, parallel+1)
+		if err != nil {
+			return formatConnError(err, cf)
+		}
+		defer pool.Close()
+
+		report, err = scanner.RunScanPool(ctx, pool, scanOpts)
+		if err != nil {
+			return err
+		}
+	} else {
+		conn, err := connection.Connect(ctx, connCfg)
+		if err != nil {
+			return formatConnError(err, cf)
+		}
+		defer conn.Close(context.Background())
+
+		report, err = scanner.RunScan(ctx, conn, scanOpts)
+		if err != nil {
+			return err
+		}
+	}
+
+	stop()
+
+	config.ApplySuppressions(report, cfg.Suppressions)
+	report.Weights = cfg.ScoreWeights()
+	report.SyncThroughput = cfg.InitialSync.Throughput
+	if err := applyBaseline(report, bf); err != nil {
 		return err
 	}
 
@@ -115,33 +170,57 @@ func runMode(cf connFlags, of outputFlags, categories string, exclude string, in
 		TodoList:            reportCfg.TodoList,
 		TodoIncludeConsider: reportCfg.TodoIncludeConsider,
 	}
-	output, err := reporter.Render(report, of.Format, reportOpts)
-	if err != nil {
+	render := func(format string) (string, error) {
+		return reporter.Render(report, format, reportOpts)
+	}
+	if err := writeOutput(of, report.Database, render); err != nil {
 		return err
 	}
-
-	return writeOutput(output, of, report.Database)
+	if report.Partial {
+		return fmt.Errorf("scan interrupted: partial report contains %d completed checks", len(report.Results))
+	}
+	return checkFailOn(report, failOn)
 }
 
-func writeOutput(output string, of outputFlags, dbname string) error {
-	var path string
-	if of.Output != "" {
-		path = MakeOutputPath(of.Output, of.Format, dbname)
-	} else {
-		path = MakeDefaultOutputPath(of.Format, dbname)
+// writeOutput renders the report in each format of of.Format and writes the
+// results to sibling files that share one timestamp, or to stdout when
+// of.Output is "-". Every format is rendered before anything is written.
+func writeOutput(of outputFlags, dbname string, render func(format string) (string, error)) error {
+	formats, err := validateOutput(of)
+	if err != nil {
+		return err
+	}
+	outputs := make([]string, len(formats))
+	for i, format := range formats {
+		if outputs[i], err = render(format); err != nil {
+			return fmt.Errorf("render report: %w", err)
+		}
 	}
 
-	dir := filepath.Dir(path)
-	if dir != "" {
-		if err := os.MkdirAll(dir, 0o755); err != nil {
-			return fmt.Errorf("create output directory: %w", err)
+	if of.Output == stdoutPath {
+		out := outputs[0]
+		if !strings.HasSuffix(out, "\n") {
+			out += "\n"
+		}
+		if _, err := io.WriteString(stdout, out); err != nil {
+			return fmt.Errorf("write report: %w", err)
 		}
+		return nil
 	}
 
-	if err := os.WriteFile(path, []byte(output), 0o644); err != nil {
-		return fmt.Errorf("write report: %w", err)
+	for i, path := range MakeOutputPaths(of.Output, formats, dbname) {
+		dir := filepath.Dir(path)
+		if dir != "" {
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return fmt.Errorf("create output directory: %w", err)
+			}
+		}
+
+		if err := os.WriteFile(path, []byte(outputs[i]), 0o644); err != nil {
+			return fmt.Errorf("write report: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Report written to %s\n", path)
 	}
-	fmt.Fprintf(os.Stderr, "Report written to %s\n", path)
 	return nil
 }
 
@@ -179,7 +258,7 @@ func formatConnError(err error, cf connFlags) error {
 	}
 
 	if hint != "" {
-		return fmt.Errorf("could not connect to database.\n       %s\n\n%s", errMsg, hint)
+		return &exitError{ExitConnection, fmt.Errorf("could not connect to database.\n       %s\n\n%s", errMsg, hint)}
 	}
-	return fmt.Errorf("could not connect to database: %s", errMsg)
+	return &exitError{ExitConnection, fmt.Errorf("could not connect to database: %s", errMsg)}
 }
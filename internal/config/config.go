@@ -4,8 +4,15 @@ package config
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/pgEdge/mm-ready-go/internal/check"
+	"github.com/pgEdge/mm-ready-go/internal/models"
 	"gopkg.in/yaml.v3"
 )
 
@@ -24,6 +31,32 @@ type ReportConfig struct {
 	TodoIncludeConsider bool
 }
 
+// TimeoutConfig holds per-check execution time limits.
+type TimeoutConfig struct {
+	// Default is the time limit applied to every check. Zero means no limit.
+	Default time.Duration
+	// Checks holds per-check overrides keyed by check name.
+	Checks map[string]time.Duration
+}
+
+// For returns the time limit for the named check.
+func (t TimeoutConfig) For(checkName string) time.Duration {
+	if d, ok := t.Checks[checkName]; ok {
+		return d
+	}
+	return t.Default
+}
+
+// SyncConfig holds the initial synchronization estimate settings.
+type SyncConfig struct {
+	// Throughput is the expected copy rate in bytes per second. Zero means
+	// copy time is not estimated.
+	Throughput float64
+}
+
+// DefaultCheckTimeout is the per-check time limit used when none is configured.
+const DefaultCheckTimeout = 5 * time.Minute
+
 // Config is the complete configuration for mm-ready-go.
 type Config struct {
 	// Checks holds global check configuration.
@@ -32,15 +65,37 @@ type Config struct {
 	ModeChecks map[string]CheckConfig
 	// Report holds report generation options.
 	Report ReportConfig
+	// Timeouts holds per-check execution time limits.
+	Timeouts TimeoutConfig
+	// Suppressions hides individual findings that have been reviewed.
+	Suppressions []Suppression
+	// Scoring holds the weights of the readiness score.
+	Scoring models.ScoreWeights
+	// InitialSync holds the initial synchronization estimate settings.
+	InitialSync SyncConfig
 }
 
 // Default returns a Config with sensible defaults.
 func Default() Config {
 	return Config{
-		Report: ReportConfig{TodoList: true},
+		Report:   ReportConfig{TodoList: true},
+		Timeouts: TimeoutConfig{Default: DefaultCheckTimeout},
+		Scoring:  models.DefaultScoreWeights(),
 	}
 }
 
+// ScoreWeights returns the configured weights of the readiness score, or nil
+// when they are the defaults, so that reports record only overrides.
+func (c Config) ScoreWeights() *models.ScoreWeights {
+	def := models.DefaultScoreWeights()
+	w := c.Scoring
+	if w.Critical == def.Critical && w.Warning == def.Warning && w.Consider == def.Consider &&
+		len(w.Categories) == 0 {
+		return nil
+	}
+	return &w
+}
+
 // GetCheckConfig returns the merged check config for a specific mode.
 func (c Config) GetCheckConfig(mode string) CheckConfig {
 	global := c.Checks
@@ -105,7 +160,7 @@ func LoadFile(path string) (Config, error) {
 		return Config{}, fmt.Errorf("parse config: %w", err)
 	}
 
-	return raw.toConfig(), nil
+	return raw.toConfig()
 }
 
 // MergeCLI merges CLI arguments with config file settings. CLI takes precedence.
@@ -151,6 +206,14 @@ type yamlConfig struct {
 	Analyze *yamlModeConfig `yaml:"analyze"`
 	// Monitor holds monitor-mode check configuration.
 	Monitor *yamlModeConfig `yaml:"monitor"`
+	// Timeouts holds per-check execution time limits.
+	Timeouts yamlTimeoutConfig `yaml:"timeouts"`
+	// Suppressions hides individual findings that have been reviewed.
+	Suppressions []yamlSuppression `yaml:"suppressions"`
+	// Scoring holds the weights of the readiness score.
+	Scoring yamlScoringConfig `yaml:"scoring"`
+	// InitialSync holds the initial synchronization estimate settings.
+	InitialSync yamlSyncConfig `yaml:"initial_sync"`
 }
 
 type yamlCheckConfig struct {
@@ -167,12 +230,43 @@ type yamlReportConfig struct {
 	TodoIncludeConsider *bool `yaml:"todo_include_consider"`
 }
 
+type yamlTimeoutConfig struct {
+	// Default is the time limit applied to every check (e.g. "60s").
+	Default string `yaml:"default"`
+	// Checks holds per-check overrides keyed by check name.
+	Checks map[string]string `yaml:"checks"`
+}
+
+type yamlSuppression struct {
+	// Check is the name of the check whose findings are suppressed.
+	Check string `yaml:"check"`
+	// Object is a glob matched against each finding's object name.
+	Object string `yaml:"object"`
+	// Reason explains why the findings are acceptable. Required.
+	Reason string `yaml:"reason"`
+	// Expires is the last day the suppression applies (YYYY-MM-DD).
+	Expires string `yaml:"expires"`
+}
+
+type yamlScoringConfig struct {
+	// SeverityWeights holds the share of a check's score lost to its most
+	// severe finding, keyed by critical, warning, and consider.
+	SeverityWeights map[string]float64 `yaml:"severity_weights"`
+	// CategoryWeights holds the weight of each category in the overall score.
+	CategoryWeights map[string]float64 `yaml:"category_weights"`
+}
+
+type yamlSyncConfig struct {
+	// Throughput is the expected copy rate, such as "100MB/s".
+	Throughput string `yaml:"throughput"`
+}
+
 type yamlModeConfig struct {
 	// Checks holds global check configuration.
 	Checks yamlCheckConfig `yaml:"checks"`
 }
 
-func (y yamlConfig) toConfig() Config {
+func (y yamlConfig) toConfig() (Config, error) {
 	cfg := Default()
 
 	cfg.Checks.Exclude = y.Checks.Exclude
@@ -200,5 +294,125 @@ func (y yamlConfig) toConfig() Config {
 		}
 	}
 
-	return cfg
+	if y.Timeouts.Default != "" {
+		d, err := time.ParseDuration(y.Timeouts.Default)
+		if err != nil {
+			return Config{}, fmt.Errorf("parse config: timeouts.default: %w", err)
+		}
+		cfg.Timeouts.Default = d
+	}
+	if len(y.Timeouts.Checks) > 0 {
+		// A misspelled name would otherwise silently get the default limit.
+		known := make(map[string]bool)
+		for _, c := range check.AllRegistered() {
+			known[c.Name()] = true
+		}
+		cfg.Timeouts.Checks = make(map[string]time.Duration, len(y.Timeouts.Checks))
+		for name, v := range y.Timeouts.Checks {
+			if !known[name] {
+				return Config{}, fmt.Errorf("parse config: timeouts.checks: unknown check %q", name)
+			}
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return Config{}, fmt.Errorf("parse config: timeouts.checks.%s: %w", name, err)
+			}
+			cfg.Timeouts.Checks[name] = d
+		}
+	}
+
+	for sev, v := range y.Scoring.SeverityWeights {
+		if v < 0 || v > 1 {
+			return Config{}, fmt.Errorf("parse config: scoring.severity_weights.%s: %v is not between 0 and 1", sev, v)
+		}
+		switch sev {
+		case "critical":
+			cfg.Scoring.Critical = v
+		case "warning":
+			cfg.Scoring.Warning = v
+		case "consider":
+			cfg.Scoring.Consider = v
+		default:
+			return Config{}, fmt.Errorf("parse config: scoring.severity_weights: unknown severity %q", sev)
+		}
+	}
+	for cat, v := range y.Scoring.CategoryWeights {
+		if v < 0 {
+			return Config{}, fmt.Errorf("parse config: scoring.category_weights.%s: %v is negative", cat, v)
+		}
+	}
+	if len(y.Scoring.CategoryWeights) > 0 {
+		cfg.Scoring.Categories = y.Scoring.CategoryWeights
+	}
+
+	if y.InitialSync.Throughput != "" {
+		rate, err := parseThroughput(y.InitialSync.Throughput)
+		if err != nil {
+			return Config{}, fmt.Errorf("parse config: initial_sync.throughput: %w", err)
+		}
+		cfg.InitialSync.Throughput = rate
+	}
+
+	for i, ys := range y.Suppressions {
+		sup, err := ys.toSuppression()
+		if err != nil {
+			return Config{}, fmt.Errorf("parse config: suppressions[%d]: %w", i, err)
+		}
+		cfg.Suppressions = append(cfg.Suppressions, sup)
+	}
+
+	return cfg, nil
+}
+
+func (y yamlSuppression) toSuppression() (Suppression, error) {
+	if y.Check == "" {
+		return Suppression{}, fmt.Errorf("check is required")
+	}
+	if y.Object == "" {
+		return Suppression{}, fmt.Errorf("object is required")
+	}
+	if _, err := path.Match(y.Object, ""); err != nil {
+		return Suppression{}, fmt.Errorf("object %q: %w", y.Object, err)
+	}
+	if strings.TrimSpace(y.Reason) == "" {
+		return Suppression{}, fmt.Errorf("reason is required")
+	}
+
+	sup := Suppression{Check: y.Check, Object: y.Object, Reason: y.Reason}
+	if y.Expires != "" {
+		d, err := time.Parse(time.DateOnly, y.Expires)
+		if err != nil {
+			return Suppression{}, fmt.Errorf("expires: %w", err)
+		}
+		sup.Expires = d
+	}
+	return sup, nil
+}
+
+// throughputRe matches a rate such as "100MB/s", "1.5 GB/s", or "500kB".
+var throughputRe = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([kKMGT]?B)(?:/s)?$`)
+
+// throughputUnits holds the size of each unit in bytes, in powers of 1024
+// as pg_size_pretty uses.
+var throughputUnits = map[string]float64{
+	"B":  1,
+	"KB": 1 << 10,
+	"MB": 1 << 20,
+	"GB": 1 << 30,
+	"TB": 1 << 40,
+}
+
+// parseThroughput parses a copy rate into bytes per second.
+func parseThroughput(s string) (float64, error) {
+	m := throughputRe.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return 0, fmt.Errorf("%q is not a rate such as 100MB/s", s)
+	}
+	n, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q: %w", s, err)
+	}
+	if n <= 0 {
+		return 0, fmt.Errorf("%q must be greater than zero", s)
+	}
+	return n * throughputUnits[strings.ToUpper(m[2])], nil
 }
@@ -3,7 +3,12 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	// Register the checks that timeouts.checks names are validated against.
+	_ "github.com/pgEdge/mm-ready-go/internal/checks"
 )
 
 func TestLoadConfigEmpty(t *testing.T) {
@@ -125,3 +130,203 @@ func TestDiscoverConfigCwd(t *testing.T) {
 		t.Errorf("expected %s, got %s", cfgPath, path)
 	}
 }
+
+func TestLoadConfigTimeouts(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "mm-ready.yaml")
+	content := `
+timeouts:
+  default: 30s
+  checks:
+    stored_procedures: 5m
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := cfg.Timeouts.For("primary_keys"); got != 30*time.Second {
+		t.Errorf("default timeout = %s, want 30s", got)
+	}
+	if got := cfg.Timeouts.For("stored_procedures"); got != 5*time.Minute {
+		t.Errorf("stored_procedures timeout = %s, want 5m", got)
+	}
+}
+
+func TestLoadConfigDefaultTimeout(t *testing.T) {
+	cfg := Default()
+	if got := cfg.Timeouts.For("primary_keys"); got != DefaultCheckTimeout {
+		t.Errorf("default timeout = %s, want %s", got, DefaultCheckTimeout)
+	}
+}
+
+func TestLoadConfigInvalidTimeout(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "mm-ready.yaml")
+	if err := os.WriteFile(path, []byte("timeouts:\n  default: soon\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadFile(path); err == nil {
+		t.Error("expected error for invalid timeout duration")
+	}
+}
+
+func TestLoadConfigUnknownTimeoutCheck(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "mm-ready.yaml")
+	if err := os.WriteFile(path, []byte("timeouts:\n  checks:\n    stored_procedure: 5m\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := LoadFile(path)
+	if err == nil || !strings.Contains(err.Error(), `unknown check "stored_procedure"`) {
+		t.Errorf("expected unknown check error, got %v", err)
+	}
+}
+
+func TestLoadConfigSuppressions(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "mm-ready.yaml")
+	content := `
+suppressions:
+  - check: primary_keys
+    object: "audit.*_log"
+    reason: Insert-only log tables
+    expires: 2026-12-31
+  - check: unlogged_tables
+    object: "*"
+    reason: Scratch tables are rebuilt on each node
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Suppressions) != 2 {
+		t.Fatalf("expected 2 suppressions, got %d", len(cfg.Suppressions))
+	}
+	s := cfg.Suppressions[0]
+	if s.Check != "primary_keys" || s.Object != "audit.*_log" || s.Reason != "Insert-only log tables" {
+		t.Errorf("unexpected suppression %+v", s)
+	}
+	if want := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC); !s.Expires.Equal(want) {
+		t.Errorf("expires = %v, want %v", s.Expires, want)
+	}
+	if !cfg.Suppressions[1].Expires.IsZero() {
+		t.Error("expected no expiry on the second suppression")
+	}
+}
+
+func TestLoadConfigInvalidSuppression(t *testing.T) {
+	cases := map[string]string{
+		"missing reason": "suppressions:\n  - check: primary_keys\n    object: \"*\"\n",
+		"missing check":  "suppressions:\n  - object: \"*\"\n    reason: r\n",
+		"missing object": "suppressions:\n  - check: primary_keys\n    reason: r\n",
+		"bad glob":       "suppressions:\n  - check: primary_keys\n    object: \"[\"\n    reason: r\n",
+		"bad expiry":     "suppressions:\n  - check: primary_keys\n    object: \"*\"\n    reason: r\n    expires: next year\n",
+	}
+	for name, content := range cases {
+		path := filepath.Join(t.TempDir(), "mm-ready.yaml")
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadFile(path); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestLoadConfigScoring(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mm-ready.yaml")
+	content := `
+scoring:
+  severity_weights:
+    warning: 0.25
+  category_weights:
+    replication: 3
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Scoring.Warning != 0.25 {
+		t.Errorf("warning weight = %v, want 0.25", cfg.Scoring.Warning)
+	}
+	if cfg.Scoring.Critical != 1 || cfg.Scoring.Consider != 0.1 {
+		t.Errorf("unset severity weights should keep their defaults, got %+v", cfg.Scoring)
+	}
+	if cfg.Scoring.Categories["replication"] != 3 {
+		t.Errorf("replication weight = %v, want 3", cfg.Scoring.Categories["replication"])
+	}
+	if w := cfg.ScoreWeights(); w == nil || w.Warning != 0.25 {
+		t.Errorf("ScoreWeights() = %+v, want the configured weights", w)
+	}
+	if w := Default().ScoreWeights(); w != nil {
+		t.Errorf("ScoreWeights() of the defaults = %+v, want nil", w)
+	}
+}
+
+func TestLoadConfigInvalidScoring(t *testing.T) {
+	cases := map[string]string{
+		"weight above 1":   "scoring:\n  severity_weights:\n    critical: 2\n",
+		"unknown severity": "scoring:\n  severity_weights:\n    info: 0.5\n",
+		"negative weight":  "scoring:\n  category_weights:\n    schema: -1\n",
+	}
+	for name, content := range cases {
+		path := filepath.Join(t.TempDir(), "mm-ready.yaml")
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadFile(path); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestLoadConfigInitialSync(t *testing.T) {
+	cases := map[string]float64{
+		"100MB/s":  100 << 20,
+		"1.5 GB/s": 1.5 * (1 << 30),
+		"512kB":    512 << 10,
+	}
+	for value, want := range cases {
+		path := filepath.Join(t.TempDir(), "mm-ready.yaml")
+		content := "initial_sync:\n  throughput: " + value + "\n"
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		cfg, err := LoadFile(path)
+		if err != nil {
+			t.Fatalf("%s: %v", value, err)
+		}
+		if cfg.InitialSync.Throughput != want {
+			t.Errorf("%s: throughput = %v, want %v", value, cfg.InitialSync.Throughput, want)
+		}
+	}
+	if Default().InitialSync.Throughput != 0 {
+		t.Error("throughput should not be set by default")
+	}
+
+	for _, bad := range []string{"fast", "100", "0MB/s", "10 Mb/s"} {
+		path := filepath.Join(t.TempDir(), "mm-ready.yaml")
+		content := "initial_sync:\n  throughput: " + bad + "\n"
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadFile(path); err == nil {
+			t.Errorf("%s: expected error", bad)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/pgEdge/mm-ready-go/internal/models"
+)
+
+// ExpiredSuppressionCheck is the check name of the findings reported for
+// expired suppressions.
+const ExpiredSuppressionCheck = "expired_suppressions"
+
+// Suppression hides the findings of one check for the objects matching a
+// glob, such as "audit.*_log".
+type Suppression struct {
+	// Check is the name of the check whose findings are suppressed.
+	Check string
+	// Object is a glob matched against each finding's object name.
+	Object string
+	// Reason explains why the findings are acceptable.
+	Reason string
+	// Expires is the last day the suppression applies. Zero means never.
+	Expires time.Time
+}
+
+// Expired reports whether the suppression no longer applies at now. A
+// suppression applies through the whole of its expiry date.
+func (s Suppression) Expired(now time.Time) bool {
+	if s.Expires.IsZero() {
+		return false
+	}
+	return !now.Before(s.Expires.AddDate(0, 0, 1))
+}
+
+// Matches reports whether f is a finding the suppression hides.
+func (s Suppression) Matches(f models.Finding) bool {
+	if f.CheckName != s.Check {
+		return false
+	}
+	ok, _ := path.Match(s.Object, f.ObjectName)
+	return ok
+}
+
+// ApplySuppressions moves the findings matched by an active suppression
+// into their result's Suppressed list, recording the reason. Each expired
+// suppression is reported as a WARNING finding, and the findings it
+// matched stay in place. Expiry is judged at the report's timestamp.
+func ApplySuppressions(report *models.ScanReport, sups []Suppression) {
+	if len(sups) == 0 {
+		return
+	}
+
+	var active, expired []Suppression
+	for _, s := range sups {
+		if s.Expired(report.Timestamp) {
+			expired = append(expired, s)
+		} else {
+			active = append(active, s)
+		}
+	}
+
+	for i := range report.Results {
+		r := &report.Results[i]
+		var kept []models.Finding
+	findings:
+		for _, f := range r.Findings {
+			for _, s := range active {
+				if s.Matches(f) {
+					f.SuppressionReason = s.Reason
+					r.Suppressed = append(r.Suppressed, f)
+					continue findings
+				}
+			}
+			kept = append(kept, f)
+		}
+		r.Findings = kept
+	}
+
+	if len(expired) == 0 {
+		return
+	}
+	result := models.CheckResult{
+		CheckName:   ExpiredSuppressionCheck,
+		Category:    "suppressions",
+		Description: "Suppressions in the configuration file that have expired",
+	}
+	for _, s := range expired {
+		result.Findings = append(result.Findings, models.Finding{
+			Severity:   models.SeverityWarning,
+			CheckName:  ExpiredSuppressionCheck,
+			Category:   "suppressions",
+			Title:      fmt.Sprintf("Suppression of %s for '%s' expired on %s", s.Check, s.Object, s.Expires.Format(time.DateOnly)),
+			Detail:     fmt.Sprintf("This suppression no longer applies, so the %s findings it covered are reported again.\nReason given: %s", s.Check, s.Reason),
+			ObjectName: s.Check + ":" + s.Object,
+			Remediation: "Resolve the findings, or review the suppression and set a new " +
+				"expires date in mm-ready.yaml.",
+			Metadata: map[string]any{
+				"check":   s.Check,
+				"object":  s.Object,
+				"expires": s.Expires.Format(time.DateOnly),
+			},
+		})
+	}
+	result.SetFingerprints()
+	report.Results = append(report.Results, result)
+}
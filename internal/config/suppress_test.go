@@ -0,0 +1,70 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pgEdge/mm-ready-go/internal/models"
+)
+
+func suppressionReport() *models.ScanReport {
+	return &models.ScanReport{
+		Timestamp: time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC),
+		Results: []models.CheckResult{{
+			CheckName: "primary_keys",
+			Category:  "schema",
+			Findings: []models.Finding{
+				{Severity: models.SeverityWarning, CheckName: "primary_keys", ObjectName: "audit.login_log"},
+				{Severity: models.SeverityWarning, CheckName: "primary_keys", ObjectName: "audit.logins"},
+				{Severity: models.SeverityWarning, CheckName: "primary_keys", ObjectName: "public.events"},
+			},
+		}},
+	}
+}
+
+func TestApplySuppressions(t *testing.T) {
+	report := suppressionReport()
+	ApplySuppressions(report, []Suppression{
+		{Check: "primary_keys", Object: "audit.*_log", Reason: "insert-only"},
+		{Check: "foreign_keys", Object: "*", Reason: "other check"},
+	})
+
+	r := report.Results[0]
+	if len(r.Findings) != 2 || len(r.Suppressed) != 1 {
+		t.Fatalf("got %d findings and %d suppressed, want 2 and 1", len(r.Findings), len(r.Suppressed))
+	}
+	if r.Suppressed[0].ObjectName != "audit.login_log" || r.Suppressed[0].SuppressionReason != "insert-only" {
+		t.Errorf("unexpected suppressed finding %+v", r.Suppressed[0])
+	}
+	if report.WarningCount() != 2 {
+		t.Errorf("WarningCount = %d, suppressed findings should not count", report.WarningCount())
+	}
+	if len(report.Results) != 1 {
+		t.Error("no expired suppressions, so no extra result expected")
+	}
+}
+
+func TestApplySuppressionsExpired(t *testing.T) {
+	report := suppressionReport()
+	ApplySuppressions(report, []Suppression{
+		{Check: "primary_keys", Object: "audit.*", Reason: "until migration",
+			Expires: time.Date(2026, 5, 31, 0, 0, 0, 0, time.UTC)},
+		{Check: "primary_keys", Object: "public.events", Reason: "last day",
+			Expires: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)},
+	})
+
+	if got := len(report.Results[0].Findings); got != 2 {
+		t.Errorf("expired suppression should not hide findings: %d findings, want 2", got)
+	}
+	if len(report.Results) != 2 {
+		t.Fatalf("expected an %s result", ExpiredSuppressionCheck)
+	}
+	expired := report.Results[1]
+	if expired.CheckName != ExpiredSuppressionCheck || len(expired.Findings) != 1 {
+		t.Fatalf("unexpected result %+v", expired)
+	}
+	f := expired.Findings[0]
+	if f.Severity != models.SeverityWarning || f.Fingerprint == "" {
+		t.Errorf("expired suppression finding = %+v", f)
+	}
+}
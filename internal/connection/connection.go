@@ -7,10 +7,18 @@ import (
 	"crypto/x509"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgconn/ctxwatch"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// cancelDeadlineDelay bounds how long a cancelled query may take to
+// acknowledge the cancel request before the socket is forcibly closed.
+const cancelDeadlineDelay = 5 * time.Second
+
 // Config holds the parameters needed to connect to a PostgreSQL database.
 type Config struct {
 	// Host is the database server hostname.
@@ -37,6 +45,50 @@ type Config struct {
 
 // Connect creates a database connection from a Config.
 func Connect(ctx context.Context, cfg Config) (*pgx.Conn, error) {
+	connConfig, err := parseConnConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := pgx.ConnectConfig(ctx, connConfig)
+	if err != nil {
+		return nil, fmt.Errorf("connect to database: %w", err)
+	}
+
+	return conn, nil
+}
+
+// ConnectPool creates a pool of up to maxConns read-only connections from a Config.
+// The first connection is established eagerly so that connection errors surface here.
+func ConnectPool(ctx context.Context, cfg Config, maxConns int) (*pgxpool.Pool, error) {
+	connConfig, err := parseConnConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	poolConfig, err := pgxpool.ParseConfig("")
+	if err != nil {
+		return nil, fmt.Errorf("parse pool config: %w", err)
+	}
+	poolConfig.ConnConfig = connConfig
+	if maxConns > 0 {
+		poolConfig.MaxConns = int32(maxConns)
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf("connect to database: %w", err)
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("connect to database: %w", err)
+	}
+
+	return pool, nil
+}
+
+// parseConnConfig builds a read-only pgx connection config from a Config.
+func parseConnConfig(cfg Config) (*pgx.ConnConfig, error) {
 	// Validate cert/key pairing early, regardless of sslmode
 	if (cfg.SSLCert != "") != (cfg.SSLKey != "") {
 		return nil, fmt.Errorf("both --sslcert and --sslkey must be provided together")
@@ -56,6 +108,16 @@ func Connect(ctx context.Context, cfg Config) (*pgx.Conn, error) {
 	}
 	connConfig.RuntimeParams["default_transaction_read_only"] = "on"
 
+	// When a check's context expires, ask the server to cancel the running
+	// query instead of closing the socket, so the connection stays usable
+	// for the checks that follow.
+	connConfig.BuildContextWatcherHandler = func(pgConn *pgconn.PgConn) ctxwatch.Handler {
+		return &pgconn.CancelRequestContextWatcherHandler{
+			Conn:          pgConn,
+			DeadlineDelay: cancelDeadlineDelay,
+		}
+	}
+
 	if cfg.SSLMode != "" && cfg.SSLMode != "disable" {
 		tlsConfig, tlsErr := buildTLSConfig(cfg)
 		if tlsErr != nil {
@@ -65,12 +127,7 @@ func Connect(ctx context.Context, cfg Config) (*pgx.Conn, error) {
 		connConfig.Fallbacks = nil
 	}
 
-	conn, err := pgx.ConnectConfig(ctx, connConfig)
-	if err != nil {
-		return nil, fmt.Errorf("connect to database: %w", err)
-	}
-
-	return conn, nil
+	return connConfig, nil
 }
 
 // GetPGVersion returns the PostgreSQL server version string.
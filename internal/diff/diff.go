@@ -0,0 +1,157 @@
+// Package diff compares two scan reports of the same database.
+package diff
+
+import (
+	"github.com/pgEdge/mm-ready-go/internal/models"
+)
+
+// SeverityChange is a finding present in both reports at different severities.
+type SeverityChange struct {
+	// Old is the finding as it appears in the old report.
+	Old models.Finding
+	// New is the finding as it appears in the new report.
+	New models.Finding
+}
+
+// Result holds the differences between two reports.
+type Result struct {
+	// Old is the earlier report.
+	Old *models.ScanReport
+	// New is the later report.
+	New *models.ScanReport
+	// Resolved holds findings in the old report that are gone from the new one.
+	Resolved []models.Finding
+	// Added holds findings in the new report that the old one did not have.
+	Added []models.Finding
+	// SeverityChanged holds findings whose severity changed.
+	SeverityChanged []SeverityChange
+	// Unchanged is the number of findings present in both at the same severity.
+	Unchanged int
+	// StartedErroring holds the new report's results for checks that fail
+	// now but did not before.
+	StartedErroring []models.CheckResult
+	// StoppedErroring holds the old report's results for checks that failed
+	// before but run cleanly now.
+	StoppedErroring []models.CheckResult
+}
+
+// Empty reports whether the two reports have no differences.
+func (r *Result) Empty() bool {
+	return len(r.Resolved) == 0 && len(r.Added) == 0 && len(r.SeverityChanged) == 0 &&
+		len(r.StartedErroring) == 0 && len(r.StoppedErroring) == 0
+}
+
+// objectKey identifies the object a finding concerns within its check.
+type objectKey struct {
+	check, object string
+}
+
+// Compare returns the differences between old and new.
+//
+// Findings are matched by fingerprint first. Findings left over on both
+// sides for the same check and object are then paired in report order, so
+// a finding whose wording changed along with its severity still counts as
+// the same finding.
+func Compare(old, new *models.ScanReport) *Result {
+	res := &Result{Old: old, New: new}
+
+	oldFindings := fingerprinted(old)
+	newFindings := fingerprinted(new)
+
+	// Pass 1: identical fingerprints.
+	byPrint := make(map[string][]int)
+	for i, f := range oldFindings {
+		byPrint[f.Fingerprint] = append(byPrint[f.Fingerprint], i)
+	}
+	oldMatched := make([]bool, len(oldFindings))
+	var leftover []models.Finding
+	for _, f := range newFindings {
+		idx := byPrint[f.Fingerprint]
+		if len(idx) == 0 {
+			leftover = append(leftover, f)
+			continue
+		}
+		byPrint[f.Fingerprint] = idx[1:]
+		oldMatched[idx[0]] = true
+		res.pair(oldFindings[idx[0]], f)
+	}
+
+	// Pass 2: same check and object.
+	byObject := make(map[objectKey][]int)
+	for i, f := range oldFindings {
+		if !oldMatched[i] {
+			k := objectKey{f.CheckName, f.ObjectName}
+			byObject[k] = append(byObject[k], i)
+		}
+	}
+	for _, f := range leftover {
+		k := objectKey{f.CheckName, f.ObjectName}
+		idx := byObject[k]
+		if len(idx) == 0 {
+			res.Added = append(res.Added, f)
+			continue
+		}
+		byObject[k] = idx[1:]
+		oldMatched[idx[0]] = true
+		res.pair(oldFindings[idx[0]], f)
+	}
+
+	for i, f := range oldFindings {
+		if !oldMatched[i] {
+			res.Resolved = append(res.Resolved, f)
+		}
+	}
+
+	res.compareErrors()
+	return res
+}
+
+// pair records a finding found in both reports.
+func (r *Result) pair(old, new models.Finding) {
+	if old.Severity != new.Severity {
+		r.SeverityChanged = append(r.SeverityChanged, SeverityChange{Old: old, New: new})
+	} else {
+		r.Unchanged++
+	}
+}
+
+// compareErrors finds the checks whose error state differs. A check that
+// is missing or skipped in either report is left out.
+func (r *Result) compareErrors() {
+	oldResults := make(map[string]models.CheckResult, len(r.Old.Results))
+	for _, cr := range r.Old.Results {
+		oldResults[cr.CheckName] = cr
+	}
+	for _, cr := range r.New.Results {
+		prev, ok := oldResults[cr.CheckName]
+		if !ok || prev.Skipped || cr.Skipped {
+			continue
+		}
+		switch {
+		case cr.Error != "" && prev.Error == "":
+			r.StartedErroring = append(r.StartedErroring, cr)
+		case cr.Error == "" && prev.Error != "":
+			r.StoppedErroring = append(r.StoppedErroring, prev)
+		}
+	}
+}
+
+// fingerprinted returns the report's findings, each with its check name and
+// fingerprint filled in.
+func fingerprinted(report *models.ScanReport) []models.Finding {
+	var all []models.Finding
+	for _, cr := range report.Results {
+		cr.Findings = append([]models.Finding(nil), cr.Findings...)
+		cr.SetFingerprints()
+		for _, f := range cr.Findings {
+			if f.CheckName == "" {
+				f.CheckName = cr.CheckName
+			}
+			if f.Category == "" {
+				f.Category = cr.Category
+			}
+			all = append(all, f)
+		}
+	}
+	return all
+}
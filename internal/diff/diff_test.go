@@ -0,0 +1,83 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/pgEdge/mm-ready-go/internal/models"
+)
+
+func finding(sev models.Severity, check, object, title string) models.Finding {
+	return models.Finding{Severity: sev, CheckName: check, Category: "schema", Title: title, ObjectName: object}
+}
+
+func report(results ...models.CheckResult) *models.ScanReport {
+	return &models.ScanReport{Database: "db", Results: results}
+}
+
+func TestCompareFindings(t *testing.T) {
+	old := report(models.CheckResult{CheckName: "primary_keys", Findings: []models.Finding{
+		finding(models.SeverityWarning, "primary_keys", "public.a", "Table 'public.a' has no primary key"),
+		finding(models.SeverityWarning, "primary_keys", "public.b", "Table 'public.b' has no primary key"),
+	}}, models.CheckResult{CheckName: "pg_version", Findings: []models.Finding{
+		finding(models.SeverityCritical, "pg_version", "PostgreSQL", "PostgreSQL 13 is not supported"),
+	}})
+	new := report(models.CheckResult{CheckName: "primary_keys", Findings: []models.Finding{
+		finding(models.SeverityWarning, "primary_keys", "public.b", "Table 'public.b' has no primary key"),
+		finding(models.SeverityWarning, "primary_keys", "public.c", "Table 'public.c' has no primary key"),
+	}}, models.CheckResult{CheckName: "pg_version", Findings: []models.Finding{
+		finding(models.SeverityInfo, "pg_version", "PostgreSQL", "PostgreSQL 17 is supported"),
+	}})
+
+	d := Compare(old, new)
+	if len(d.Resolved) != 1 || d.Resolved[0].ObjectName != "public.a" {
+		t.Errorf("resolved = %+v, want public.a", d.Resolved)
+	}
+	if len(d.Added) != 1 || d.Added[0].ObjectName != "public.c" {
+		t.Errorf("added = %+v, want public.c", d.Added)
+	}
+	if len(d.SeverityChanged) != 1 {
+		t.Fatalf("severity changed = %+v, want the pg_version finding", d.SeverityChanged)
+	}
+	c := d.SeverityChanged[0]
+	if c.Old.Severity != models.SeverityCritical || c.New.Severity != models.SeverityInfo {
+		t.Errorf("severity change %s -> %s", c.Old.Severity, c.New.Severity)
+	}
+	if d.Unchanged != 1 {
+		t.Errorf("unchanged = %d, want 1", d.Unchanged)
+	}
+	if d.Empty() {
+		t.Error("Empty() = true with changes")
+	}
+}
+
+func TestCompareErrors(t *testing.T) {
+	old := report(
+		models.CheckResult{CheckName: "a", Error: "permission denied"},
+		models.CheckResult{CheckName: "b"},
+		models.CheckResult{CheckName: "c", Skipped: true},
+	)
+	new := report(
+		models.CheckResult{CheckName: "a"},
+		models.CheckResult{CheckName: "b", Error: "timed out after 1s"},
+		models.CheckResult{CheckName: "c", Error: "boom"},
+		models.CheckResult{CheckName: "d", Error: "new check"},
+	)
+
+	d := Compare(old, new)
+	if len(d.StoppedErroring) != 1 || d.StoppedErroring[0].CheckName != "a" || d.StoppedErroring[0].Error != "permission denied" {
+		t.Errorf("stopped erroring = %+v, want a with its old error", d.StoppedErroring)
+	}
+	if len(d.StartedErroring) != 1 || d.StartedErroring[0].CheckName != "b" {
+		t.Errorf("started erroring = %+v, want b", d.StartedErroring)
+	}
+}
+
+func TestCompareIdentical(t *testing.T) {
+	r := report(models.CheckResult{CheckName: "primary_keys", Findings: []models.Finding{
+		finding(models.SeverityWarning, "primary_keys", "public.a", "Table 'public.a' has no primary key"),
+	}})
+	d := Compare(r, r)
+	if !d.Empty() || d.Unchanged != 1 {
+		t.Errorf("identical reports: empty %v unchanged %d", d.Empty(), d.Unchanged)
+	}
+}
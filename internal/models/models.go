@@ -2,8 +2,12 @@
 package models
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
 )
 
@@ -90,8 +94,63 @@ type Finding struct {
 	Remediation string `json:"remediation,omitempty"`
 	// Metadata holds additional key-value data for this finding.
 	Metadata map[string]any `json:"metadata,omitempty"`
+	// Subtype distinguishes the kinds of finding a check can report for one
+	// object. When empty, the title with its quoted names and numbers
+	// removed is used instead.
+	Subtype string `json:"subtype,omitempty"`
+	// Fingerprint identifies this finding across runs; see Fingerprint.
+	Fingerprint string `json:"fingerprint,omitempty"`
+	// Known marks a finding whose fingerprint is in the report's baseline.
+	Known bool `json:"known,omitempty"`
+	// SuppressionReason is the reason given by the suppression that hid
+	// this finding, for findings in CheckResult.Suppressed.
+	SuppressionReason string `json:"suppression_reason,omitempty"`
+	// Line is the line of the analyzed dump file where ObjectName is
+	// defined, or 0 when unknown. Only analyze sets it.
+	Line int `json:"line,omitempty"`
 }
 
+var (
+	quotedRe = regexp.MustCompile(`'[^']*'`)
+	numberRe = regexp.MustCompile(`[0-9]+(\.[0-9]+)?`)
+)
+
+// subtype returns Subtype, or the finding's title with the parts that vary
+// between runs (quoted names, counts, sizes) replaced by placeholders.
+func (f *Finding) subtype() string {
+	if f.Subtype != "" {
+		return f.Subtype
+	}
+	t := quotedRe.ReplaceAllString(f.Title, "?")
+	t = numberRe.ReplaceAllString(t, "#")
+	return strings.ToLower(strings.Join(strings.Fields(t), " "))
+}
+
+// Fingerprint returns a stable identifier for a finding made from the check
+// that reported it, the object it concerns, and its subtype. The same issue
+// on the same object gets the same fingerprint in every run, however its
+// detail text or counts change.
+func Fingerprint(checkName, objectName, subtype string) string {
+	sum := sha256.Sum256([]byte(checkName + "\x00" + objectName + "\x00" + subtype))
+	return hex.EncodeToString(sum[:8])
+}
+
+// ErrorKind classifies why a check failed.
+type ErrorKind string
+
+const (
+	// ErrorKindPermissionDenied means the connected role lacks a privilege the check needs.
+	ErrorKindPermissionDenied ErrorKind = "permission_denied"
+	// ErrorKindUndefinedTable means a relation, schema, or function the check queries does not exist.
+	ErrorKindUndefinedTable ErrorKind = "undefined_table"
+	// ErrorKindTimeout means the check exceeded its time limit or its query was cancelled.
+	ErrorKindTimeout ErrorKind = "timeout"
+	// ErrorKindPanic means the check panicked.
+	ErrorKindPanic ErrorKind = "panic"
+	// ErrorKindQueryError covers any other failure.
+	ErrorKindQueryError ErrorKind = "query_error"
+)
+
 // CheckResult holds the outcome of running a single check.
 type CheckResult struct {
 	// CheckName identifies which check produced this finding.
@@ -102,12 +161,37 @@ type CheckResult struct {
 	Description string `json:"description"`
 	// Findings holds all findings from this check.
 	Findings []Finding `json:"findings"`
+	// Suppressed holds findings hidden by a suppression in the configuration.
+	Suppressed []Finding `json:"suppressed,omitempty"`
 	// Error holds the error message if the check failed.
 	Error string `json:"error,omitempty"`
+	// ErrorKind classifies Error; empty when the check did not fail.
+	ErrorKind ErrorKind `json:"error_kind,omitempty"`
 	// Skipped indicates whether the check was skipped.
 	Skipped bool `json:"skipped,omitempty"`
 	// SkipReason explains why the check was skipped.
 	SkipReason string `json:"skip_reason,omitempty"`
+	// TimedOut indicates the check was cancelled after exceeding its time limit.
+	TimedOut bool `json:"timed_out,omitempty"`
+	// Effort is the check's hint of the work needed to remediate one of its
+	// findings. Empty means small.
+	Effort Effort `json:"effort,omitempty"`
+}
+
+// SetFingerprints fills in the Fingerprint of every finding that lacks one.
+// Findings without a CheckName are attributed to the result's check.
+func (r *CheckResult) SetFingerprints() {
+	for i := range r.Findings {
+		f := &r.Findings[i]
+		if f.Fingerprint != "" {
+			continue
+		}
+		name := f.CheckName
+		if name == "" {
+			name = r.CheckName
+		}
+		f.Fingerprint = Fingerprint(name, f.ObjectName, f.subtype())
+	}
 }
 
 // ScanReport is the top-level result of scanning a database.
@@ -128,6 +212,20 @@ type ScanReport struct {
 	SpockTarget string `json:"spock_target"`
 	// ScanMode is the mode used for this scan.
 	ScanMode string `json:"scan_mode"`
+	// Partial indicates the scan was interrupted before all checks completed.
+	Partial bool `json:"partial,omitempty"`
+	// SnapshotID is the exported snapshot all checks ran in, if any.
+	SnapshotID string `json:"snapshot_id,omitempty"`
+	// StartLSN is the WAL position when the scan's snapshot was taken.
+	StartLSN string `json:"start_lsn,omitempty"`
+	// Baseline is the path of the baseline report findings were compared
+	// with, if any.
+	Baseline string `json:"baseline,omitempty"`
+	// Weights scores the report's readiness; nil means DefaultScoreWeights.
+	Weights *ScoreWeights `json:"weights,omitempty"`
+	// SyncThroughput is the expected initial sync copy rate in bytes per
+	// second; zero means copy time is not estimated.
+	SyncThroughput float64 `json:"sync_throughput,omitempty"`
 }
 
 // NewScanReport creates a ScanReport with sensible defaults.
@@ -151,6 +249,15 @@ func (r *ScanReport) Findings() []Finding {
 	return all
 }
 
+// SuppressedFindings returns all suppressed findings from all check results.
+func (r *ScanReport) SuppressedFindings() []Finding {
+	var all []Finding
+	for _, cr := range r.Results {
+		all = append(all, cr.Suppressed...)
+	}
+	return all
+}
+
 // CriticalCount returns the number of CRITICAL findings.
 func (r *ScanReport) CriticalCount() int {
 	return r.countBySeverity(SeverityCritical)
@@ -204,6 +311,46 @@ func (r *ScanReport) ChecksSkipped() int {
 	return count
 }
 
+// ChecksTimedOut returns the number of checks that exceeded their time limit.
+func (r *ScanReport) ChecksTimedOut() int {
+	count := 0
+	for _, cr := range r.Results {
+		if cr.TimedOut {
+			count++
+		}
+	}
+	return count
+}
+
+// NewCount returns the number of findings not in the baseline. Without a
+// baseline every finding is new.
+func (r *ScanReport) NewCount() int {
+	return len(r.Findings()) - r.KnownCount()
+}
+
+// KnownCount returns the number of findings that are in the baseline.
+func (r *ScanReport) KnownCount() int {
+	count := 0
+	for _, f := range r.Findings() {
+		if f.Known {
+			count++
+		}
+	}
+	return count
+}
+
+// ApplyBaseline marks each finding whose fingerprint is in known as Known
+// and records path as the report's baseline.
+func (r *ScanReport) ApplyBaseline(path string, known map[string]bool) {
+	r.Baseline = path
+	for i := range r.Results {
+		for j := range r.Results[i].Findings {
+			f := &r.Results[i].Findings[j]
+			f.Known = known[f.Fingerprint]
+		}
+	}
+}
+
 func (r *ScanReport) countBySeverity(sev Severity) int {
 	count := 0
 	for _, f := range r.Findings() {
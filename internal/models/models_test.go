@@ -207,6 +207,28 @@ func TestChecksPassedExcludesSkipped(t *testing.T) {
 	}
 }
 
+func TestChecksTimedOut(t *testing.T) {
+	r := &ScanReport{
+		Database:  "db",
+		Host:      "h",
+		Port:      5432,
+		Timestamp: time.Now().UTC(),
+	}
+	r.Results = append(r.Results, CheckResult{
+		CheckName:   "x",
+		Category:    "c",
+		Description: "d",
+		Error:       "timed out after 1s",
+		TimedOut:    true,
+	})
+	if r.ChecksTimedOut() != 1 {
+		t.Errorf("ChecksTimedOut = %d, want 1", r.ChecksTimedOut())
+	}
+	if r.ChecksPassed() != 0 {
+		t.Errorf("ChecksPassed = %d, want 0", r.ChecksPassed())
+	}
+}
+
 // -- Test helpers -------------------------------------------------------------
 
 func makeFinding(opts ...func(*Finding)) Finding {
@@ -326,3 +348,194 @@ func sampleReport() *ScanReport {
 
 	return r
 }
+
+func TestFingerprintStableAcrossCounts(t *testing.T) {
+	a := CheckResult{CheckName: "multiple_unique_indexes", Findings: []Finding{{
+		Title:      "Table 'public.t' has 2 unique indexes",
+		ObjectName: "public.t",
+	}}}
+	b := CheckResult{CheckName: "multiple_unique_indexes", Findings: []Finding{{
+		Title:      "Table 'public.t' has 3 unique indexes",
+		Detail:     "changed detail",
+		ObjectName: "public.t",
+	}}}
+	a.SetFingerprints()
+	b.SetFingerprints()
+	if a.Findings[0].Fingerprint == "" {
+		t.Fatal("fingerprint not set")
+	}
+	if a.Findings[0].Fingerprint != b.Findings[0].Fingerprint {
+		t.Errorf("fingerprints differ: %s vs %s", a.Findings[0].Fingerprint, b.Findings[0].Fingerprint)
+	}
+}
+
+func TestFingerprintDistinguishesObjectAndSubtype(t *testing.T) {
+	base := Fingerprint("primary_keys", "public.a", "x")
+	for _, other := range []string{
+		Fingerprint("primary_keys", "public.b", "x"),
+		Fingerprint("primary_keys", "public.a", "y"),
+		Fingerprint("sequence_pks", "public.a", "x"),
+	} {
+		if other == base {
+			t.Errorf("fingerprint collision: %s", base)
+		}
+	}
+
+	r := CheckResult{CheckName: "c", Findings: []Finding{
+		{Title: "Table 'public.a' is unlogged", ObjectName: "public.a"},
+		{Title: "Table 'public.a' is unlogged", ObjectName: "public.a", Subtype: "other"},
+		{Title: "Kept", ObjectName: "public.a", Fingerprint: "preset"},
+	}}
+	r.SetFingerprints()
+	if r.Findings[0].Fingerprint == r.Findings[1].Fingerprint {
+		t.Error("Subtype should change the fingerprint")
+	}
+	if r.Findings[2].Fingerprint != "preset" {
+		t.Errorf("existing fingerprint overwritten: %s", r.Findings[2].Fingerprint)
+	}
+}
+
+func TestApplyBaseline(t *testing.T) {
+	r := &ScanReport{}
+	r.Results = append(r.Results, CheckResult{CheckName: "c", Findings: []Finding{
+		{Title: "a", Fingerprint: "fa"},
+		{Title: "b", Fingerprint: "fb"},
+		{Title: "c", Fingerprint: "fc"},
+	}})
+	if r.NewCount() != 3 || r.KnownCount() != 0 {
+		t.Errorf("without baseline: new %d known %d, want 3 and 0", r.NewCount(), r.KnownCount())
+	}
+
+	r.ApplyBaseline("base.json", map[string]bool{"fa": true, "fc": true, "gone": true})
+	if r.Baseline != "base.json" {
+		t.Errorf("Baseline = %q", r.Baseline)
+	}
+	if r.NewCount() != 1 || r.KnownCount() != 2 {
+		t.Errorf("new %d known %d, want 1 and 2", r.NewCount(), r.KnownCount())
+	}
+	if r.Results[0].Findings[1].Known {
+		t.Error("finding b should be new")
+	}
+}
+
+// -- Readiness ----------------------------------------------------------------
+
+func TestReadinessDefaultWeights(t *testing.T) {
+	rd := sampleReport().Readiness()
+	// replication: wal_level is CRITICAL (0), hba_config errored and is left
+	// out. schema: WARNING 0.5, CONSIDER 0.9, passing 1. config: INFO 1.
+	want := []CategoryScore{
+		{Category: "config", Score: 100, Checks: 1},
+		{Category: "replication", Score: 0, Checks: 1},
+		{Category: "schema", Score: 80, Checks: 3},
+	}
+	if len(rd.Categories) != len(want) {
+		t.Fatalf("categories = %+v, want %+v", rd.Categories, want)
+	}
+	for i, c := range rd.Categories {
+		if c != want[i] {
+			t.Errorf("category %d = %+v, want %+v", i, c, want[i])
+		}
+	}
+	if rd.Score != 60 {
+		t.Errorf("Score = %d, want 60", rd.Score)
+	}
+}
+
+func TestReadinessConfiguredWeights(t *testing.T) {
+	r := sampleReport()
+	r.Weights = &ScoreWeights{Critical: 1, Warning: 1, Consider: 0, Categories: map[string]float64{"replication": 2}}
+	rd := r.Readiness()
+	// schema: (0 + 1 + 1) / 3; overall (2*0 + 66.7 + 100) / 4.
+	if rd.Categories[2].Score != 67 {
+		t.Errorf("schema score = %d, want 67", rd.Categories[2].Score)
+	}
+	if rd.Score != 42 {
+		t.Errorf("Score = %d, want 42", rd.Score)
+	}
+}
+
+func TestReadinessEmptyReport(t *testing.T) {
+	rd := emptyReport().Readiness()
+	if rd.Score != 100 || len(rd.Categories) != 0 {
+		t.Errorf("empty report readiness = %+v, want score 100 and no categories", rd)
+	}
+	if rd.Effort.String() != "none" {
+		t.Errorf("Effort = %q, want none", rd.Effort)
+	}
+}
+
+func TestReadinessEffort(t *testing.T) {
+	r := sampleReport()
+	r.Results[1].Effort = EffortMedium
+	est := r.Readiness().Effort
+	// INFO findings take no effort; unhinted checks are small.
+	if est.Small != 2 || est.Medium != 1 || est.Large != 0 || est.Hours != 6 {
+		t.Errorf("Effort = %+v, want 2 small, 1 medium, 6 hours", est)
+	}
+	if got := est.String(); got != "about 6 hours (2 small, 1 medium)" {
+		t.Errorf("String = %q", got)
+	}
+
+	r.Results[0].Effort = EffortLarge
+	if got := r.Readiness().Effort.String(); got != "about 3 days (1 small, 1 medium, 1 large)" {
+		t.Errorf("String = %q", got)
+	}
+}
+
+func TestSyncEstimate(t *testing.T) {
+	r := sampleReport()
+	if r.SyncEstimate() != nil {
+		t.Error("report without initial_sync results should have no estimate")
+	}
+
+	r.SyncThroughput = 100 << 20
+	r.Results = append(r.Results, CheckResult{
+		CheckName: SyncCheckName,
+		Findings: []Finding{
+			{Metadata: map[string]any{"tables": int64(4), "data_bytes": float64(12 << 30), "index_bytes": int64(2 << 30)}},
+			{Metadata: map[string]any{"replication_set": "default", "tables": 4, "data_bytes": int64(12 << 30)}},
+		},
+	})
+	est := r.SyncEstimate()
+	if est == nil {
+		t.Fatal("expected an estimate")
+	}
+	want := SyncGroup{Tables: 4, DataBytes: 12 << 30, IndexBytes: 2 << 30}
+	if est.Total != want {
+		t.Errorf("Total = %+v, want %+v", est.Total, want)
+	}
+	if len(est.Sets) != 1 || est.Sets[0].Name != "default" || est.Sets[0].DataBytes != 12<<30 {
+		t.Errorf("Sets = %+v", est.Sets)
+	}
+	if got := est.CopyTime(est.Total); got != 122880*time.Millisecond {
+		t.Errorf("CopyTime = %v", got)
+	}
+	if got := FormatCopyTime(est.CopyTime(est.Total)); got != "about 3 min" {
+		t.Errorf("FormatCopyTime = %q", got)
+	}
+}
+
+func TestFormatBytesAndCopyTime(t *testing.T) {
+	for n, want := range map[int64]string{
+		512:            "512 bytes",
+		1536:           "1.5 kB",
+		5 << 20:        "5.0 MB",
+		3 << 30:        "3.0 GB",
+		int64(2) << 40: "2.0 TB",
+	} {
+		if got := FormatBytes(n); got != want {
+			t.Errorf("FormatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+	for d, want := range map[time.Duration]string{
+		30 * time.Second:                "under a minute",
+		90 * time.Second:                "about 2 min",
+		2 * time.Hour:                   "about 2 h",
+		2*time.Hour + 4*time.Minute + 1: "about 2 h 5 min",
+	} {
+		if got := FormatCopyTime(d); got != want {
+			t.Errorf("FormatCopyTime(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Effort is a rough size for the work needed to remediate one finding.
+type Effort string
+
+const (
+	// EffortSmall is a setting change or a single statement, such as
+	// setting a GUC or creating an index.
+	EffortSmall Effort = "small"
+	// EffortMedium is a schema change on one object, such as adding a
+	// primary key.
+	EffortMedium Effort = "medium"
+	// EffortLarge is an application or architecture change, such as moving
+	// to a new major version or replacing advisory locks.
+	EffortLarge Effort = "large"
+)
+
+// effortHours is the rough number of hours each effort size takes.
+var effortHours = map[Effort]float64{
+	EffortSmall:  1,
+	EffortMedium: 4,
+	EffortLarge:  16,
+}
+
+// ScoreWeights controls how findings lower the readiness score.
+type ScoreWeights struct {
+	// Critical, Warning, and Consider are the share of a check's score lost
+	// when its most severe finding has that severity, from 0 to 1. INFO
+	// findings cost nothing.
+	Critical float64 `json:"critical"`
+	Warning  float64 `json:"warning"`
+	Consider float64 `json:"consider"`
+	// Categories holds the weight of each category in the overall score.
+	// Categories not listed have weight 1.
+	Categories map[string]float64 `json:"categories,omitempty"`
+}
+
+// DefaultScoreWeights returns the weights used when none are configured.
+func DefaultScoreWeights() ScoreWeights {
+	return ScoreWeights{Critical: 1, Warning: 0.5, Consider: 0.1}
+}
+
+// penalty returns the share of a check's score lost to a finding of sev.
+func (w ScoreWeights) penalty(sev Severity) float64 {
+	switch sev {
+	case SeverityCritical:
+		return w.Critical
+	case SeverityWarning:
+		return w.Warning
+	case SeverityConsider:
+		return w.Consider
+	default:
+		return 0
+	}
+}
+
+// category returns the weight of a category in the overall score.
+func (w ScoreWeights) category(name string) float64 {
+	if v, ok := w.Categories[name]; ok {
+		return v
+	}
+	return 1
+}
+
+// CategoryScore is the readiness score of one check category.
+type CategoryScore struct {
+	// Category is the check category.
+	Category string
+	// Score is from 0 (every check has a CRITICAL finding) to 100 (every
+	// check passed).
+	Score int
+	// Checks is the number of checks the score is based on.
+	Checks int
+}
+
+// EffortEstimate is a rough estimate of the work needed to remediate the
+// report's findings.
+type EffortEstimate struct {
+	// Small, Medium, and Large count the findings of each effort size.
+	Small, Medium, Large int
+	// Hours is the estimated total in hours.
+	Hours float64
+}
+
+// String describes the estimate, for example
+// "about 3 days (4 small, 2 medium, 1 large)".
+func (e EffortEstimate) String() string {
+	if e.Small+e.Medium+e.Large == 0 {
+		return "none"
+	}
+	size := fmt.Sprintf("about %g hour%s", e.Hours, plural(e.Hours))
+	if e.Hours >= 16 {
+		days := math.Ceil(e.Hours / 8)
+		size = fmt.Sprintf("about %g day%s", days, plural(days))
+	}
+	var parts []string
+	for _, p := range []struct {
+		n    int
+		name Effort
+	}{{e.Small, EffortSmall}, {e.Medium, EffortMedium}, {e.Large, EffortLarge}} {
+		if p.n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", p.n, p.name))
+		}
+	}
+	return fmt.Sprintf("%s (%s)", size, strings.Join(parts, ", "))
+}
+
+func plural(n float64) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
+
+// Readiness summarizes how close the database is to being ready for Spock.
+type Readiness struct {
+	// Score is the weighted mean of the category scores, from 0 to 100.
+	Score int
+	// Categories holds the score of each category that had checks run,
+	// sorted by name.
+	Categories []CategoryScore
+	// Effort estimates the work needed to remediate the findings.
+	Effort EffortEstimate
+}
+
+// Readiness scores the report using its Weights, or DefaultScoreWeights
+// when none are set.
+//
+// Each check that ran scores 1 less the penalty of its most severe finding.
+// Skipped checks and checks that failed are left out, as their outcome is
+// unknown. A category scores the mean of its checks, and the overall score
+// is the mean of the category scores weighted by category. The effort
+// estimate counts every CRITICAL, WARNING, and CONSIDER finding at the
+// effort size of its check.
+func (r *ScanReport) Readiness() Readiness {
+	w := DefaultScoreWeights()
+	if r.Weights != nil {
+		w = *r.Weights
+	}
+
+	type tally struct {
+		credit float64
+		checks int
+	}
+	tallies := make(map[string]*tally)
+	var est EffortEstimate
+	for _, cr := range r.Results {
+		for _, f := range cr.Findings {
+			if f.Severity == SeverityInfo {
+				continue
+			}
+			effort := cr.Effort
+			if _, ok := effortHours[effort]; !ok {
+				effort = EffortSmall
+			}
+			switch effort {
+			case EffortSmall:
+				est.Small++
+			case EffortMedium:
+				est.Medium++
+			case EffortLarge:
+				est.Large++
+			}
+			est.Hours += effortHours[effort]
+		}
+
+		if cr.Skipped || cr.Error != "" {
+			continue
+		}
+		penalty := 0.0
+		for _, f := range cr.Findings {
+			penalty = math.Max(penalty, w.penalty(f.Severity))
+		}
+		t, ok := tallies[cr.Category]
+		if !ok {
+			t = &tally{}
+			tallies[cr.Category] = t
+		}
+		t.credit += 1 - math.Min(penalty, 1)
+		t.checks++
+	}
+
+	var res Readiness
+	res.Effort = est
+	var weighted, totalWeight float64
+	for cat, t := range tallies {
+		score := 100 * t.credit / float64(t.checks)
+		res.Categories = append(res.Categories, CategoryScore{
+			Category: cat,
+			Score:    int(math.Round(score)),
+			Checks:   t.checks,
+		})
+		weighted += w.category(cat) * score
+		totalWeight += w.category(cat)
+	}
+	sort.Slice(res.Categories, func(i, j int) bool {
+		return res.Categories[i].Category < res.Categories[j].Category
+	})
+	res.Score = 100
+	if totalWeight > 0 {
+		res.Score = int(math.Round(weighted / totalWeight))
+	}
+	return res
+}
@@ -0,0 +1,129 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// SyncCheckName is the name of the check whose findings size the initial
+// synchronization of a new node.
+const SyncCheckName = "initial_sync"
+
+// SyncGroup is the data copied for one group of tables during the initial
+// synchronization.
+type SyncGroup struct {
+	// Name is the replication set, or empty for all replicated tables.
+	Name string
+	// Tables is the number of tables in the group.
+	Tables int
+	// DataBytes is the size of the tables' heap and TOAST data, which the
+	// initial COPY transfers.
+	DataBytes int64
+	// IndexBytes is the size of the tables' indexes, which are rebuilt on
+	// the new node rather than copied.
+	IndexBytes int64
+}
+
+// SyncEstimate sizes the initial synchronization of a new node.
+type SyncEstimate struct {
+	// Total covers every replicated table once.
+	Total SyncGroup
+	// Sets breaks the total down by replication set, when Spock is
+	// installed. A table in several sets is counted in each of them.
+	Sets []SyncGroup
+	// Throughput is the expected copy rate in bytes per second; zero when
+	// none is configured.
+	Throughput float64
+}
+
+// CopyTime returns how long copying g's data takes at the configured
+// throughput, or zero when no throughput is configured.
+func (e SyncEstimate) CopyTime(g SyncGroup) time.Duration {
+	if e.Throughput <= 0 {
+		return 0
+	}
+	return time.Duration(float64(g.DataBytes) / e.Throughput * float64(time.Second))
+}
+
+// SyncEstimate sizes the initial synchronization from the findings of the
+// initial_sync check, using the report's SyncThroughput. It returns nil when
+// the check did not run or found no tables.
+//
+// The check reports the total in a finding without a replication_set
+// metadata key, and each replication set in a finding with one. Sizes are
+// read from the tables, data_bytes, and index_bytes metadata keys.
+func (r *ScanReport) SyncEstimate() *SyncEstimate {
+	for _, cr := range r.Results {
+		if cr.CheckName != SyncCheckName || cr.Skipped || cr.Error != "" {
+			continue
+		}
+		var est *SyncEstimate
+		var sets []SyncGroup
+		for _, f := range cr.Findings {
+			g := SyncGroup{
+				Tables:     int(metaInt(f.Metadata, "tables")),
+				DataBytes:  metaInt(f.Metadata, "data_bytes"),
+				IndexBytes: metaInt(f.Metadata, "index_bytes"),
+			}
+			if name, ok := f.Metadata["replication_set"].(string); ok {
+				g.Name = name
+				sets = append(sets, g)
+				continue
+			}
+			est = &SyncEstimate{Total: g, Throughput: r.SyncThroughput}
+		}
+		if est != nil {
+			est.Sets = sets
+		}
+		return est
+	}
+	return nil
+}
+
+// metaInt reads a whole number from finding metadata. Numbers are int64
+// when a check sets them and may be float64 or int after decoding.
+func metaInt(meta map[string]any, key string) int64 {
+	switch v := meta[key].(type) {
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	case float64:
+		return int64(v)
+	default:
+		return 0
+	}
+}
+
+// FormatBytes renders a size the way pg_size_pretty would, rounded to one
+// decimal place, for example "1.5 GB".
+func FormatBytes(n int64) string {
+	size := float64(n)
+	units := []string{"bytes", "kB", "MB", "GB", "TB"}
+	i := 0
+	for size >= 1024 && i < len(units)-1 {
+		size /= 1024
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%d bytes", n)
+	}
+	return fmt.Sprintf("%.1f %s", size, units[i])
+}
+
+// FormatCopyTime renders a copy time rounded up to the minute, for example
+// "about 2 h 5 min", or "under a minute".
+func FormatCopyTime(d time.Duration) string {
+	minutes := int64(math.Ceil(d.Minutes()))
+	switch {
+	case d < time.Minute:
+		return "under a minute"
+	case minutes < 60:
+		return fmt.Sprintf("about %d min", minutes)
+	case minutes%60 == 0:
+		return fmt.Sprintf("about %d h", minutes/60)
+	default:
+		return fmt.Sprintf("about %d h %d min", minutes/60, minutes%60)
+	}
+}
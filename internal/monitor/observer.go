@@ -8,9 +8,12 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/pgEdge/mm-ready-go/internal/catalog"
 	"github.com/pgEdge/mm-ready-go/internal/check"
+	"github.com/pgEdge/mm-ready-go/internal/config"
 	"github.com/pgEdge/mm-ready-go/internal/connection"
 	"github.com/pgEdge/mm-ready-go/internal/models"
+	"github.com/pgEdge/mm-ready-go/internal/scanner"
 )
 
 // Options configures a monitor run.
@@ -31,9 +34,14 @@ type Options struct {
 	Exclude []string
 	// IncludeOnly lists check names to run exclusively.
 	IncludeOnly []string
+	// Timeouts holds the time limit for each check. Zero means no limit.
+	Timeouts config.TimeoutConfig
 }
 
 // RunMonitor runs a full scan plus time-based observation.
+//
+// If ctx is cancelled, the run stops and the report holds only the checks
+// that completed, with Partial set.
 func RunMonitor(ctx context.Context, conn *pgx.Conn, opts Options) (*models.ScanReport, error) {
 	pgVersion, err := connection.GetPGVersion(ctx, conn)
 	if err != nil {
@@ -57,20 +65,39 @@ func RunMonitor(ctx context.Context, conn *pgx.Conn, opts Options) (*models.Scan
 		fmt.Fprintf(os.Stderr, "Phase 1: Running %d standard checks...\n", total)
 	}
 
+	env, err := check.LoadEnvironment(ctx, conn, checks)
+	if err != nil {
+		return nil, fmt.Errorf("evaluate prerequisites: %w", err)
+	}
+
+	// Structural checks share one catalog, loaded before any of them runs.
+	if check.UsesCatalog(checks) {
+		ctx = catalog.Preload(ctx, conn, opts.Timeouts.Default)
+	}
+
 	for i, c := range checks {
+		if ctx.Err() != nil {
+			report.Partial = true
+			return report, nil
+		}
 		if opts.Verbose {
 			fmt.Fprintf(os.Stderr, "  [%d/%d] %s/%s\n", i+1, total, c.Category(), c.Name())
 		}
-		result := models.CheckResult{
-			CheckName:   c.Name(),
-			Category:    c.Category(),
-			Description: c.Description(),
+		if reason := env.SkipReason(c); reason != "" {
+			report.Results = append(report.Results, models.CheckResult{
+				CheckName:   c.Name(),
+				Category:    c.Category(),
+				Description: c.Description(),
+				Skipped:     true,
+				SkipReason:  reason,
+			})
+			continue
 		}
-		findings, err := c.Run(ctx, conn)
-		if err != nil {
-			result.Error = fmt.Sprintf("%v", err)
-		} else {
-			result.Findings = findings
+		result := scanner.RunCheck(ctx, c, conn, opts.Timeouts.For(c.Name()))
+		if ctx.Err() != nil {
+			// Interrupted mid-check: keep only what completed.
+			report.Partial = true
+			return report, nil
 		}
 		report.Results = append(report.Results, result)
 	}
@@ -81,6 +108,10 @@ func RunMonitor(ctx context.Context, conn *pgx.Conn, opts Options) (*models.Scan
 			fmt.Fprintf(os.Stderr, "\nPhase 2: Observing pg_stat_statements for %ds...\n", opts.Duration)
 		}
 		delta, err := CollectOverDuration(ctx, conn, opts.Duration, opts.Verbose)
+		if ctx.Err() != nil {
+			report.Partial = true
+			return report, nil
+		}
 		if err != nil {
 			report.Results = append(report.Results, models.CheckResult{
 				CheckName:   "pgstat_observation",
@@ -221,6 +252,7 @@ func buildPgstatResult(delta *StatsDelta) models.CheckResult {
 		},
 	})
 
+	result.SetFingerprints()
 	return result
 }
 
@@ -345,5 +377,6 @@ func buildLogResult(analysis *LogAnalysis) models.CheckResult {
 		ObjectName: "(log)",
 	})
 
+	result.SetFingerprints()
 	return result
 }
@@ -97,6 +97,7 @@ func TakeSnapshot(ctx context.Context, conn *pgx.Conn) (map[string]StatementSnap
 }
 
 // CollectOverDuration takes two snapshots separated by duration seconds and computes the delta.
+// Cancelling ctx ends the wait early with ctx's error.
 func CollectOverDuration(ctx context.Context, conn *pgx.Conn, duration int, verbose bool) (*StatsDelta, error) {
 	if verbose {
 		fmt.Fprintln(os.Stderr, "  Taking initial pg_stat_statements snapshot...")
@@ -123,7 +124,11 @@ func CollectOverDuration(ctx context.Context, conn *pgx.Conn, duration int, verb
 		if duration-elapsed < sleepTime {
 			sleepTime = duration - elapsed
 		}
-		time.Sleep(time.Duration(sleepTime) * time.Second)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Duration(sleepTime) * time.Second):
+		}
 		elapsed = int(time.Since(beforeTime).Seconds())
 		if verbose && elapsed < duration {
 			remaining := duration - elapsed
@@ -36,6 +36,10 @@ var (
 
 	reAlterAddIdentity = regexp.MustCompile(`(?i)ALTER\s+TABLE\s+(?:ONLY\s+)?([\w"]+(?:\.[\w"]+)?)\s+ALTER\s+COLUMN\s+([\w"]+)\s+ADD\s+GENERATED\s+(ALWAYS|BY\s+DEFAULT)\s+AS\s+IDENTITY`)
 
+	reIdentitySeqName = regexp.MustCompile(`(?i)\bSEQUENCE\s+NAME\s+([\w"]+(?:\.[\w"]+)?)`)
+
+	reAlterReplicaIdentity = regexp.MustCompile(`(?i)ALTER\s+TABLE\s+(?:ONLY\s+)?([\w"]+(?:\.[\w"]+)?)\s+REPLICA\s+IDENTITY\s+(DEFAULT|FULL|NOTHING|USING\s+INDEX\s+([\w"]+))`)
+
 	reAlterSeqOwned = regexp.MustCompile(`(?i)ALTER\s+SEQUENCE\s+([\w"]+(?:\.[\w"]+)?)\s+OWNED\s+BY\s+([\w"]+(?:\.[\w"]+)?)\.([\w"]+)`)
 
 	reCreateTypeEnum = regexp.MustCompile(`(?i)CREATE\s+TYPE\s+([\w"]+(?:\.[\w"]+)?)\s+AS\s+ENUM\s*\(`)
@@ -74,6 +78,9 @@ var (
 
 	// Index method
 	reIndexMethod = regexp.MustCompile(`(?i)\bUSING\s+(\w+)`)
+	// Index predicate; index expressions cannot contain WHERE, and a column
+	// named where is quoted.
+	reIndexWhere = regexp.MustCompile(`(?i)\sWHERE\s`)
 
 	// Deferrable
 	reDeferrable        = regexp.MustCompile(`(?i)\bDEFERRABLE\b`)
@@ -98,7 +105,7 @@ func ParseDump(filePath string) (*ParsedSchema, error) {
 	statements := splitStatements(text, schema, searchPath)
 
 	for _, stmtInfo := range statements {
-		processStatement(stmtInfo.stmt, stmtInfo.searchPath, schema)
+		processStatement(stmtInfo.stmt, stmtInfo.searchPath, stmtInfo.line, schema)
 	}
 
 	return schema, nil
@@ -107,17 +114,19 @@ func ParseDump(filePath string) (*ParsedSchema, error) {
 type statementInfo struct {
 	stmt       string
 	searchPath string
+	line       int // 1-based line of the statement's first line
 }
 
 // splitStatements splits SQL text into statements, tracking search_path and extracting PG version.
 func splitStatements(text string, schema *ParsedSchema, searchPath string) []statementInfo {
 	var results []statementInfo
 	var buf []string
+	start := 0
 	inDollarQuote := false
 	dollarTag := ""
 
 	lines := strings.Split(text, "\n")
-	for _, line := range lines {
+	for i, line := range lines {
 		stripped := strings.TrimSpace(line)
 
 		// Extract PG version from comment header
@@ -170,12 +179,15 @@ func splitStatements(text string, schema *ParsedSchema, searchPath string) []sta
 			dollarTag = ""
 		}
 
+		if len(buf) == 0 {
+			start = i + 1
+		}
 		buf = append(buf, line)
 
 		// Statement ends at semicolon (outside dollar quotes)
 		if !inDollarQuote && strings.HasSuffix(stripped, ";") {
 			stmt := strings.Join(buf, "\n")
-			results = append(results, statementInfo{stmt: stmt, searchPath: searchPath})
+			results = append(results, statementInfo{stmt: stmt, searchPath: searchPath, line: start})
 			buf = nil
 		}
 	}
@@ -183,14 +195,15 @@ func splitStatements(text string, schema *ParsedSchema, searchPath string) []sta
 	// Flush any remaining buffer
 	if len(buf) > 0 {
 		stmt := strings.Join(buf, "\n")
-		results = append(results, statementInfo{stmt: stmt, searchPath: searchPath})
+		results = append(results, statementInfo{stmt: stmt, searchPath: searchPath, line: start})
 	}
 
 	return results
 }
 
-// processStatement processes a single complete SQL statement.
-func processStatement(stmt string, searchPath string, schema *ParsedSchema) {
+// processStatement processes a single complete SQL statement that starts on
+// the given line of the dump.
+func processStatement(stmt string, searchPath string, line int, schema *ParsedSchema) {
 	upper := strings.ToUpper(strings.TrimSpace(stmt))
 
 	// CREATE EXTENSION
@@ -204,6 +217,7 @@ func processStatement(stmt string, searchPath string, schema *ParsedSchema) {
 			schema.Extensions = append(schema.Extensions, ExtensionDef{
 				Name:       name,
 				SchemaName: extSchema,
+				Line:       line,
 			})
 		}
 		return
@@ -227,6 +241,7 @@ func processStatement(stmt string, searchPath string, schema *ParsedSchema) {
 				SchemaName: s,
 				TypeName:   n,
 				Labels:     labels,
+				Line:       line,
 			})
 		}
 		return
@@ -240,35 +255,14 @@ func processStatement(stmt string, searchPath string, schema *ParsedSchema) {
 				SchemaName:   s,
 				SequenceName: n,
 				DataType:     "bigint",
+				Line:         line,
 			}
 
 			// Parse options
 			if as := reSeqAs.FindStringSubmatch(stmt); as != nil {
 				seq.DataType = strings.ToLower(as[1])
 			}
-			if start := reSeqStart.FindStringSubmatch(stmt); start != nil {
-				if v, err := strconv.ParseInt(start[1], 10, 64); err == nil {
-					seq.StartValue = &v
-				}
-			}
-			if inc := reSeqIncrement.FindStringSubmatch(stmt); inc != nil {
-				if v, err := strconv.ParseInt(inc[1], 10, 64); err == nil {
-					seq.Increment = &v
-				}
-			}
-			if min := reSeqMinValue.FindStringSubmatch(stmt); min != nil {
-				if v, err := strconv.ParseInt(min[1], 10, 64); err == nil {
-					seq.MinValue = &v
-				}
-			}
-			if max := reSeqMaxValue.FindStringSubmatch(stmt); max != nil {
-				if v, err := strconv.ParseInt(max[1], 10, 64); err == nil {
-					seq.MaxValue = &v
-				}
-			}
-			if reSeqCycle.MatchString(stmt) && !reSeqNoCycle.MatchString(stmt) {
-				seq.Cycle = true
-			}
+			parseSequenceOptions(stmt, &seq)
 
 			schema.Sequences = append(schema.Sequences, seq)
 		}
@@ -290,6 +284,7 @@ func processStatement(stmt string, searchPath string, schema *ParsedSchema) {
 			SchemaName: s,
 			TableName:  n,
 			Unlogged:   unlogged,
+			Line:       line,
 		}
 
 		// Extract column body
@@ -328,6 +323,7 @@ func processStatement(stmt string, searchPath string, schema *ParsedSchema) {
 			ConstraintType: conType,
 			TableSchema:    s,
 			TableName:      n,
+			Line:           line,
 		}
 
 		// Extract columns from parentheses after constraint type keyword
@@ -380,6 +376,7 @@ func processStatement(stmt string, searchPath string, schema *ParsedSchema) {
 			TableName:   n,
 			IsUnique:    isUnique,
 			Method:      "btree",
+			Line:        line,
 		}
 
 		// Extract method
@@ -394,6 +391,7 @@ func processStatement(stmt string, searchPath string, schema *ParsedSchema) {
 		if colContent != "" {
 			idx.Columns = parseColumnList(colContent)
 		}
+		idx.Partial = reIndexWhere.MatchString(stmt)
 
 		schema.Indexes = append(schema.Indexes, idx)
 		return
@@ -433,6 +431,43 @@ func processStatement(stmt string, searchPath string, schema *ParsedSchema) {
 					}
 				}
 			}
+
+			// The identity sequence is only named inside this statement;
+			// it takes the column's data type.
+			if sm := reIdentitySeqName.FindStringSubmatch(stmt); sm != nil {
+				seqS, seqN := splitQualified(sm[1], searchPath)
+				seq := SequenceDef{
+					SchemaName:    seqS,
+					SequenceName:  seqN,
+					DataType:      "bigint",
+					OwnedByTable:  s + "." + n,
+					OwnedByColumn: col,
+					Line:          line,
+				}
+				if tbl != nil {
+					for _, c := range tbl.Columns {
+						if c.Name == col {
+							seq.DataType = strings.ToLower(c.DataType)
+							break
+						}
+					}
+				}
+				parseSequenceOptions(stmt, &seq)
+				schema.Sequences = append(schema.Sequences, seq)
+			}
+		}
+		return
+	}
+
+	// ALTER TABLE REPLICA IDENTITY
+	if m := reAlterReplicaIdentity.FindStringSubmatch(stmt); m != nil {
+		s, n := splitQualified(m[1], searchPath)
+		if tbl := schema.GetTable(s, n); tbl != nil && !excludedSchemas[s] {
+			tbl.ReplicaIdentity = strings.ToUpper(m[2])
+			if m[3] != "" {
+				tbl.ReplicaIdentity = "INDEX"
+				tbl.ReplicaIdentityIndex = unquote(m[3])
+			}
 		}
 		return
 	}
@@ -465,6 +500,7 @@ func processStatement(stmt string, searchPath string, schema *ParsedSchema) {
 				RuleName:   ruleName,
 				Event:      event,
 				IsInstead:  isInstead,
+				Line:       line,
 			})
 		}
 		return
@@ -588,6 +624,35 @@ func parseColumn(line string) *ColumnDef {
 	}
 }
 
+// parseSequenceOptions reads START WITH, INCREMENT BY, MINVALUE, MAXVALUE, and
+// CYCLE from a CREATE SEQUENCE statement or an identity column's sequence
+// options into seq.
+func parseSequenceOptions(stmt string, seq *SequenceDef) {
+	if start := reSeqStart.FindStringSubmatch(stmt); start != nil {
+		if v, err := strconv.ParseInt(start[1], 10, 64); err == nil {
+			seq.StartValue = &v
+		}
+	}
+	if inc := reSeqIncrement.FindStringSubmatch(stmt); inc != nil {
+		if v, err := strconv.ParseInt(inc[1], 10, 64); err == nil {
+			seq.Increment = &v
+		}
+	}
+	if min := reSeqMinValue.FindStringSubmatch(stmt); min != nil {
+		if v, err := strconv.ParseInt(min[1], 10, 64); err == nil {
+			seq.MinValue = &v
+		}
+	}
+	if max := reSeqMaxValue.FindStringSubmatch(stmt); max != nil {
+		if v, err := strconv.ParseInt(max[1], 10, 64); err == nil {
+			seq.MaxValue = &v
+		}
+	}
+	if reSeqCycle.MatchString(stmt) && !reSeqNoCycle.MatchString(stmt) {
+		seq.Cycle = true
+	}
+}
+
 // parseInlineConstraint parses inline table-level constraints within CREATE TABLE body.
 func parseInlineConstraint(text string, tbl *TableDef, searchPath string, schema *ParsedSchema) {
 	// CONSTRAINT name TYPE (cols)
@@ -608,6 +673,7 @@ func parseInlineConstraint(text string, tbl *TableDef, searchPath string, schema
 			TableSchema:    tbl.SchemaName,
 			TableName:      tbl.TableName,
 			Columns:        parseColumnList(colContent),
+			Line:           tbl.Line,
 		})
 	} else if strings.HasPrefix(restUpper, "UNIQUE") {
 		colContent := extractParenContent(rest)
@@ -617,6 +683,7 @@ func parseInlineConstraint(text string, tbl *TableDef, searchPath string, schema
 			TableSchema:    tbl.SchemaName,
 			TableName:      tbl.TableName,
 			Columns:        parseColumnList(colContent),
+			Line:           tbl.Line,
 		}
 		if reDeferrable.MatchString(rest) && !reNotDeferrable.MatchString(rest) {
 			con.Deferrable = true
@@ -630,6 +697,7 @@ func parseInlineConstraint(text string, tbl *TableDef, searchPath string, schema
 			TableSchema:    tbl.SchemaName,
 			TableName:      tbl.TableName,
 			Columns:        parseColumnList(colContent),
+			Line:           tbl.Line,
 		}
 		if ref := reFkReferences.FindStringSubmatch(rest); ref != nil {
 			rs, rn := splitQualified(ref[1], searchPath)
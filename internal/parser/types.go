@@ -1,6 +1,8 @@
 // Package parser provides SQL dump parsing for offline schema analysis.
 package parser
 
+import "strings"
+
 // ColumnDef represents a column definition within a table.
 type ColumnDef struct {
 	Name          string // Column name
@@ -25,6 +27,7 @@ type ConstraintDef struct {
 	OnUpdate          string   // FK: ON UPDATE action
 	Deferrable        bool     // Constraint is DEFERRABLE
 	InitiallyDeferred bool     // Constraint is INITIALLY DEFERRED
+	Line              int      // Line in the dump where the constraint is defined
 }
 
 // IndexDef represents an index on a table.
@@ -35,6 +38,8 @@ type IndexDef struct {
 	Columns     []string // Indexed columns
 	IsUnique    bool     // Is a unique index
 	Method      string   // Index method (btree, hash, gist, etc.)
+	Partial     bool     // Has a WHERE predicate
+	Line        int      // Line in the dump where the index is created
 }
 
 // SequenceDef represents a sequence object.
@@ -49,6 +54,7 @@ type SequenceDef struct {
 	Cycle         bool   // CYCLE option enabled
 	OwnedByTable  string // Table owning this sequence (schema.table)
 	OwnedByColumn string // Column owning this sequence
+	Line          int    // Line in the dump where the sequence is created
 }
 
 // TableDef represents a table definition.
@@ -59,12 +65,18 @@ type TableDef struct {
 	Unlogged    bool        // Is an UNLOGGED table
 	Inherits    []string    // Parent tables (for table inheritance)
 	PartitionBy string      // PARTITION BY clause if partitioned
+	// ReplicaIdentity is DEFAULT, FULL, NOTHING, or INDEX when the dump
+	// sets it with ALTER TABLE ... REPLICA IDENTITY, else empty.
+	ReplicaIdentity      string
+	ReplicaIdentityIndex string // Index named by REPLICA IDENTITY USING INDEX
+	Line                 int    // Line in the dump where the table is created
 }
 
 // ExtensionDef represents an installed extension.
 type ExtensionDef struct {
 	Name       string // Extension name
 	SchemaName string // Schema where extension is installed
+	Line       int    // Line in the dump where the extension is created
 }
 
 // EnumTypeDef represents a custom ENUM type.
@@ -72,6 +84,7 @@ type EnumTypeDef struct {
 	SchemaName string   // Schema containing the type
 	TypeName   string   // Type name
 	Labels     []string // Enum labels/values
+	Line       int      // Line in the dump where the type is created
 }
 
 // RuleDef represents a rule on a table.
@@ -81,6 +94,7 @@ type RuleDef struct {
 	RuleName   string // Rule name
 	Event      string // Event: INSERT, UPDATE, DELETE, SELECT
 	IsInstead  bool   // Is DO INSTEAD rule
+	Line       int    // Line in the dump where the rule is created
 }
 
 // ParsedSchema contains all parsed objects from a pg_dump SQL file.
@@ -129,3 +143,49 @@ func (s *ParsedSchema) GetIndexesForTable(schema, name string) []IndexDef {
 	}
 	return result
 }
+
+// ObjectLine returns the line in the dump where the object a finding names
+// is defined, or 0 if it is not known. It accepts the object names checks
+// use: "schema.name" for tables, sequences, and types, and
+// "schema.table.name" for constraints, rules, and columns. A column falls
+// back to its table's line.
+func (s *ParsedSchema) ObjectLine(object string) int {
+	for _, t := range s.Tables {
+		fqn := t.SchemaName + "." + t.TableName
+		if object == fqn {
+			return t.Line
+		}
+		if strings.HasPrefix(object, fqn+".") {
+			name := object[len(fqn)+1:]
+			for _, c := range s.Constraints {
+				if c.TableSchema == t.SchemaName && c.TableName == t.TableName && c.Name == name {
+					return c.Line
+				}
+			}
+			for _, r := range s.Rules {
+				if r.SchemaName == t.SchemaName && r.TableName == t.TableName && r.RuleName == name {
+					return r.Line
+				}
+			}
+			if !strings.Contains(name, ".") {
+				return t.Line
+			}
+		}
+	}
+	for _, q := range s.Sequences {
+		if object == q.SchemaName+"."+q.SequenceName {
+			return q.Line
+		}
+	}
+	for _, e := range s.EnumTypes {
+		if object == e.SchemaName+"."+e.TypeName {
+			return e.Line
+		}
+	}
+	for _, x := range s.Extensions {
+		if object == x.Name {
+			return x.Line
+		}
+	}
+	return 0
+}
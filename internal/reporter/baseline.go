@@ -0,0 +1,25 @@
+package reporter
+
+import (
+	"fmt"
+)
+
+// LoadBaseline reads a JSON report written by RenderJSON and returns the
+// fingerprints of its findings. Findings in reports written before
+// fingerprints existed are fingerprinted from their check, object, and
+// title, as SetFingerprints would.
+func LoadBaseline(path string) (map[string]bool, error) {
+	report, err := LoadJSON(path)
+	if err != nil {
+		return nil, fmt.Errorf("load baseline: %w", err)
+	}
+
+	known := make(map[string]bool)
+	for _, r := range report.Results {
+		r.SetFingerprints()
+		for _, f := range r.Findings {
+			known[f.Fingerprint] = true
+		}
+	}
+	return known, nil
+}
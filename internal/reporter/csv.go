@@ -0,0 +1,165 @@
+package reporter
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pgEdge/mm-ready-go/internal/models"
+)
+
+// csvColumns are the fixed columns of CSV and TSV output. Flattened
+// metadata columns follow them.
+var csvColumns = []string{
+	"severity", "category", "check", "schema", "table", "object",
+	"title", "remediation", "fingerprint",
+}
+
+// qualifiedRe matches "schema.table" and "schema.table.column" style names.
+var qualifiedRe = regexp.MustCompile(`^([^.\s()]+)\.([^.\s()]+)(\.[^.\s()]+)?$`)
+
+// RenderCSV renders one row per finding as CSV for spreadsheet triage.
+func RenderCSV(report *models.ScanReport) string {
+	return writeCSV(findingRows(report))
+}
+
+// RenderTSV renders one row per finding as tab-separated values. Tabs and
+// line breaks inside a value are replaced by spaces, as TSV has no quoting.
+func RenderTSV(report *models.ScanReport) string {
+	return writeTSV(findingRows(report))
+}
+
+// writeCSV formats rows as CSV.
+func writeCSV(rows [][]string) string {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	for _, row := range rows {
+		_ = w.Write(row)
+	}
+	w.Flush()
+	return b.String()
+}
+
+// writeTSV formats rows as tab-separated values.
+func writeTSV(rows [][]string) string {
+	clean := strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ", "\r", " ")
+	var b strings.Builder
+	for _, row := range rows {
+		for i, v := range row {
+			row[i] = clean.Replace(v)
+		}
+		b.WriteString(strings.Join(row, "\t"))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// findingRows returns the header and one row per finding. Each metadata key
+// used by any finding gets a "metadata.<key>" column; nested objects are
+// flattened into dotted keys.
+func findingRows(report *models.ScanReport) [][]string {
+	type entry struct {
+		check, category string
+		finding         models.Finding
+		meta            map[string]string
+	}
+	var entries []entry
+	keys := make(map[string]bool)
+	for _, r := range report.Results {
+		for _, f := range r.Findings {
+			meta := make(map[string]string)
+			flattenMetadata("", f.Metadata, meta)
+			for k := range meta {
+				keys[k] = true
+			}
+			entries = append(entries, entry{r.CheckName, r.Category, f, meta})
+		}
+	}
+
+	metaKeys := make([]string, 0, len(keys))
+	for k := range keys {
+		metaKeys = append(metaKeys, k)
+	}
+	sort.Strings(metaKeys)
+
+	header := append([]string(nil), csvColumns...)
+	for _, k := range metaKeys {
+		header = append(header, "metadata."+k)
+	}
+	rows := [][]string{header}
+
+	for _, e := range entries {
+		f := e.finding
+		schema, table := splitObject(e.category, f.ObjectName)
+		row := []string{
+			f.Severity.String(), e.category, e.check, schema, table, f.ObjectName,
+			f.Title, f.Remediation, f.Fingerprint,
+		}
+		for _, k := range metaKeys {
+			row = append(row, e.meta[k])
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// splitObject returns the schema and table of a qualified object name, or
+// empty strings when the name is not one. Config findings name settings,
+// such as spock.conflict_resolution, and names ending in .conf are files, so
+// neither is split.
+func splitObject(category, object string) (schema, table string) {
+	if category == "config" || strings.HasSuffix(object, ".conf") {
+		return "", ""
+	}
+	m := qualifiedRe.FindStringSubmatch(object)
+	if m == nil {
+		return "", ""
+	}
+	return m[1], m[2]
+}
+
+// flattenMetadata writes meta into out as strings, joining nested object
+// keys with dots and list elements with "; ".
+func flattenMetadata(prefix string, meta map[string]any, out map[string]string) {
+	for k, v := range meta {
+		if prefix != "" {
+			k = prefix + "." + k
+		}
+		if nested, ok := v.(map[string]any); ok {
+			flattenMetadata(k, nested, out)
+			continue
+		}
+		out[k] = metadataString(v)
+	}
+}
+
+// metadataString formats a metadata value for a single cell.
+func metadataString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case []string:
+		return strings.Join(v, "; ")
+	case []any:
+		parts := make([]string, len(v))
+		for i, e := range v {
+			parts[i] = metadataString(e)
+		}
+		return strings.Join(parts, "; ")
+	case map[string]any:
+		data, _ := json.Marshal(v)
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
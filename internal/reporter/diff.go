@@ -0,0 +1,589 @@
+package reporter
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/pgEdge/mm-ready-go/internal/diff"
+	"github.com/pgEdge/mm-ready-go/internal/models"
+)
+
+// DiffFormats lists the formats RenderDiff can write.
+var DiffFormats = []string{"json", "markdown", "html", "sarif", "junit", "csv", "tsv"}
+
+// RenderDiff dispatches to the appropriate diff renderer based on format.
+func RenderDiff(d *diff.Result, format string) (string, error) {
+	switch format {
+	case "json":
+		return RenderDiffJSON(d), nil
+	case "markdown":
+		return RenderDiffMarkdown(d), nil
+	case "html":
+		return RenderDiffHTML(d), nil
+	case "sarif":
+		return RenderDiffSARIF(d), nil
+	case "junit":
+		return RenderDiffJUnit(d), nil
+	case "csv":
+		return writeCSV(diffRows(d)), nil
+	case "tsv":
+		return writeTSV(diffRows(d)), nil
+	default:
+		return "", fmt.Errorf("unknown format: %s", format)
+	}
+}
+
+type jsonDiff struct {
+	// Meta describes the two reports compared.
+	Meta jsonDiffMeta `json:"meta"`
+	// Summary holds the number of each kind of change.
+	Summary jsonDiffSummary `json:"summary"`
+	// Resolved holds findings gone from the new report.
+	Resolved []jsonDiffFinding `json:"resolved"`
+	// New holds findings the old report did not have.
+	New []jsonDiffFinding `json:"new"`
+	// SeverityChanged holds findings whose severity changed.
+	SeverityChanged []jsonSeverityChange `json:"severity_changed"`
+	// StartedErroring holds checks that fail now but did not before.
+	StartedErroring []jsonDiffError `json:"started_erroring"`
+	// StoppedErroring holds checks that failed before but run cleanly now.
+	StoppedErroring []jsonDiffError `json:"stopped_erroring"`
+}
+
+type jsonDiffMeta struct {
+	// Tool is the tool name.
+	Tool string `json:"tool"`
+	// Version is the tool version.
+	Version string `json:"version"`
+	// Old describes the earlier report.
+	Old jsonDiffReport `json:"old"`
+	// New describes the later report.
+	New jsonDiffReport `json:"new"`
+}
+
+type jsonDiffReport struct {
+	// Database is the database name.
+	Database string `json:"database"`
+	// Host is the database server hostname.
+	Host string `json:"host"`
+	// Timestamp is when the scan was performed.
+	Timestamp string `json:"timestamp"`
+}
+
+type jsonDiffSummary struct {
+	// Resolved is the count of resolved findings.
+	Resolved int `json:"resolved"`
+	// New is the count of new findings.
+	New int `json:"new"`
+	// SeverityChanged is the count of findings whose severity changed.
+	SeverityChanged int `json:"severity_changed"`
+	// Unchanged is the count of findings present in both reports.
+	Unchanged int `json:"unchanged"`
+	// StartedErroring is the count of checks that started failing.
+	StartedErroring int `json:"started_erroring"`
+	// StoppedErroring is the count of checks that stopped failing.
+	StoppedErroring int `json:"stopped_erroring"`
+}
+
+type jsonDiffFinding struct {
+	// CheckName identifies which check produced this finding.
+	CheckName string `json:"check_name"`
+	// Category is the check category.
+	Category string `json:"category"`
+	jsonFinding
+}
+
+type jsonSeverityChange struct {
+	// CheckName identifies which check produced this finding.
+	CheckName string `json:"check_name"`
+	// Category is the check category.
+	Category string `json:"category"`
+	// ObjectName is the database object this finding relates to.
+	ObjectName string `json:"object_name"`
+	// OldSeverity is the severity in the old report.
+	OldSeverity string `json:"old_severity"`
+	// NewSeverity is the severity in the new report.
+	NewSeverity string `json:"new_severity"`
+	// Title is the finding's title in the new report.
+	Title string `json:"title"`
+	// Fingerprint identifies the finding in the new report.
+	Fingerprint string `json:"fingerprint"`
+}
+
+type jsonDiffError struct {
+	// CheckName identifies the check.
+	CheckName string `json:"check_name"`
+	// Category is the check category.
+	Category string `json:"category"`
+	// Error is the check's error message.
+	Error string `json:"error"`
+	// ErrorKind classifies Error.
+	ErrorKind string `json:"error_kind,omitempty"`
+}
+
+// RenderDiffJSON renders the differences between two reports as JSON.
+func RenderDiffJSON(d *diff.Result) string {
+	data := jsonDiff{
+		Meta: jsonDiffMeta{
+			Tool:    "mm-ready-go",
+			Version: "0.1.0",
+			Old:     diffReportMeta(d.Old),
+			New:     diffReportMeta(d.New),
+		},
+		Summary: jsonDiffSummary{
+			Resolved:        len(d.Resolved),
+			New:             len(d.Added),
+			SeverityChanged: len(d.SeverityChanged),
+			Unchanged:       d.Unchanged,
+			StartedErroring: len(d.StartedErroring),
+			StoppedErroring: len(d.StoppedErroring),
+		},
+		Resolved:        diffFindings(d.Resolved),
+		New:             diffFindings(d.Added),
+		SeverityChanged: make([]jsonSeverityChange, 0, len(d.SeverityChanged)),
+		StartedErroring: diffErrors(d.StartedErroring),
+		StoppedErroring: diffErrors(d.StoppedErroring),
+	}
+	for _, c := range d.SeverityChanged {
+		data.SeverityChanged = append(data.SeverityChanged, jsonSeverityChange{
+			CheckName:   c.New.CheckName,
+			Category:    c.New.Category,
+			ObjectName:  c.New.ObjectName,
+			OldSeverity: c.Old.Severity.String(),
+			NewSeverity: c.New.Severity.String(),
+			Title:       c.New.Title,
+			Fingerprint: c.New.Fingerprint,
+		})
+	}
+
+	out, _ := json.MarshalIndent(data, "", "  ")
+	return string(out)
+}
+
+func diffReportMeta(r *models.ScanReport) jsonDiffReport {
+	return jsonDiffReport{
+		Database:  r.Database,
+		Host:      r.Host,
+		Timestamp: r.Timestamp.Format(jsonTimestamp),
+	}
+}
+
+func diffFindings(findings []models.Finding) []jsonDiffFinding {
+	out := make([]jsonDiffFinding, 0, len(findings))
+	for _, f := range findings {
+		out = append(out, jsonDiffFinding{CheckName: f.CheckName, Category: f.Category, jsonFinding: toJSONFinding(f)})
+	}
+	return out
+}
+
+func diffErrors(results []models.CheckResult) []jsonDiffError {
+	out := make([]jsonDiffError, 0, len(results))
+	for _, r := range results {
+		out = append(out, jsonDiffError{
+			CheckName: r.CheckName,
+			Category:  r.Category,
+			Error:     r.Error,
+			ErrorKind: string(r.ErrorKind),
+		})
+	}
+	return out
+}
+
+// RenderDiffMarkdown renders the differences between two reports as Markdown.
+func RenderDiffMarkdown(d *diff.Result) string {
+	var lines []string
+
+	lines = append(lines, "# MM-Ready: Report Comparison")
+	lines = append(lines, "")
+	lines = append(lines, fmt.Sprintf("**Old:** %s (%s)  ", d.Old.Database, d.Old.Timestamp.UTC().Format("2006-01-02 15:04:05 UTC")))
+	lines = append(lines, fmt.Sprintf("**New:** %s (%s)", d.New.Database, d.New.Timestamp.UTC().Format("2006-01-02 15:04:05 UTC")))
+	lines = append(lines, "")
+
+	lines = append(lines, "## Summary")
+	lines = append(lines, "")
+	lines = append(lines, "| Change | Count |")
+	lines = append(lines, "|--------|-------|")
+	lines = append(lines, fmt.Sprintf("| Resolved findings | %d |", len(d.Resolved)))
+	lines = append(lines, fmt.Sprintf("| **New findings** | **%d** |", len(d.Added)))
+	lines = append(lines, fmt.Sprintf("| Severity changed | %d |", len(d.SeverityChanged)))
+	lines = append(lines, fmt.Sprintf("| Unchanged | %d |", d.Unchanged))
+	lines = append(lines, fmt.Sprintf("| Checks started erroring | %d |", len(d.StartedErroring)))
+	lines = append(lines, fmt.Sprintf("| Checks stopped erroring | %d |", len(d.StoppedErroring)))
+	lines = append(lines, "")
+
+	if d.Empty() {
+		lines = append(lines, "> **NO CHANGES** — Both reports have the same findings.")
+		lines = append(lines, "")
+	}
+
+	findingTable := func(heading string, findings []models.Finding) {
+		if len(findings) == 0 {
+			return
+		}
+		lines = append(lines, fmt.Sprintf("## %s (%d)", heading, len(findings)))
+		lines = append(lines, "")
+		lines = append(lines, "| Severity | Check | Object | Finding |")
+		lines = append(lines, "|----------|-------|--------|---------|")
+		for _, f := range findings {
+			lines = append(lines, fmt.Sprintf("| %s | %s | `%s` | %s |", f.Severity, f.CheckName, f.ObjectName, mdCell(f.Title)))
+		}
+		lines = append(lines, "")
+	}
+	findingTable("New Findings", d.Added)
+	findingTable("Resolved Findings", d.Resolved)
+
+	if len(d.SeverityChanged) > 0 {
+		lines = append(lines, fmt.Sprintf("## Severity Changed (%d)", len(d.SeverityChanged)))
+		lines = append(lines, "")
+		lines = append(lines, "| Old | New | Check | Object | Finding |")
+		lines = append(lines, "|-----|-----|-------|--------|---------|")
+		for _, c := range d.SeverityChanged {
+			lines = append(lines, fmt.Sprintf("| %s | %s | %s | `%s` | %s |",
+				c.Old.Severity, c.New.Severity, c.New.CheckName, c.New.ObjectName, mdCell(c.New.Title)))
+		}
+		lines = append(lines, "")
+	}
+
+	errorList := func(heading string, results []models.CheckResult) {
+		if len(results) == 0 {
+			return
+		}
+		lines = append(lines, fmt.Sprintf("## %s (%d)", heading, len(results)))
+		lines = append(lines, "")
+		for _, r := range results {
+			lines = append(lines, fmt.Sprintf("- **%s/%s**: %s", r.Category, r.CheckName, r.Error))
+		}
+		lines = append(lines, "")
+	}
+	errorList("Checks Started Erroring", d.StartedErroring)
+	errorList("Checks Stopped Erroring", d.StoppedErroring)
+
+	lines = append(lines, "---")
+	lines = append(lines, "*Generated by mm-ready-go v0.1.0*")
+
+	return strings.Join(lines, "\n")
+}
+
+// RenderDiffHTML renders the differences between two reports as a
+// standalone HTML page.
+func RenderDiffHTML(d *diff.Result) string {
+	var main []string
+	main = append(main, `<div class="main no-sidebar">`)
+	main = append(main, `<h1>MM-Ready: Report Comparison</h1>`)
+	main = append(main, fmt.Sprintf(`<p><strong>Old:</strong> %s (%s)<br>`, esc(d.Old.Database), d.Old.Timestamp.UTC().Format("2006-01-02 15:04:05 UTC")))
+	main = append(main, fmt.Sprintf(`<strong>New:</strong> %s (%s)</p>`, esc(d.New.Database), d.New.Timestamp.UTC().Format("2006-01-02 15:04:05 UTC")))
+
+	main = append(main, `<div class="summary-box">`)
+	main = append(main, fmt.Sprintf(`<div class="summary-card passed"><div class="number">%d</div>Resolved</div>`, len(d.Resolved)))
+	main = append(main, fmt.Sprintf(`<div class="summary-card critical"><div class="number">%d</div>New</div>`, len(d.Added)))
+	main = append(main, fmt.Sprintf(`<div class="summary-card warning"><div class="number">%d</div>Severity Changed</div>`, len(d.SeverityChanged)))
+	main = append(main, fmt.Sprintf(`<div class="summary-card"><div class="number">%d</div>Unchanged</div>`, d.Unchanged))
+	main = append(main, fmt.Sprintf(`<div class="summary-card critical"><div class="number">%d</div>Started Erroring</div>`, len(d.StartedErroring)))
+	main = append(main, fmt.Sprintf(`<div class="summary-card passed"><div class="number">%d</div>Stopped Erroring</div>`, len(d.StoppedErroring)))
+	main = append(main, `</div>`)
+
+	if d.Empty() {
+		main = append(main, `<blockquote style="border-left-color: #16a34a; background: #f0fdf4;">`)
+		main = append(main, `<strong>NO CHANGES</strong> — Both reports have the same findings.`)
+		main = append(main, `</blockquote>`)
+	}
+
+	findingTable := func(id, heading string, findings []models.Finding) {
+		if len(findings) == 0 {
+			return
+		}
+		main = append(main, fmt.Sprintf(`<h2 id="%s">%s (%d)</h2>`, id, heading, len(findings)))
+		main = append(main, `<table>`)
+		main = append(main, `<tr><th>Severity</th><th>Check</th><th>Object</th><th>Finding</th></tr>`)
+		for _, f := range findings {
+			badgeCls, _ := sevBadgeClass(f.Severity)
+			main = append(main, fmt.Sprintf(`<tr><td><span class="badge %s">%s</span></td><td>%s</td><td><code>%s</code></td><td>%s</td></tr>`,
+				badgeCls, f.Severity, esc(f.CheckName), esc(f.ObjectName), esc(f.Title)))
+		}
+		main = append(main, `</table>`)
+	}
+	findingTable("new", "New Findings", d.Added)
+	findingTable("resolved", "Resolved Findings", d.Resolved)
+
+	if len(d.SeverityChanged) > 0 {
+		main = append(main, fmt.Sprintf(`<h2 id="severity-changed">Severity Changed (%d)</h2>`, len(d.SeverityChanged)))
+		main = append(main, `<table>`)
+		main = append(main, `<tr><th>Old</th><th>New</th><th>Check</th><th>Object</th><th>Finding</th></tr>`)
+		for _, c := range d.SeverityChanged {
+			oldCls, _ := sevBadgeClass(c.Old.Severity)
+			newCls, _ := sevBadgeClass(c.New.Severity)
+			main = append(main, fmt.Sprintf(`<tr><td><span class="badge %s">%s</span></td><td><span class="badge %s">%s</span></td><td>%s</td><td><code>%s</code></td><td>%s</td></tr>`,
+				oldCls, c.Old.Severity, newCls, c.New.Severity, esc(c.New.CheckName), esc(c.New.ObjectName), esc(c.New.Title)))
+		}
+		main = append(main, `</table>`)
+	}
+
+	errorList := func(id, heading string, results []models.CheckResult) {
+		if len(results) == 0 {
+			return
+		}
+		main = append(main, fmt.Sprintf(`<h2 id="%s">%s (%d)</h2>`, id, heading, len(results)))
+		main = append(main, `<ul>`)
+		for _, r := range results {
+			main = append(main, fmt.Sprintf(`<li><strong>%s/%s</strong>: %s</li>`, esc(r.Category), esc(r.CheckName), esc(r.Error)))
+		}
+		main = append(main, `</ul>`)
+	}
+	errorList("started-erroring", "Checks Started Erroring", d.StartedErroring)
+	errorList("stopped-erroring", "Checks Stopped Erroring", d.StoppedErroring)
+
+	main = append(main, `<hr>`)
+	main = append(main, `<p><em>Generated by mm-ready-go v0.1.0</em></p>`)
+	main = append(main, `</div>`)
+
+	var doc []string
+	doc = append(doc, `<!DOCTYPE html>`)
+	doc = append(doc, `<html lang="en">`)
+	doc = append(doc, `<head>`)
+	doc = append(doc, `<meta charset="UTF-8">`)
+	doc = append(doc, `<meta name="viewport" content="width=device-width, initial-scale=1.0">`)
+	doc = append(doc, fmt.Sprintf(`<title>MM-Ready Comparison: %s</title>`, esc(d.New.Database)))
+	doc = append(doc, fmt.Sprintf(`<style>%s</style>`, htmlCSS))
+	doc = append(doc, `</head>`)
+	doc = append(doc, `<body>`)
+	doc = append(doc, main...)
+	doc = append(doc, `</body></html>`)
+
+	return strings.Join(doc, "\n")
+}
+
+// RenderDiffSARIF renders the differences between two reports as a SARIF
+// 2.1.0 log. New findings, severity changes, and resolved findings are
+// results whose baselineState is new, updated, and absent; resolved findings
+// keep their location in the old report. Checks that started erroring are
+// error notifications, and checks that stopped erroring are notes.
+func RenderDiffSARIF(d *diff.Result) string {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "mm-ready-go",
+			Version:        "0.1.0",
+			InformationURI: "https://github.com/pgEdge/mm-ready-go",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+		Properties: map[string]any{
+			"database":      d.New.Database,
+			"old_timestamp": d.Old.Timestamp.Format(jsonTimestamp),
+			"new_timestamp": d.New.Timestamp.Format(jsonTimestamp),
+			"unchanged":     d.Unchanged,
+		},
+	}
+
+	rules := make(map[string]int)
+	worst := make(map[string]models.Severity)
+	add := func(f models.Finding, report *models.ScanReport, state string) *sarifResult {
+		idx, ok := rules[f.CheckName]
+		if !ok {
+			idx = len(run.Tool.Driver.Rules)
+			rules[f.CheckName] = idx
+			worst[f.CheckName] = f.Severity
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, newSARIFRule(diffCheck(d, f.CheckName)))
+		}
+		if f.Severity < worst[f.CheckName] {
+			worst[f.CheckName] = f.Severity
+		}
+		var artifact string
+		if report.ScanMode == "analyze" {
+			artifact = filepath.ToSlash(report.Host)
+		}
+		res := sarifFinding(f, f.CheckName, idx, artifact, false)
+		res.BaselineState = state
+		run.Results = append(run.Results, res)
+		return &run.Results[len(run.Results)-1]
+	}
+	for _, f := range d.Added {
+		add(f, d.New, "new")
+	}
+	for _, c := range d.SeverityChanged {
+		add(c.New, d.New, "updated").Properties["old_severity"] = c.Old.Severity.String()
+	}
+	for _, f := range d.Resolved {
+		add(f, d.Old, "absent")
+	}
+	for name, idx := range rules {
+		run.Tool.Driver.Rules[idx].DefaultConfiguration.Level = sarifLevel(worst[name])
+	}
+
+	invocation := sarifInvocation{ExecutionSuccessful: !d.New.Partial}
+	for _, r := range d.StartedErroring {
+		invocation.ToolExecutionNotifications = append(invocation.ToolExecutionNotifications, sarifNotification{
+			Level:      "error",
+			Message:    sarifMessage{Text: r.Error},
+			Descriptor: sarifDescriptorRef{ID: r.CheckName},
+		})
+	}
+	for _, r := range d.StoppedErroring {
+		invocation.ToolExecutionNotifications = append(invocation.ToolExecutionNotifications, sarifNotification{
+			Level:      "note",
+			Message:    sarifMessage{Text: "Check no longer fails; it failed before with: " + r.Error},
+			Descriptor: sarifDescriptorRef{ID: r.CheckName},
+		})
+	}
+	run.Invocations = []sarifInvocation{invocation}
+
+	out, _ := json.MarshalIndent(sarifLog{Schema: sarifSchema, Version: "2.1.0", Runs: []sarifRun{run}}, "", "  ")
+	return string(out)
+}
+
+// RenderDiffJUnit renders the differences between two reports as JUnit XML,
+// with a testsuite per kind of change and a testcase per changed finding or
+// check. New CRITICAL and WARNING findings fail, as do findings raised to one
+// of those severities, and checks that started erroring are errors. Resolved
+// findings and checks that stopped erroring pass.
+func RenderDiffJUnit(d *diff.Result) string {
+	root := junitTestSuites{Name: "mm-ready-go"}
+	suite := func(name string) *junitTestSuite {
+		s := &junitTestSuite{
+			Name:      name,
+			Timestamp: d.New.Timestamp.UTC().Format("2006-01-02T15:04:05"),
+			Hostname:  d.New.Host,
+			Properties: []junitProperty{
+				{Name: "database", Value: d.New.Database},
+				{Name: "old_timestamp", Value: d.Old.Timestamp.Format(jsonTimestamp)},
+				{Name: "new_timestamp", Value: d.New.Timestamp.Format(jsonTimestamp)},
+			},
+		}
+		root.Suites = append(root.Suites, s)
+		return s
+	}
+	add := func(s *junitTestSuite, tc junitTestCase) {
+		s.Tests++
+		root.Tests++
+		if tc.Failure != nil {
+			s.Failures++
+			root.Failures++
+		}
+		if tc.Error != nil {
+			s.Errors++
+			root.Errors++
+		}
+		s.Cases = append(s.Cases, tc)
+	}
+	findingCase := func(f models.Finding) junitTestCase {
+		return junitTestCase{Name: f.CheckName + ": " + f.ObjectName, Classname: "mm-ready." + f.Category}
+	}
+	checkCase := func(r models.CheckResult) junitTestCase {
+		return junitTestCase{Name: r.CheckName, Classname: "mm-ready." + r.Category}
+	}
+
+	if len(d.Added) > 0 {
+		s := suite("new")
+		for _, f := range d.Added {
+			tc := findingCase(f)
+			body := junitFindings([]models.Finding{f})
+			if f.Severity <= models.SeverityWarning {
+				tc.Failure = &junitResult{Message: f.Title, Type: f.Severity.String(), Body: body}
+			} else {
+				tc.SystemOut = &junitOutput{Body: body}
+			}
+			add(s, tc)
+		}
+	}
+	if len(d.SeverityChanged) > 0 {
+		s := suite("severity_changed")
+		for _, c := range d.SeverityChanged {
+			tc := findingCase(c.New)
+			body := fmt.Sprintf("Severity changed from %s to %s.\n\n%s",
+				c.Old.Severity, c.New.Severity, junitFindings([]models.Finding{c.New}))
+			if c.New.Severity <= models.SeverityWarning && c.New.Severity < c.Old.Severity {
+				tc.Failure = &junitResult{
+					Message: fmt.Sprintf("%s -> %s: %s", c.Old.Severity, c.New.Severity, c.New.Title),
+					Type:    c.New.Severity.String(),
+					Body:    body,
+				}
+			} else {
+				tc.SystemOut = &junitOutput{Body: body}
+			}
+			add(s, tc)
+		}
+	}
+	if len(d.Resolved) > 0 {
+		s := suite("resolved")
+		for _, f := range d.Resolved {
+			tc := findingCase(f)
+			tc.SystemOut = &junitOutput{Body: junitFindings([]models.Finding{f})}
+			add(s, tc)
+		}
+	}
+	if len(d.StartedErroring) > 0 {
+		s := suite("started_erroring")
+		for _, r := range d.StartedErroring {
+			tc := checkCase(r)
+			tc.Error = &junitResult{Message: r.Error, Type: string(r.ErrorKind), Body: r.Error}
+			add(s, tc)
+		}
+	}
+	if len(d.StoppedErroring) > 0 {
+		s := suite("stopped_erroring")
+		for _, r := range d.StoppedErroring {
+			tc := checkCase(r)
+			tc.SystemOut = &junitOutput{Body: "Failed before with: " + r.Error}
+			add(s, tc)
+		}
+	}
+
+	out, _ := xml.MarshalIndent(root, "", "  ")
+	return xml.Header + string(out) + "\n"
+}
+
+// diffColumns are the columns of CSV and TSV diff output.
+var diffColumns = []string{
+	"change", "severity", "old_severity", "category", "check", "schema", "table",
+	"object", "title", "remediation", "fingerprint", "error",
+}
+
+// diffRows returns the header and one row per changed finding or check for
+// CSV and TSV output. The change column is new, severity_changed, resolved,
+// started_erroring, or stopped_erroring.
+func diffRows(d *diff.Result) [][]string {
+	rows := [][]string{diffColumns}
+	finding := func(change string, f models.Finding, oldSeverity string) {
+		schema, table := splitObject(f.Category, f.ObjectName)
+		rows = append(rows, []string{
+			change, f.Severity.String(), oldSeverity, f.Category, f.CheckName, schema, table,
+			f.ObjectName, f.Title, f.Remediation, f.Fingerprint, "",
+		})
+	}
+	for _, f := range d.Added {
+		finding("new", f, "")
+	}
+	for _, c := range d.SeverityChanged {
+		finding("severity_changed", c.New, c.Old.Severity.String())
+	}
+	for _, f := range d.Resolved {
+		finding("resolved", f, "")
+	}
+	check := func(change string, r models.CheckResult) {
+		rows = append(rows, []string{
+			change, "", "", r.Category, r.CheckName, "", "", "", "", "", "", r.Error,
+		})
+	}
+	for _, r := range d.StartedErroring {
+		check("started_erroring", r)
+	}
+	for _, r := range d.StoppedErroring {
+		check("stopped_erroring", r)
+	}
+	return rows
+}
+
+// diffCheck returns the named check's result, from the new report when the
+// check ran there and from the old one otherwise.
+func diffCheck(d *diff.Result, name string) models.CheckResult {
+	for _, report := range []*models.ScanReport{d.New, d.Old} {
+		for _, r := range report.Results {
+			if r.CheckName == name {
+				return r
+			}
+		}
+	}
+	return models.CheckResult{CheckName: name}
+}
@@ -73,6 +73,7 @@ body {
 .main {
     margin-left: 270px; padding: 32px 40px; max-width: 1000px;
 }
+.main.no-sidebar { margin: 0 auto; }
 h1 { border-bottom: 3px solid #2563eb; padding-bottom: 10px; margin-top: 0; }
 h2 { color: #1e40af; margin-top: 2.5em; }
 h3 { color: #374151; margin-top: 1.5em; }
@@ -107,6 +108,19 @@ hr { border: none; border-top: 1px solid #e5e7eb; margin: 1.5em 0; }
 }
 .finding-card p { margin: 6px 0; }
 .finding-detail { white-space: pre-wrap; }
+.finding-fingerprint { color: #6b7280; font-size: 0.85em; }
+.known-findings { margin-bottom: 1.2em; }
+.known-findings > summary { cursor: pointer; color: #6b7280; margin-bottom: 0.8em; }
+.known-tag { font-size: 0.7em; font-weight: normal; color: #6b7280; border: 1px solid #d1d5db;
+             border-radius: 4px; padding: 1px 6px; vertical-align: middle; }
+.summary-card.new .number { color: #7c3aed; }
+.summary-card.known .number { color: #6b7280; }
+.readiness-table { width: auto; margin-bottom: 1.5em; }
+.readiness-table td.passed { color: #16a34a; font-weight: bold; }
+.readiness-table td.warning { color: #d97706; font-weight: bold; }
+.readiness-table td.critical { color: #dc2626; font-weight: bold; }
+.sync-table { width: auto; margin-bottom: 0.5em; }
+.sync-table tr.total td { font-weight: bold; }
 .todo-summary {
     padding: 12px 18px; border-radius: 8px; margin-bottom: 1.5em;
     font-weight: 600;
@@ -272,6 +286,46 @@ func sevBadgeClass(sev models.Severity) (string, string) {
 	}
 }
 
+// findingCard renders one finding as a card.
+func findingCard(finding models.Finding) []string {
+	var card []string
+	if finding.Fingerprint != "" {
+		card = append(card, fmt.Sprintf(`<div class="finding-card" id="f-%s" data-fingerprint="%s">`, esc(finding.Fingerprint), esc(finding.Fingerprint)))
+	} else {
+		card = append(card, `<div class="finding-card">`)
+	}
+	if finding.Known {
+		card = append(card, fmt.Sprintf(`<h4>%s <span class="known-tag">known</span></h4>`, esc(finding.Title)))
+	} else {
+		card = append(card, fmt.Sprintf(`<h4>%s</h4>`, esc(finding.Title)))
+	}
+	if finding.ObjectName != "" {
+		card = append(card, fmt.Sprintf(`<p><strong>Object:</strong> <code>%s</code></p>`, esc(finding.ObjectName)))
+	}
+	card = append(card, fmt.Sprintf(`<p class="finding-detail">%s</p>`, esc(finding.Detail)))
+	if finding.Fingerprint != "" {
+		card = append(card, fmt.Sprintf(`<p class="finding-fingerprint">Fingerprint: <code>%s</code></p>`, esc(finding.Fingerprint)))
+	}
+	if finding.Remediation != "" {
+		card = append(card, fmt.Sprintf(`<p><strong>Remediation:</strong></p><pre>%s</pre>`, esc(finding.Remediation)))
+	}
+	card = append(card, `</div>`)
+	return card
+}
+
+// scoreClass returns the summary-card class that colors a readiness score:
+// green from 90, amber from 60, and red below.
+func scoreClass(score int) string {
+	switch {
+	case score >= 90:
+		return "passed"
+	case score >= 60:
+		return "warning"
+	default:
+		return "critical"
+	}
+}
+
 // sevCatEntry holds findings grouped by category within a severity level.
 type sevCatEntry struct {
 	severity models.Severity
@@ -325,29 +379,12 @@ func RenderHTML(report *models.ScanReport, opts ReportOptions) string {
 	allFindings := report.Findings()
 	sevCatMap := buildSevCatMap(allFindings)
 
-	// Collect errors.
-	var errors []models.CheckResult
-	for _, r := range report.Results {
-		if r.Error != "" {
-			errors = append(errors, r)
-		}
-	}
+	// Collect errors, keeping missing privileges apart from real failures.
+	privileges, errors := splitErrors(report)
+	suppressed := report.SuppressedFindings()
 
 	// Collect to-do items (findings with remediation, filtered by options).
-	var todoItems []models.Finding
-	if opts.TodoList {
-		todoSevs := []models.Severity{models.SeverityCritical, models.SeverityWarning}
-		if opts.TodoIncludeConsider {
-			todoSevs = append(todoSevs, models.SeverityConsider)
-		}
-		for _, sev := range todoSevs {
-			for _, f := range allFindings {
-				if f.Severity == sev && f.Remediation != "" {
-					todoItems = append(todoItems, f)
-				}
-			}
-		}
-	}
+	todoItems := collectTodos(allFindings, opts)
 
 	collapsedSeverities := map[models.Severity]bool{
 		models.SeverityConsider: true,
@@ -397,12 +434,24 @@ func RenderHTML(report *models.ScanReport, opts ReportOptions) string {
 		sb = append(sb, `</div>`)
 	}
 
+	if len(privileges) > 0 {
+		sb = append(sb, fmt.Sprintf(
+			`<a class="tree-link" href="#privileges">Insufficient Privileges <span class="tree-badge tree-badge-warning">%d</span></a>`,
+			len(privileges),
+		))
+	}
 	if len(errors) > 0 {
 		sb = append(sb, fmt.Sprintf(
 			`<a class="tree-link" href="#errors">Errors <span class="tree-badge tree-badge-errors">%d</span></a>`,
 			len(errors),
 		))
 	}
+	if len(suppressed) > 0 {
+		sb = append(sb, fmt.Sprintf(
+			`<a class="tree-link" href="#suppressed">Suppressed <span class="tree-badge tree-badge-info">%d</span></a>`,
+			len(suppressed),
+		))
+	}
 	if len(todoItems) > 0 {
 		sb = append(sb, fmt.Sprintf(
 			`<a class="tree-link" href="#todo">To Do List <span class="tree-badge tree-badge-warning">%d</span></a>`,
@@ -427,17 +476,69 @@ func RenderHTML(report *models.ScanReport, opts ReportOptions) string {
 	main = append(main, fmt.Sprintf(`<strong>PostgreSQL:</strong> %s<br>`, esc(report.PGVersion)))
 	main = append(main, fmt.Sprintf(`<strong>Scan Time:</strong> %s<br>`, report.Timestamp.Format("2006-01-02 15:04:05 UTC")))
 	main = append(main, fmt.Sprintf(`<strong>Mode:</strong> %s<br>`, esc(report.ScanMode)))
+	if report.StartLSN != "" {
+		main = append(main, fmt.Sprintf(`<strong>Snapshot LSN:</strong> %s<br>`, esc(report.StartLSN)))
+	}
+	if report.Baseline != "" {
+		main = append(main, fmt.Sprintf(`<strong>Baseline:</strong> %s<br>`, esc(report.Baseline)))
+	}
 	main = append(main, fmt.Sprintf(`<strong>Target:</strong> Spock %s</p>`, report.SpockTarget))
 
+	readiness := report.Readiness()
 	main = append(main, `<div class="summary-box">`)
+	main = append(main, fmt.Sprintf(`<div class="summary-card %s"><div class="number">%d</div>Readiness Score</div>`, scoreClass(readiness.Score), readiness.Score))
 	main = append(main, fmt.Sprintf(`<div class="summary-card"><div class="number">%d</div>Checks Run</div>`, report.ChecksTotal()))
 	main = append(main, fmt.Sprintf(`<div class="summary-card passed"><div class="number">%d</div>Passed</div>`, report.ChecksPassed()))
 	main = append(main, fmt.Sprintf(`<div class="summary-card critical"><div class="number">%d</div>Critical</div>`, report.CriticalCount()))
 	main = append(main, fmt.Sprintf(`<div class="summary-card warning"><div class="number">%d</div>Warnings</div>`, report.WarningCount()))
 	main = append(main, fmt.Sprintf(`<div class="summary-card consider"><div class="number">%d</div>Consider</div>`, report.ConsiderCount()))
 	main = append(main, fmt.Sprintf(`<div class="summary-card info"><div class="number">%d</div>Info</div>`, report.InfoCount()))
+	if report.Baseline != "" {
+		main = append(main, fmt.Sprintf(`<div class="summary-card new"><div class="number">%d</div>New</div>`, report.NewCount()))
+		main = append(main, fmt.Sprintf(`<div class="summary-card known"><div class="number">%d</div>Known</div>`, report.KnownCount()))
+	}
+	if len(suppressed) > 0 {
+		main = append(main, fmt.Sprintf(`<div class="summary-card known"><div class="number">%d</div>Suppressed</div>`, len(suppressed)))
+	}
 	main = append(main, `</div>`)
 
+	main = append(main, fmt.Sprintf(`<p><strong>Estimated effort:</strong> %s</p>`, esc(readiness.Effort.String())))
+	if len(readiness.Categories) > 0 {
+		main = append(main, `<table class="readiness-table">`)
+		main = append(main, `<tr><th>Category</th><th>Score</th><th>Checks</th></tr>`)
+		for _, c := range readiness.Categories {
+			main = append(main, fmt.Sprintf(`<tr><td>%s</td><td class="%s">%d</td><td>%d</td></tr>`,
+				esc(c.Category), scoreClass(c.Score), c.Score, c.Checks))
+		}
+		main = append(main, `</table>`)
+	}
+
+	if est := report.SyncEstimate(); est != nil {
+		header, rows := syncTable(est)
+		main = append(main, `<h2 id="initial-sync">Initial Sync Estimate</h2>`)
+		main = append(main, `<table class="sync-table">`)
+		main = append(main, `<tr><th>`+strings.Join(header, `</th><th>`)+`</th></tr>`)
+		for i, r := range rows {
+			for j := range r {
+				r[j] = esc(r[j])
+			}
+			if i == len(rows)-1 {
+				main = append(main, `<tr class="total"><td>`+strings.Join(r, `</td><td>`)+`</td></tr>`)
+			} else {
+				main = append(main, `<tr><td>`+strings.Join(r, `</td><td>`)+`</td></tr>`)
+			}
+		}
+		main = append(main, `</table>`)
+		main = append(main, fmt.Sprintf(`<p>%s</p>`, esc(syncNote(est))))
+	}
+
+	if report.Partial {
+		main = append(main, `<blockquote style="border-left-color: #991b1b; background: #fef2f2;">`)
+		main = append(main, fmt.Sprintf(`<strong>PARTIAL REPORT</strong> — The scan was interrupted; only %d completed check%s are included.`,
+			len(report.Results), pluralS(len(report.Results))))
+		main = append(main, `</blockquote>`)
+	}
+
 	critCount := report.CriticalCount()
 	warnCount := report.WarningCount()
 	if critCount == 0 && warnCount == 0 {
@@ -468,21 +569,36 @@ func RenderHTML(report *models.ScanReport, opts ReportOptions) string {
 			anchor := fmt.Sprintf("sev-%s-%s", sevSlug, slug(cf.category))
 			main = append(main, fmt.Sprintf(`<h3 id="%s">%s (%d)</h3>`, anchor, esc(cf.category), len(cf.findings)))
 
+			var known []models.Finding
 			for _, finding := range cf.findings {
-				main = append(main, `<div class="finding-card">`)
-				main = append(main, fmt.Sprintf(`<h4>%s</h4>`, esc(finding.Title)))
-				if finding.ObjectName != "" {
-					main = append(main, fmt.Sprintf(`<p><strong>Object:</strong> <code>%s</code></p>`, esc(finding.ObjectName)))
+				if finding.Known {
+					known = append(known, finding)
+					continue
 				}
-				main = append(main, fmt.Sprintf(`<p class="finding-detail">%s</p>`, esc(finding.Detail)))
-				if finding.Remediation != "" {
-					main = append(main, fmt.Sprintf(`<p><strong>Remediation:</strong></p><pre>%s</pre>`, esc(finding.Remediation)))
+				main = append(main, findingCard(finding)...)
+			}
+			if len(known) > 0 {
+				main = append(main, `<details class="known-findings">`)
+				main = append(main, fmt.Sprintf(`<summary>%d known finding%s from the baseline</summary>`, len(known), pluralS(len(known))))
+				for _, finding := range known {
+					main = append(main, findingCard(finding)...)
 				}
-				main = append(main, `</div>`)
+				main = append(main, `</details>`)
 			}
 		}
 	}
 
+	// Insufficient privileges section.
+	if len(privileges) > 0 {
+		main = append(main, `<h2 id="privileges">Insufficient Privileges</h2>`)
+		main = append(main, `<p>These checks could not run because the connected role lacks a privilege. Grant it, or run as a more privileged role, to include them.</p>`)
+		main = append(main, `<ul>`)
+		for _, r := range privileges {
+			main = append(main, fmt.Sprintf(`<li><strong>%s/%s</strong>: needs %s</li>`, esc(r.Category), esc(r.CheckName), esc(privilegeNeeded(r.Error))))
+		}
+		main = append(main, `</ul>`)
+	}
+
 	// Errors section.
 	if len(errors) > 0 {
 		main = append(main, `<h2 id="errors">Errors</h2>`)
@@ -493,6 +609,29 @@ func RenderHTML(report *models.ScanReport, opts ReportOptions) string {
 		main = append(main, `</ul>`)
 	}
 
+	// Skipped checks section.
+	if skipped := groupSkipped(report); len(skipped) > 0 {
+		main = append(main, `<h2 id="skipped">Skipped Checks</h2>`)
+		main = append(main, `<ul>`)
+		for _, g := range skipped {
+			main = append(main, fmt.Sprintf(`<li><strong>%s</strong>: %s</li>`, esc(g.reason), esc(strings.Join(g.checks, ", "))))
+		}
+		main = append(main, `</ul>`)
+	}
+
+	// Suppressed findings section.
+	if len(suppressed) > 0 {
+		main = append(main, `<h2 id="suppressed">Suppressed Findings</h2>`)
+		main = append(main, `<p>These findings match a suppression in the configuration file and are left out of the counts above.</p>`)
+		main = append(main, `<table>`)
+		main = append(main, `<tr><th>Severity</th><th>Check</th><th>Object</th><th>Finding</th><th>Reason</th></tr>`)
+		for _, f := range suppressed {
+			main = append(main, fmt.Sprintf(`<tr><td>%s</td><td>%s</td><td><code>%s</code></td><td>%s</td><td>%s</td></tr>`,
+				f.Severity, esc(f.CheckName), esc(f.ObjectName), esc(f.Title), esc(f.SuppressionReason)))
+		}
+		main = append(main, `</table>`)
+	}
+
 	// To Do list section.
 	if len(todoItems) > 0 {
 		var critTodos, warnTodos, considerTodos []models.Finding
@@ -520,19 +659,8 @@ func RenderHTML(report *models.ScanReport, opts ReportOptions) string {
 			cssClass = "todo-summary todo-summary-green"
 		}
 
-		var parts []string
-		if len(critTodos) > 0 {
-			parts = append(parts, fmt.Sprintf("%d critical", len(critTodos)))
-		}
-		if len(warnTodos) > 0 {
-			parts = append(parts, fmt.Sprintf("%d warning%s", len(warnTodos), pluralS(len(warnTodos))))
-		}
-		if len(considerTodos) > 0 {
-			parts = append(parts, fmt.Sprintf("%d to consider", len(considerTodos)))
-		}
-
 		main = append(main, fmt.Sprintf(`<div class="%s">`, cssClass))
-		main = append(main, fmt.Sprintf(`%d item%s to address (%s)`, len(todoItems), pluralS(len(todoItems)), strings.Join(parts, ", ")))
+		main = append(main, todoSummary(todoItems))
 		main = append(main, fmt.Sprintf(` &mdash; <span id="todo-counter">0 of %d completed</span>`, len(todoItems)))
 		main = append(main, `</div>`)
 
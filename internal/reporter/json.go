@@ -32,6 +32,16 @@ type jsonMeta struct {
 	PGVersion string `json:"pg_version"`
 	// SpockTarget is the target Spock version.
 	SpockTarget string `json:"spock_target"`
+	// ScanMode is the mode used for this scan (scan, audit, analyze, monitor).
+	ScanMode string `json:"scan_mode"`
+	// Partial indicates the scan was interrupted before all checks completed.
+	Partial bool `json:"partial,omitempty"`
+	// SnapshotID is the exported snapshot all checks ran in.
+	SnapshotID string `json:"snapshot_id,omitempty"`
+	// StartLSN is the WAL position when the scan's snapshot was taken.
+	StartLSN string `json:"start_lsn,omitempty"`
+	// Baseline is the baseline report findings were compared with.
+	Baseline string `json:"baseline,omitempty"`
 }
 
 type jsonSummary struct {
@@ -47,6 +57,61 @@ type jsonSummary struct {
 	Consider int `json:"consider"`
 	// Info is the count of info findings.
 	Info int `json:"info"`
+	// New is the count of findings not in the baseline.
+	New int `json:"new"`
+	// Known is the count of findings in the baseline.
+	Known int `json:"known"`
+	// Suppressed is the count of findings hidden by suppressions.
+	Suppressed int `json:"suppressed"`
+	// Readiness holds the readiness score and effort estimate.
+	Readiness jsonReadiness `json:"readiness"`
+	// InitialSync sizes the initial synchronization of a new node; omitted
+	// when the initial_sync check did not run and no throughput is set.
+	InitialSync *jsonInitialSync `json:"initial_sync,omitempty"`
+}
+
+type jsonInitialSync struct {
+	jsonSyncGroup
+	// Throughput is the configured copy rate in bytes per second.
+	Throughput float64 `json:"throughput,omitempty"`
+	// ReplicationSets breaks the total down by replication set.
+	ReplicationSets []jsonSyncGroup `json:"replication_sets,omitempty"`
+}
+
+type jsonSyncGroup struct {
+	// Name is the replication set; empty for the total.
+	Name string `json:"name,omitempty"`
+	// Tables is the number of tables copied.
+	Tables int `json:"tables"`
+	// DataBytes is the size of the table data copied.
+	DataBytes int64 `json:"data_bytes"`
+	// IndexBytes is the size of the indexes rebuilt on the new node.
+	IndexBytes int64 `json:"index_bytes"`
+	// CopySeconds is the estimated copy time; omitted without a throughput.
+	CopySeconds float64 `json:"copy_seconds,omitempty"`
+}
+
+type jsonReadiness struct {
+	// Score is the overall readiness score, from 0 to 100.
+	Score int `json:"score"`
+	// Categories holds the readiness score of each category.
+	Categories map[string]int `json:"categories"`
+	// Effort estimates the work needed to remediate the findings.
+	Effort jsonEffort `json:"effort"`
+	// Weights holds the configured weights the score was computed with;
+	// omitted when the defaults were used.
+	Weights *models.ScoreWeights `json:"weights,omitempty"`
+}
+
+type jsonEffort struct {
+	// Small is the count of findings with small remediation effort.
+	Small int `json:"small"`
+	// Medium is the count of findings with medium remediation effort.
+	Medium int `json:"medium"`
+	// Large is the count of findings with large remediation effort.
+	Large int `json:"large"`
+	// Hours is the estimated total in hours.
+	Hours float64 `json:"hours"`
 }
 
 type jsonResult struct {
@@ -62,10 +127,18 @@ type jsonResult struct {
 	Skipped bool `json:"skipped"`
 	// Error holds the error message if the check failed.
 	Error *string `json:"error"`
+	// ErrorKind classifies Error (permission_denied, undefined_table, timeout, panic, query_error).
+	ErrorKind string `json:"error_kind,omitempty"`
 	// SkipReason explains why the check was skipped.
 	SkipReason string `json:"skip_reason,omitempty"`
+	// TimedOut indicates the check exceeded its time limit.
+	TimedOut bool `json:"timed_out,omitempty"`
+	// Effort is the check's remediation effort hint (small, medium, large).
+	Effort string `json:"effort,omitempty"`
 	// Findings holds all findings from this check.
 	Findings []jsonFinding `json:"findings"`
+	// Suppressed holds findings hidden by suppressions.
+	Suppressed []jsonFinding `json:"suppressed,omitempty"`
 }
 
 type jsonFinding struct {
@@ -81,6 +154,15 @@ type jsonFinding struct {
 	Remediation string `json:"remediation"`
 	// Metadata holds additional key-value data for this finding.
 	Metadata map[string]any `json:"metadata"`
+	// Fingerprint identifies this finding across runs.
+	Fingerprint string `json:"fingerprint"`
+	// Known indicates the finding is in the baseline.
+	Known bool `json:"known"`
+	// SuppressionReason is the reason given by the suppression that hid
+	// this finding.
+	SuppressionReason string `json:"suppression_reason,omitempty"`
+	// Line is the line of the analyzed dump file where the object is defined.
+	Line int `json:"line,omitempty"`
 }
 
 // RenderJSON renders the report as a JSON string.
@@ -89,12 +171,17 @@ func RenderJSON(report *models.ScanReport) string {
 		Meta: jsonMeta{
 			Tool:        "mm-ready-go",
 			Version:     "0.1.0",
-			Timestamp:   report.Timestamp.Format("2006-01-02T15:04:05-07:00"),
+			Timestamp:   report.Timestamp.Format(jsonTimestamp),
 			Database:    report.Database,
 			Host:        report.Host,
 			Port:        report.Port,
 			PGVersion:   report.PGVersion,
 			SpockTarget: report.SpockTarget,
+			ScanMode:    report.ScanMode,
+			Partial:     report.Partial,
+			SnapshotID:  report.SnapshotID,
+			StartLSN:    report.StartLSN,
+			Baseline:    report.Baseline,
 		},
 		Summary: jsonSummary{
 			TotalChecks:  report.ChecksTotal(),
@@ -103,6 +190,11 @@ func RenderJSON(report *models.ScanReport) string {
 			Warnings:     report.WarningCount(),
 			Consider:     report.ConsiderCount(),
 			Info:         report.InfoCount(),
+			New:          report.NewCount(),
+			Known:        report.KnownCount(),
+			Suppressed:   len(report.SuppressedFindings()),
+			Readiness:    toJSONReadiness(report),
+			InitialSync:  toJSONInitialSync(report),
 		},
 		Results: make([]jsonResult, 0, len(report.Results)),
 	}
@@ -114,30 +206,25 @@ func RenderJSON(report *models.ScanReport) string {
 			Description: r.Description,
 			Passed:      len(r.Findings) == 0 && r.Error == "",
 			Skipped:     r.Skipped,
+			TimedOut:    r.TimedOut,
+			Effort:      string(r.Effort),
 			Findings:    make([]jsonFinding, 0, len(r.Findings)),
 		}
 
 		if r.Error != "" {
 			errStr := r.Error
 			entry.Error = &errStr
+			entry.ErrorKind = string(r.ErrorKind)
 		}
 		if r.Skipped {
 			entry.SkipReason = r.SkipReason
 		}
 
 		for _, f := range r.Findings {
-			meta := f.Metadata
-			if meta == nil {
-				meta = make(map[string]any)
-			}
-			entry.Findings = append(entry.Findings, jsonFinding{
-				Severity:    f.Severity.String(),
-				Title:       f.Title,
-				Detail:      f.Detail,
-				ObjectName:  f.ObjectName,
-				Remediation: f.Remediation,
-				Metadata:    meta,
-			})
+			entry.Findings = append(entry.Findings, toJSONFinding(f))
+		}
+		for _, f := range r.Suppressed {
+			entry.Suppressed = append(entry.Suppressed, toJSONFinding(f))
 		}
 
 		data.Results = append(data.Results, entry)
@@ -146,3 +233,65 @@ func RenderJSON(report *models.ScanReport) string {
 	out, _ := json.MarshalIndent(data, "", "  ")
 	return string(out)
 }
+
+func toJSONReadiness(report *models.ScanReport) jsonReadiness {
+	rd := report.Readiness()
+	out := jsonReadiness{
+		Score:      rd.Score,
+		Categories: make(map[string]int, len(rd.Categories)),
+		Effort: jsonEffort{
+			Small:  rd.Effort.Small,
+			Medium: rd.Effort.Medium,
+			Large:  rd.Effort.Large,
+			Hours:  rd.Effort.Hours,
+		},
+		Weights: report.Weights,
+	}
+	for _, c := range rd.Categories {
+		out.Categories[c.Category] = c.Score
+	}
+	return out
+}
+
+func toJSONInitialSync(report *models.ScanReport) *jsonInitialSync {
+	est := report.SyncEstimate()
+	if est == nil {
+		if report.SyncThroughput <= 0 {
+			return nil
+		}
+		return &jsonInitialSync{Throughput: report.SyncThroughput}
+	}
+	group := func(g models.SyncGroup) jsonSyncGroup {
+		return jsonSyncGroup{
+			Name:        g.Name,
+			Tables:      g.Tables,
+			DataBytes:   g.DataBytes,
+			IndexBytes:  g.IndexBytes,
+			CopySeconds: est.CopyTime(g).Seconds(),
+		}
+	}
+	out := &jsonInitialSync{jsonSyncGroup: group(est.Total), Throughput: est.Throughput}
+	for _, g := range est.Sets {
+		out.ReplicationSets = append(out.ReplicationSets, group(g))
+	}
+	return out
+}
+
+func toJSONFinding(f models.Finding) jsonFinding {
+	meta := f.Metadata
+	if meta == nil {
+		meta = make(map[string]any)
+	}
+	return jsonFinding{
+		Severity:          f.Severity.String(),
+		Title:             f.Title,
+		Detail:            f.Detail,
+		ObjectName:        f.ObjectName,
+		Remediation:       f.Remediation,
+		Metadata:          meta,
+		Fingerprint:       f.Fingerprint,
+		Known:             f.Known,
+		SuppressionReason: f.SuppressionReason,
+		Line:              f.Line,
+	}
+}
@@ -0,0 +1,174 @@
+package reporter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/pgEdge/mm-ready-go/internal/models"
+)
+
+type junitTestSuites struct {
+	XMLName xml.Name `xml:"testsuites"`
+	// Name is the tool name.
+	Name string `xml:"name,attr"`
+	// Tests is the number of checks.
+	Tests int `xml:"tests,attr"`
+	// Failures is the number of checks with CRITICAL or WARNING findings.
+	Failures int `xml:"failures,attr"`
+	// Errors is the number of checks that failed to run.
+	Errors int `xml:"errors,attr"`
+	// Skipped is the number of skipped checks.
+	Skipped int `xml:"skipped,attr"`
+	// Suites holds one testsuite per category.
+	Suites []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	// Name is the check category.
+	Name      string `xml:"name,attr"`
+	Tests     int    `xml:"tests,attr"`
+	Failures  int    `xml:"failures,attr"`
+	Errors    int    `xml:"errors,attr"`
+	Skipped   int    `xml:"skipped,attr"`
+	Timestamp string `xml:"timestamp,attr"`
+	// Hostname is the database host, or the dump file for analyze reports.
+	Hostname string `xml:"hostname,attr,omitempty"`
+	// Properties holds the scan metadata.
+	Properties []junitProperty `xml:"properties>property"`
+	// Cases holds one testcase per check.
+	Cases []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	// Name is the check name.
+	Name string `xml:"name,attr"`
+	// Classname is "mm-ready.<category>".
+	Classname string `xml:"classname,attr"`
+	// Failure lists the check's new CRITICAL and WARNING findings.
+	Failure *junitResult `xml:"failure,omitempty"`
+	// Error holds the check's error.
+	Error *junitResult `xml:"error,omitempty"`
+	// Skipped holds the reason the check was skipped.
+	Skipped *junitResult `xml:"skipped,omitempty"`
+	// SystemOut lists the check's other findings.
+	SystemOut *junitOutput `xml:"system-out,omitempty"`
+}
+
+type junitResult struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Body    string `xml:",cdata"`
+}
+
+type junitOutput struct {
+	Body string `xml:",cdata"`
+}
+
+// RenderJUnit renders the report as JUnit XML, with a testsuite per category
+// and a testcase per check. New CRITICAL and WARNING findings fail their
+// testcase, check errors are errors, and skipped checks are skipped. Other
+// findings, including those known from a baseline, are written to the
+// testcase's system-out.
+func RenderJUnit(report *models.ScanReport) string {
+	root := junitTestSuites{Name: "mm-ready-go"}
+	suites := make(map[string]*junitTestSuite)
+
+	for _, r := range report.Results {
+		suite, ok := suites[r.Category]
+		if !ok {
+			suite = &junitTestSuite{
+				Name:      r.Category,
+				Timestamp: report.Timestamp.UTC().Format("2006-01-02T15:04:05"),
+				Hostname:  report.Host,
+				Properties: []junitProperty{
+					{Name: "database", Value: report.Database},
+					{Name: "pg_version", Value: report.PGVersion},
+					{Name: "spock_target", Value: report.SpockTarget},
+					{Name: "scan_mode", Value: report.ScanMode},
+				},
+			}
+			suites[r.Category] = suite
+			root.Suites = append(root.Suites, suite)
+		}
+
+		tc := junitTestCase{Name: r.CheckName, Classname: "mm-ready." + r.Category}
+		suite.Tests++
+		root.Tests++
+
+		if r.Skipped {
+			tc.Skipped = &junitResult{Message: r.SkipReason}
+			suite.Skipped++
+			root.Skipped++
+			suite.Cases = append(suite.Cases, tc)
+			continue
+		}
+		if r.Error != "" {
+			tc.Error = &junitResult{Message: r.Error, Type: string(r.ErrorKind), Body: r.Error}
+			suite.Errors++
+			root.Errors++
+		}
+
+		var failing, other []models.Finding
+		for _, f := range r.Findings {
+			if f.Severity <= models.SeverityWarning && !f.Known {
+				failing = append(failing, f)
+			} else {
+				other = append(other, f)
+			}
+		}
+		if len(failing) > 0 {
+			worst := failing[0].Severity
+			for _, f := range failing {
+				if f.Severity < worst {
+					worst = f.Severity
+				}
+			}
+			tc.Failure = &junitResult{
+				Message: fmt.Sprintf("%d finding(s): %s", len(failing), failing[0].Title),
+				Type:    worst.String(),
+				Body:    junitFindings(failing),
+			}
+			suite.Failures++
+			root.Failures++
+		}
+		if len(other) > 0 {
+			tc.SystemOut = &junitOutput{Body: junitFindings(other)}
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	out, _ := xml.MarshalIndent(root, "", "  ")
+	return xml.Header + string(out) + "\n"
+}
+
+// junitFindings formats findings as plain text for a failure body or
+// system-out.
+func junitFindings(findings []models.Finding) string {
+	var blocks []string
+	for _, f := range findings {
+		lines := []string{fmt.Sprintf("[%s] %s", f.Severity, f.Title)}
+		if f.Known {
+			lines[0] += " (known)"
+		}
+		if f.ObjectName != "" {
+			lines = append(lines, "Object: "+f.ObjectName)
+		}
+		if f.Detail != "" {
+			lines = append(lines, f.Detail)
+		}
+		if f.Remediation != "" {
+			lines = append(lines, "Remediation: "+f.Remediation)
+		}
+		if f.Fingerprint != "" {
+			lines = append(lines, "Fingerprint: "+f.Fingerprint)
+		}
+		blocks = append(blocks, strings.Join(lines, "\n"))
+	}
+	return strings.Join(blocks, "\n\n")
+}
@@ -0,0 +1,165 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/pgEdge/mm-ready-go/internal/models"
+)
+
+// jsonTimestamp is the layout RenderJSON writes meta.timestamp in. Fractional
+// seconds are written only when present, so that LoadJSON restores the exact
+// scan time.
+const jsonTimestamp = "2006-01-02T15:04:05.999999999-07:00"
+
+// LoadJSON reads a report written by RenderJSON back into a ScanReport.
+//
+// Everything RenderJSON writes is restored, so rendering the loaded report
+// again gives the same output. Summary counts are recomputed from the
+// results, the readiness score from the results and the recorded weights,
+// and the initial sync estimate from the results and the recorded
+// throughput. Numbers in finding metadata come back as int64 when they are
+// whole and float64 otherwise, and JSON arrays as []any.
+func LoadJSON(path string) (*models.ScanReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read report: %w", err)
+	}
+	report, err := parseJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse report %s: %w", path, err)
+	}
+	return report, nil
+}
+
+// parseJSON decodes a report written by RenderJSON.
+func parseJSON(data []byte) (*models.ScanReport, error) {
+	var doc jsonReport
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	report := &models.ScanReport{
+		Database:    doc.Meta.Database,
+		Host:        doc.Meta.Host,
+		Port:        doc.Meta.Port,
+		PGVersion:   doc.Meta.PGVersion,
+		SpockTarget: doc.Meta.SpockTarget,
+		ScanMode:    doc.Meta.ScanMode,
+		Partial:     doc.Meta.Partial,
+		SnapshotID:  doc.Meta.SnapshotID,
+		StartLSN:    doc.Meta.StartLSN,
+		Baseline:    doc.Meta.Baseline,
+		Weights:     doc.Summary.Readiness.Weights,
+	}
+	if doc.Meta.Timestamp != "" {
+		ts, err := time.Parse(jsonTimestamp, doc.Meta.Timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("meta.timestamp: %w", err)
+		}
+		if _, offset := ts.Zone(); offset == 0 {
+			ts = ts.UTC()
+		}
+		report.Timestamp = ts
+	}
+	if doc.Summary.InitialSync != nil {
+		report.SyncThroughput = doc.Summary.InitialSync.Throughput
+	}
+
+	for _, r := range doc.Results {
+		result := models.CheckResult{
+			CheckName:   r.CheckName,
+			Category:    r.Category,
+			Description: r.Description,
+			ErrorKind:   models.ErrorKind(r.ErrorKind),
+			Skipped:     r.Skipped,
+			SkipReason:  r.SkipReason,
+			TimedOut:    r.TimedOut,
+			Effort:      models.Effort(r.Effort),
+		}
+		if r.Error != nil {
+			result.Error = *r.Error
+		}
+		for _, f := range r.Findings {
+			finding, err := fromJSONFinding(f, r)
+			if err != nil {
+				return nil, err
+			}
+			result.Findings = append(result.Findings, finding)
+		}
+		for _, f := range r.Suppressed {
+			finding, err := fromJSONFinding(f, r)
+			if err != nil {
+				return nil, err
+			}
+			result.Suppressed = append(result.Suppressed, finding)
+		}
+		report.Results = append(report.Results, result)
+	}
+	return report, nil
+}
+
+func fromJSONFinding(f jsonFinding, r jsonResult) (models.Finding, error) {
+	sev, err := models.ParseSeverity(f.Severity)
+	if err != nil {
+		return models.Finding{}, fmt.Errorf("%s: %w", r.CheckName, err)
+	}
+	return models.Finding{
+		Severity:          sev,
+		CheckName:         r.CheckName,
+		Category:          r.Category,
+		Title:             f.Title,
+		Detail:            f.Detail,
+		ObjectName:        f.ObjectName,
+		Remediation:       f.Remediation,
+		Metadata:          fromJSONMetadata(f.Metadata),
+		Fingerprint:       f.Fingerprint,
+		Known:             f.Known,
+		SuppressionReason: f.SuppressionReason,
+		Line:              f.Line,
+	}, nil
+}
+
+// fromJSONMetadata converts the json.Number values decoded from finding
+// metadata to int64 or float64. An empty object becomes nil, as RenderJSON
+// writes nil metadata as {}.
+func fromJSONMetadata(meta map[string]any) map[string]any {
+	if len(meta) == 0 {
+		return nil
+	}
+	out := make(map[string]any, len(meta))
+	for k, v := range meta {
+		out[k] = fromJSONValue(v)
+	}
+	return out
+}
+
+func fromJSONValue(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		f, _ := v.Float64()
+		return f
+	case []any:
+		out := make([]any, len(v))
+		for i, e := range v {
+			out[i] = fromJSONValue(e)
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, e := range v {
+			out[k] = fromJSONValue(e)
+		}
+		return out
+	default:
+		return v
+	}
+}
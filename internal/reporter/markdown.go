@@ -8,8 +8,11 @@ import (
 	"github.com/pgEdge/mm-ready-go/internal/models"
 )
 
-// RenderMarkdown renders the report as Markdown.
-func RenderMarkdown(report *models.ScanReport) string {
+// RenderMarkdown renders the report as Markdown. Like the HTML report it has
+// a severity by category matrix, collapsible CONSIDER and INFO sections, and
+// a prioritized To Do checklist controlled by opts, so it can stand in for
+// the HTML report in wiki pages and merge requests.
+func RenderMarkdown(report *models.ScanReport, opts ReportOptions) string {
 	var lines []string
 
 	// Header
@@ -23,6 +26,12 @@ func RenderMarkdown(report *models.ScanReport) string {
 	}
 	lines = append(lines, fmt.Sprintf("**PostgreSQL:** %s  ", report.PGVersion))
 	lines = append(lines, fmt.Sprintf("**Scan Time:** %s  ", report.Timestamp.Format("2006-01-02 15:04:05 UTC")))
+	if report.StartLSN != "" {
+		lines = append(lines, fmt.Sprintf("**Snapshot LSN:** %s  ", report.StartLSN))
+	}
+	if report.Baseline != "" {
+		lines = append(lines, fmt.Sprintf("**Baseline:** %s  ", report.Baseline))
+	}
 	lines = append(lines, fmt.Sprintf("**Target:** Spock %s", report.SpockTarget))
 	lines = append(lines, "")
 
@@ -37,7 +46,50 @@ func RenderMarkdown(report *models.ScanReport) string {
 	lines = append(lines, fmt.Sprintf("| WARNING | %d |", report.WarningCount()))
 	lines = append(lines, fmt.Sprintf("| CONSIDER | %d |", report.ConsiderCount()))
 	lines = append(lines, fmt.Sprintf("| INFO | %d |", report.InfoCount()))
+	if report.Baseline != "" {
+		lines = append(lines, fmt.Sprintf("| New | %d |", report.NewCount()))
+		lines = append(lines, fmt.Sprintf("| Known (in baseline) | %d |", report.KnownCount()))
+	}
+	if n := len(report.SuppressedFindings()); n > 0 {
+		lines = append(lines, fmt.Sprintf("| Suppressed | %d |", n))
+	}
+	readiness := report.Readiness()
+	lines = append(lines, fmt.Sprintf("| **Readiness Score** | **%d/100** |", readiness.Score))
+	lines = append(lines, fmt.Sprintf("| Estimated Effort | %s |", readiness.Effort))
 	lines = append(lines, "")
+	if len(readiness.Categories) > 0 {
+		lines = append(lines, "| Category | Score | Checks |")
+		lines = append(lines, "|----------|-------|--------|")
+		for _, c := range readiness.Categories {
+			lines = append(lines, fmt.Sprintf("| %s | %d | %d |", mdCell(c.Category), c.Score, c.Checks))
+		}
+		lines = append(lines, "")
+	}
+
+	if est := report.SyncEstimate(); est != nil {
+		header, rows := syncTable(est)
+		lines = append(lines, "## Initial Sync Estimate")
+		lines = append(lines, "")
+		lines = append(lines, "| "+strings.Join(header, " | ")+" |")
+		lines = append(lines, "|"+strings.Repeat("---|", len(header)))
+		for i, r := range rows {
+			for j := range r {
+				r[j] = mdCell(r[j])
+			}
+			if i == len(rows)-1 {
+				r[0] = "**" + r[0] + "**"
+			}
+			lines = append(lines, "| "+strings.Join(r, " | ")+" |")
+		}
+		lines = append(lines, "")
+		lines = append(lines, syncNote(est))
+		lines = append(lines, "")
+	}
+
+	if report.Partial {
+		lines = append(lines, fmt.Sprintf("> **PARTIAL REPORT** — The scan was interrupted; only %d completed check(s) are included.", len(report.Results)))
+		lines = append(lines, "")
+	}
 
 	// Readiness verdict
 	if report.CriticalCount() == 0 && report.WarningCount() == 0 {
@@ -49,70 +101,78 @@ func RenderMarkdown(report *models.ScanReport) string {
 	}
 	lines = append(lines, "")
 
-	// Findings by severity
 	allFindings := report.Findings()
-	for _, sev := range []models.Severity{models.SeverityCritical, models.SeverityWarning, models.SeverityConsider, models.SeverityInfo} {
-		var sevFindings []models.Finding
-		for _, f := range allFindings {
-			if f.Severity == sev {
-				sevFindings = append(sevFindings, f)
-			}
-		}
-		if len(sevFindings) == 0 {
-			continue
-		}
+	sevCatMap := buildSevCatMap(allFindings)
+	todoItems := collectTodos(allFindings, opts)
 
-		lines = append(lines, fmt.Sprintf("## %s (%d)", sev.String(), len(sevFindings)))
-		lines = append(lines, "")
+	// Severity by category matrix
+	if len(sevCatMap) > 0 {
+		lines = append(lines, mdSevCatMatrix(sevCatMap)...)
+	}
 
-		// Group by category
-		catSet := make(map[string]bool)
-		for _, f := range sevFindings {
-			catSet[f.Category] = true
-		}
-		var categories []string
-		for cat := range catSet {
-			categories = append(categories, cat)
+	// Findings by severity / category. CONSIDER and INFO sections, and
+	// findings known from the baseline, are folded into <details> blocks.
+	collapsedSeverities := map[models.Severity]bool{
+		models.SeverityConsider: true,
+		models.SeverityInfo:     true,
+	}
+	for _, entry := range sevCatMap {
+		sevCount := 0
+		for _, cf := range entry.categories {
+			sevCount += len(cf.findings)
 		}
-		sort.Strings(categories)
 
-		for _, cat := range categories {
-			var catFindings []models.Finding
-			for _, f := range sevFindings {
-				if f.Category == cat {
-					catFindings = append(catFindings, f)
-				}
-			}
+		heading := fmt.Sprintf("%s (%d)", entry.severity.String(), sevCount)
+		collapsed := collapsedSeverities[entry.severity]
+		if collapsed {
+			lines = append(lines, "<details>")
+			lines = append(lines, fmt.Sprintf("<summary><strong>%s</strong></summary>", heading))
+		} else {
+			lines = append(lines, "## "+heading)
+		}
+		lines = append(lines, "")
 
-			lines = append(lines, fmt.Sprintf("### %s", cat))
+		for _, cf := range entry.categories {
+			lines = append(lines, fmt.Sprintf("### %s (%d)", cf.category, len(cf.findings)))
 			lines = append(lines, "")
 
-			for _, finding := range catFindings {
-				lines = append(lines, fmt.Sprintf("#### %s", finding.Title))
-				lines = append(lines, "")
-				if finding.ObjectName != "" {
-					lines = append(lines, fmt.Sprintf("**Object:** `%s`  ", finding.ObjectName))
+			var known []models.Finding
+			for _, finding := range cf.findings {
+				if finding.Known {
+					known = append(known, finding)
+					continue
 				}
-				lines = append(lines, fmt.Sprintf("**Check:** %s", finding.CheckName))
-				lines = append(lines, "")
-				lines = append(lines, finding.Detail)
+				lines = append(lines, mdFinding(finding)...)
+			}
+			if len(known) > 0 {
+				lines = append(lines, "<details>")
+				lines = append(lines, fmt.Sprintf("<summary>%d known finding%s from the baseline</summary>", len(known), pluralS(len(known))))
 				lines = append(lines, "")
-				if finding.Remediation != "" {
-					lines = append(lines, fmt.Sprintf("**Remediation:** %s", finding.Remediation))
-					lines = append(lines, "")
+				for _, finding := range known {
+					lines = append(lines, mdFinding(finding)...)
 				}
-				lines = append(lines, "---")
+				lines = append(lines, "</details>")
 				lines = append(lines, "")
 			}
 		}
+
+		if collapsed {
+			lines = append(lines, "</details>")
+			lines = append(lines, "")
+		}
 	}
 
-	// Errors
-	var errors []models.CheckResult
-	for _, r := range report.Results {
-		if r.Error != "" {
-			errors = append(errors, r)
+	// Insufficient privileges, then errors
+	privileges, errors := splitErrors(report)
+	if len(privileges) > 0 {
+		lines = append(lines, "## Insufficient Privileges")
+		lines = append(lines, "")
+		lines = append(lines, "These checks could not run because the connected role lacks a privilege.")
+		lines = append(lines, "")
+		for _, r := range privileges {
+			lines = append(lines, fmt.Sprintf("- **%s/%s**: needs %s", r.Category, r.CheckName, privilegeNeeded(r.Error)))
 		}
+		lines = append(lines, "")
 	}
 	if len(errors) > 0 {
 		lines = append(lines, "## Errors")
@@ -123,9 +183,145 @@ func RenderMarkdown(report *models.ScanReport) string {
 		lines = append(lines, "")
 	}
 
+	// Skipped checks
+	if skipped := groupSkipped(report); len(skipped) > 0 {
+		lines = append(lines, "## Skipped Checks")
+		lines = append(lines, "")
+		for _, g := range skipped {
+			lines = append(lines, fmt.Sprintf("- **%s**: %s", g.reason, strings.Join(g.checks, ", ")))
+		}
+		lines = append(lines, "")
+	}
+
+	// Suppressed findings
+	if suppressed := report.SuppressedFindings(); len(suppressed) > 0 {
+		lines = append(lines, fmt.Sprintf("## Suppressed Findings (%d)", len(suppressed)))
+		lines = append(lines, "")
+		lines = append(lines, "These findings match a suppression in the configuration file and are left out of the counts above.")
+		lines = append(lines, "")
+		lines = append(lines, "| Severity | Check | Object | Finding | Reason |")
+		lines = append(lines, "|----------|-------|--------|---------|--------|")
+		for _, f := range suppressed {
+			lines = append(lines, fmt.Sprintf("| %s | %s | `%s` | %s | %s |",
+				f.Severity, f.CheckName, f.ObjectName, mdCell(f.Title), mdCell(f.SuppressionReason)))
+		}
+		lines = append(lines, "")
+	}
+
+	// To Do list
+	if len(todoItems) > 0 {
+		lines = append(lines, "## To Do List")
+		lines = append(lines, "")
+		lines = append(lines, fmt.Sprintf("**%s**", todoSummary(todoItems)))
+		lines = append(lines, "")
+		for _, sev := range []models.Severity{models.SeverityCritical, models.SeverityWarning, models.SeverityConsider} {
+			var items []models.Finding
+			for _, f := range todoItems {
+				if f.Severity == sev {
+					items = append(items, f)
+				}
+			}
+			if len(items) == 0 {
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("### %s", sev.String()))
+			lines = append(lines, "")
+			for _, f := range items {
+				item := fmt.Sprintf("- [ ] **%s**", f.Title)
+				if f.ObjectName != "" {
+					item += fmt.Sprintf(" — `%s`", f.ObjectName)
+				}
+				lines = append(lines, item+"  ")
+				lines = append(lines, "  "+strings.Join(strings.Fields(f.Remediation), " "))
+			}
+			lines = append(lines, "")
+		}
+	}
+
 	// Footer
 	lines = append(lines, "---")
 	lines = append(lines, "*Generated by mm-ready-go v0.1.0*")
 
 	return strings.Join(lines, "\n")
 }
+
+// mdFinding renders one finding under its category heading.
+func mdFinding(finding models.Finding) []string {
+	var lines []string
+	if finding.Known {
+		lines = append(lines, fmt.Sprintf("#### %s *(known)*", finding.Title))
+	} else {
+		lines = append(lines, fmt.Sprintf("#### %s", finding.Title))
+	}
+	lines = append(lines, "")
+	if finding.ObjectName != "" {
+		lines = append(lines, fmt.Sprintf("**Object:** `%s`  ", finding.ObjectName))
+	}
+	if finding.Fingerprint != "" {
+		lines = append(lines, fmt.Sprintf("**Check:** %s  ", finding.CheckName))
+		lines = append(lines, fmt.Sprintf("**Fingerprint:** `%s`", finding.Fingerprint))
+	} else {
+		lines = append(lines, fmt.Sprintf("**Check:** %s", finding.CheckName))
+	}
+	lines = append(lines, "")
+	lines = append(lines, finding.Detail)
+	lines = append(lines, "")
+	if finding.Remediation != "" {
+		lines = append(lines, fmt.Sprintf("**Remediation:** %s", finding.Remediation))
+		lines = append(lines, "")
+	}
+	lines = append(lines, "---")
+	lines = append(lines, "")
+	return lines
+}
+
+// mdSevCatMatrix renders a table of finding counts with a row per category
+// and a column per severity.
+func mdSevCatMatrix(sevCatMap []sevCatEntry) []string {
+	sevs := []models.Severity{models.SeverityCritical, models.SeverityWarning, models.SeverityConsider, models.SeverityInfo}
+	counts := make(map[string]map[models.Severity]int)
+	var categories []string
+	for _, entry := range sevCatMap {
+		for _, cf := range entry.categories {
+			if counts[cf.category] == nil {
+				counts[cf.category] = make(map[models.Severity]int)
+				categories = append(categories, cf.category)
+			}
+			counts[cf.category][entry.severity] = len(cf.findings)
+		}
+	}
+	sort.Strings(categories)
+
+	lines := []string{
+		"## Findings by Category",
+		"",
+		"| Category | CRITICAL | WARNING | CONSIDER | INFO | Total |",
+		"|----------|----------|---------|----------|------|-------|",
+	}
+	totals := make(map[models.Severity]int)
+	grand := 0
+	for _, cat := range categories {
+		row := "| " + mdCell(cat) + " |"
+		sum := 0
+		for _, sev := range sevs {
+			n := counts[cat][sev]
+			totals[sev] += n
+			sum += n
+			row += fmt.Sprintf(" %d |", n)
+		}
+		grand += sum
+		lines = append(lines, row+fmt.Sprintf(" %d |", sum))
+	}
+	row := "| **Total** |"
+	for _, sev := range sevs {
+		row += fmt.Sprintf(" **%d** |", totals[sev])
+	}
+	lines = append(lines, row+fmt.Sprintf(" **%d** |", grand), "")
+	return lines
+}
+
+// mdCell makes text safe to place in a Markdown table cell.
+func mdCell(text string) string {
+	text = strings.ReplaceAll(text, "|", `\|`)
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -3,6 +3,8 @@ package reporter
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/pgEdge/mm-ready-go/internal/models"
 )
@@ -26,10 +28,169 @@ func Render(report *models.ScanReport, format string, opts ReportOptions) (strin
 	case "json":
 		return RenderJSON(report), nil
 	case "markdown":
-		return RenderMarkdown(report), nil
+		return RenderMarkdown(report, opts), nil
 	case "html":
 		return RenderHTML(report, opts), nil
+	case "sarif":
+		return RenderSARIF(report), nil
+	case "junit":
+		return RenderJUnit(report), nil
+	case "csv":
+		return RenderCSV(report), nil
+	case "tsv":
+		return RenderTSV(report), nil
 	default:
 		return "", fmt.Errorf("unknown format: %s", format)
 	}
 }
+
+// collectTodos returns the findings for the To Do list: CRITICAL and WARNING
+// findings that have a remediation, then CONSIDER ones when
+// opts.TodoIncludeConsider is set. It returns nil when opts.TodoList is off.
+func collectTodos(allFindings []models.Finding, opts ReportOptions) []models.Finding {
+	if !opts.TodoList {
+		return nil
+	}
+	todoSevs := []models.Severity{models.SeverityCritical, models.SeverityWarning}
+	if opts.TodoIncludeConsider {
+		todoSevs = append(todoSevs, models.SeverityConsider)
+	}
+	var items []models.Finding
+	for _, sev := range todoSevs {
+		for _, f := range allFindings {
+			if f.Severity == sev && f.Remediation != "" {
+				items = append(items, f)
+			}
+		}
+	}
+	return items
+}
+
+// todoSummary describes the To Do list, for example
+// "3 items to address (1 critical, 2 warnings)".
+func todoSummary(items []models.Finding) string {
+	var crit, warn, consider int
+	for _, f := range items {
+		switch f.Severity {
+		case models.SeverityCritical:
+			crit++
+		case models.SeverityWarning:
+			warn++
+		case models.SeverityConsider:
+			consider++
+		}
+	}
+	var parts []string
+	if crit > 0 {
+		parts = append(parts, fmt.Sprintf("%d critical", crit))
+	}
+	if warn > 0 {
+		parts = append(parts, fmt.Sprintf("%d warning%s", warn, pluralS(warn)))
+	}
+	if consider > 0 {
+		parts = append(parts, fmt.Sprintf("%d to consider", consider))
+	}
+	return fmt.Sprintf("%d item%s to address (%s)", len(items), pluralS(len(items)), strings.Join(parts, ", "))
+}
+
+// syncTable returns the header and rows of the initial sync summary: one
+// row per replication set, then the total. The copy time column is left out
+// when no throughput is configured.
+func syncTable(est *models.SyncEstimate) (header []string, rows [][]string) {
+	header = []string{"Replication Set", "Tables", "Table Data", "Indexes"}
+	if est.Throughput > 0 {
+		header = append(header, "Copy Time")
+	}
+	row := func(name string, g models.SyncGroup) []string {
+		r := []string{name, fmt.Sprint(g.Tables), models.FormatBytes(g.DataBytes), models.FormatBytes(g.IndexBytes)}
+		if est.Throughput > 0 {
+			r = append(r, models.FormatCopyTime(est.CopyTime(g)))
+		}
+		return r
+	}
+	for _, g := range est.Sets {
+		rows = append(rows, row(g.Name, g))
+	}
+	return header, append(rows, row("Total", est.Total))
+}
+
+// syncNote explains what the initial sync estimate covers.
+func syncNote(est *models.SyncEstimate) string {
+	if est.Throughput > 0 {
+		return fmt.Sprintf("Copy time assumes %s/s. Indexes are rebuilt on the new node after the copy, "+
+			"which takes additional time.", models.FormatBytes(int64(est.Throughput)))
+	}
+	return "Set initial_sync.throughput in mm-ready.yaml to estimate copy time."
+}
+
+// splitErrors separates failed checks into those that lack a privilege and
+// all other errors, so that reports can list missing grants apart from bugs.
+func splitErrors(report *models.ScanReport) (privileges, errs []models.CheckResult) {
+	for _, r := range report.Results {
+		if r.Error == "" {
+			continue
+		}
+		if r.ErrorKind == models.ErrorKindPermissionDenied {
+			privileges = append(privileges, r)
+		} else {
+			errs = append(errs, r)
+		}
+	}
+	return privileges, errs
+}
+
+// skipGroup lists the checks skipped for one reason.
+type skipGroup struct {
+	reason string
+	checks []string
+}
+
+// groupSkipped groups skipped checks by SkipReason, in first-seen order.
+func groupSkipped(report *models.ScanReport) []skipGroup {
+	var groups []skipGroup
+	index := map[string]int{}
+	for _, r := range report.Results {
+		if !r.Skipped {
+			continue
+		}
+		i, ok := index[r.SkipReason]
+		if !ok {
+			i = len(groups)
+			index[r.SkipReason] = i
+			groups = append(groups, skipGroup{reason: r.SkipReason})
+		}
+		groups[i].checks = append(groups[i].checks, r.CheckName)
+	}
+	return groups
+}
+
+var (
+	permissionDeniedRe = regexp.MustCompile(`permission denied for (\w+(?: \w+)?) ("?[^\s"(]+"?)`)
+	roleRequiredRe     = regexp.MustCompile(`(?:have privileges of|a member of) (?:the )?"?(pg_\w+)"?`)
+)
+
+// privilegeNeeded describes the privilege a permission_denied error asks for,
+// e.g. "SELECT on table spock.node" or "membership in pg_read_all_stats".
+// Unrecognised messages are returned as-is after a generic prefix.
+func privilegeNeeded(errMsg string) string {
+	if m := roleRequiredRe.FindStringSubmatch(errMsg); m != nil {
+		return "membership in " + m[1]
+	}
+	if m := permissionDeniedRe.FindStringSubmatch(errMsg); m != nil {
+		object := strings.Trim(m[2], `"`)
+		switch m[1] {
+		case "table", "view", "materialized view", "sequence", "relation":
+			return fmt.Sprintf("SELECT on %s %s", m[1], object)
+		case "function", "procedure":
+			return fmt.Sprintf("EXECUTE on %s %s", m[1], object)
+		case "schema":
+			return "USAGE on schema " + object
+		default:
+			return fmt.Sprintf("access to %s %s", m[1], object)
+		}
+	}
+	if strings.Contains(errMsg, "must be superuser") {
+		return "superuser"
+	}
+	return "a privilege: " + errMsg
+}
@@ -1,12 +1,17 @@
 package reporter
 
 import (
+	"encoding/csv"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"os"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/pgEdge/mm-ready-go/internal/diff"
 	"github.com/pgEdge/mm-ready-go/internal/models"
 )
 
@@ -49,6 +54,7 @@ func sampleReport() *models.ScanReport {
 			Title:       "wal_level is not 'logical'",
 			Detail:      "Current value: replica",
 			Remediation: "ALTER SYSTEM SET wal_level = 'logical';",
+			Fingerprint: "0123456789abcdef",
 		}},
 	})
 
@@ -193,6 +199,122 @@ func TestJSONSummaryCounts(t *testing.T) {
 	}
 }
 
+func TestJSONReadiness(t *testing.T) {
+	r := sampleReport()
+	r.Results[1].Effort = models.EffortMedium
+	var data map[string]any
+	if err := json.Unmarshal([]byte(RenderJSON(r)), &data); err != nil {
+		t.Fatal(err)
+	}
+	rd := data["summary"].(map[string]any)["readiness"].(map[string]any)
+	if rd["score"] != float64(60) {
+		t.Errorf("readiness score = %v, want 60", rd["score"])
+	}
+	if cats := rd["categories"].(map[string]any); cats["schema"] != float64(80) || cats["replication"] != float64(0) {
+		t.Errorf("category scores = %v", cats)
+	}
+	effort := rd["effort"].(map[string]any)
+	if effort["medium"] != float64(1) || effort["small"] != float64(2) || effort["hours"] != float64(6) {
+		t.Errorf("effort = %v", effort)
+	}
+	if _, ok := rd["weights"]; ok {
+		t.Error("default weights should not be written")
+	}
+	result := data["results"].([]any)[1].(map[string]any)
+	if result["effort"] != "medium" {
+		t.Errorf("result effort = %v, want medium", result["effort"])
+	}
+}
+
+func TestMarkdownAndHTMLReadiness(t *testing.T) {
+	md := RenderMarkdown(sampleReport(), DefaultReportOptions())
+	for _, want := range []string{
+		"| **Readiness Score** | **60/100** |",
+		"| Estimated Effort | about 3 hours (3 small) |",
+		"| schema | 80 | 3 |",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown missing %q", want)
+		}
+	}
+	html := RenderHTML(sampleReport(), DefaultReportOptions())
+	if !strings.Contains(html, `<div class="summary-card warning"><div class="number">60</div>Readiness Score</div>`) {
+		t.Error("HTML should show the readiness score card")
+	}
+	if !strings.Contains(html, `<tr><td>replication</td><td class="critical">0</td><td>1</td></tr>`) {
+		t.Error("HTML should list category scores")
+	}
+}
+
+// syncReport returns a report with initial_sync findings for two
+// replication sets.
+func syncReport() *models.ScanReport {
+	r := sampleReport()
+	r.SyncThroughput = 256 << 10
+	r.Results = append(r.Results, models.CheckResult{
+		CheckName: models.SyncCheckName, Category: "replication",
+		Findings: []models.Finding{
+			makeFinding(func(f *models.Finding) {
+				f.Severity = models.SeverityInfo
+				f.Metadata = map[string]any{"tables": 3, "data_bytes": int64(3 << 30), "index_bytes": int64(1 << 30)}
+			}),
+			makeFinding(func(f *models.Finding) {
+				f.Severity = models.SeverityInfo
+				f.Metadata = map[string]any{"replication_set": "default", "tables": 2,
+					"data_bytes": int64(3 << 30), "index_bytes": int64(1 << 30)}
+			}),
+			makeFinding(func(f *models.Finding) {
+				f.Severity = models.SeverityInfo
+				f.Metadata = map[string]any{"replication_set": "default_insert_only", "tables": 1,
+					"data_bytes": int64(600 << 20), "index_bytes": int64(0)}
+			}),
+		},
+	})
+	return r
+}
+
+func TestInitialSyncSummary(t *testing.T) {
+	r := syncReport()
+	md := RenderMarkdown(r, DefaultReportOptions())
+	for _, want := range []string{
+		"## Initial Sync Estimate",
+		"| Replication Set | Tables | Table Data | Indexes | Copy Time |",
+		"| default | 2 | 3.0 GB | 1.0 GB | about 3 h 25 min |",
+		"| default_insert_only | 1 | 600.0 MB | 0 bytes | about 40 min |",
+		"| **Total** | 3 | 3.0 GB | 1.0 GB | about 3 h 25 min |",
+		"Copy time assumes 256.0 kB/s.",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown missing %q", want)
+		}
+	}
+	html := RenderHTML(r, DefaultReportOptions())
+	if !strings.Contains(html, `<tr class="total"><td>Total</td><td>3</td><td>3.0 GB</td><td>1.0 GB</td><td>about 3 h 25 min</td></tr>`) {
+		t.Error("HTML should show the initial sync total")
+	}
+
+	var data map[string]any
+	if err := json.Unmarshal([]byte(RenderJSON(r)), &data); err != nil {
+		t.Fatal(err)
+	}
+	sync := data["summary"].(map[string]any)["initial_sync"].(map[string]any)
+	if sync["data_bytes"] != float64(3<<30) || sync["copy_seconds"] != float64(12288) {
+		t.Errorf("initial_sync = %v", sync)
+	}
+	if sets := sync["replication_sets"].([]any); len(sets) != 2 {
+		t.Errorf("replication_sets = %v", sets)
+	}
+
+	r.SyncThroughput = 0
+	md = RenderMarkdown(r, DefaultReportOptions())
+	if strings.Contains(md, "Copy Time") || !strings.Contains(md, "Set initial_sync.throughput") {
+		t.Error("without a throughput, copy time should be left out")
+	}
+	if strings.Contains(RenderMarkdown(sampleReport(), DefaultReportOptions()), "Initial Sync") {
+		t.Error("reports without the initial_sync check should have no sync section")
+	}
+}
+
 func TestJSONResultsCount(t *testing.T) {
 	var data map[string]any
 	if err := json.Unmarshal([]byte(RenderJSON(sampleReport())), &data); err != nil {
@@ -222,6 +344,9 @@ func TestJSONFindingFields(t *testing.T) {
 	if f["title"] != "wal_level is not 'logical'" {
 		t.Errorf("title = %v", f["title"])
 	}
+	if f["fingerprint"] != "0123456789abcdef" {
+		t.Errorf("fingerprint = %v", f["fingerprint"])
+	}
 }
 
 func TestJSONErrorReported(t *testing.T) {
@@ -260,10 +385,82 @@ func TestJSONMetaFields(t *testing.T) {
 	}
 }
 
+func TestJSONPartialAndTimedOut(t *testing.T) {
+	r := sampleReport()
+	r.Partial = true
+	r.Results = append(r.Results, models.CheckResult{
+		CheckName:   "stored_procedures",
+		Category:    "functions",
+		Description: "Stored procedures audit",
+		Error:       "timed out after 1m0s",
+		TimedOut:    true,
+	})
+
+	var data map[string]any
+	if err := json.Unmarshal([]byte(RenderJSON(r)), &data); err != nil {
+		t.Fatal(err)
+	}
+	meta := data["meta"].(map[string]any)
+	if meta["partial"] != true {
+		t.Errorf("meta.partial = %v, want true", meta["partial"])
+	}
+	results := data["results"].([]any)
+	last := results[len(results)-1].(map[string]any)
+	if last["timed_out"] != true {
+		t.Errorf("timed_out = %v, want true", last["timed_out"])
+	}
+	if last["passed"] != false {
+		t.Errorf("passed = %v, want false", last["passed"])
+	}
+}
+
+func TestJSONSnapshotMeta(t *testing.T) {
+	r := sampleReport()
+	r.SnapshotID = "00000003-0000001B-1"
+	r.StartLSN = "0/1A2B3C4"
+
+	var data map[string]any
+	if err := json.Unmarshal([]byte(RenderJSON(r)), &data); err != nil {
+		t.Fatal(err)
+	}
+	meta := data["meta"].(map[string]any)
+	if meta["snapshot_id"] != "00000003-0000001B-1" {
+		t.Errorf("snapshot_id = %v", meta["snapshot_id"])
+	}
+	if meta["start_lsn"] != "0/1A2B3C4" {
+		t.Errorf("start_lsn = %v", meta["start_lsn"])
+	}
+}
+
+func TestJSONErrorKind(t *testing.T) {
+	r := sampleReport()
+	r.Results = append(r.Results, models.CheckResult{
+		CheckName:   "conflict_log",
+		Category:    "replication",
+		Description: "Conflict log",
+		Error:       "ERROR: permission denied for table resolutions (SQLSTATE 42501)",
+		ErrorKind:   models.ErrorKindPermissionDenied,
+	})
+
+	var data map[string]any
+	if err := json.Unmarshal([]byte(RenderJSON(r)), &data); err != nil {
+		t.Fatal(err)
+	}
+	results := data["results"].([]any)
+	last := results[len(results)-1].(map[string]any)
+	if last["error_kind"] != "permission_denied" {
+		t.Errorf("error_kind = %v, want permission_denied", last["error_kind"])
+	}
+	first := results[0].(map[string]any)
+	if _, ok := first["error_kind"]; ok {
+		t.Error("passing result should not have error_kind")
+	}
+}
+
 // -- Markdown Reporter --------------------------------------------------------
 
 func TestMarkdownContainsHeader(t *testing.T) {
-	output := RenderMarkdown(sampleReport())
+	output := RenderMarkdown(sampleReport(), DefaultReportOptions())
 	if !strings.Contains(output, "testdb") {
 		t.Error("markdown should contain database name")
 	}
@@ -274,7 +471,7 @@ func TestMarkdownContainsHeader(t *testing.T) {
 }
 
 func TestMarkdownContainsSeveritySections(t *testing.T) {
-	output := RenderMarkdown(sampleReport())
+	output := RenderMarkdown(sampleReport(), DefaultReportOptions())
 	if !strings.Contains(output, "CRITICAL") {
 		t.Error("markdown should contain CRITICAL")
 	}
@@ -284,7 +481,7 @@ func TestMarkdownContainsSeveritySections(t *testing.T) {
 }
 
 func TestMarkdownContainsFindingTitles(t *testing.T) {
-	output := RenderMarkdown(sampleReport())
+	output := RenderMarkdown(sampleReport(), DefaultReportOptions())
 	if !strings.Contains(output, "wal_level is not 'logical'") {
 		t.Error("markdown should contain wal_level finding title")
 	}
@@ -293,6 +490,122 @@ func TestMarkdownContainsFindingTitles(t *testing.T) {
 	}
 }
 
+func TestMarkdownContainsFingerprint(t *testing.T) {
+	output := RenderMarkdown(sampleReport(), DefaultReportOptions())
+	if !strings.Contains(output, "**Fingerprint:** `0123456789abcdef`") {
+		t.Error("Markdown should contain the finding fingerprint")
+	}
+}
+
+func TestMarkdownPartialBanner(t *testing.T) {
+	r := sampleReport()
+	if strings.Contains(RenderMarkdown(r, DefaultReportOptions()), "PARTIAL REPORT") {
+		t.Error("complete report should not contain PARTIAL REPORT")
+	}
+	r.Partial = true
+	if !strings.Contains(RenderMarkdown(r, DefaultReportOptions()), "PARTIAL REPORT") {
+		t.Error("partial report should contain PARTIAL REPORT")
+	}
+}
+
+func TestMarkdownPrivilegesSeparateFromErrors(t *testing.T) {
+	r := sampleReport()
+	r.Results = append(r.Results, models.CheckResult{
+		CheckName:   "hba_config",
+		Category:    "config",
+		Description: "HBA config check",
+		Error:       "ERROR: permission denied for view pg_hba_file_rules (SQLSTATE 42501)",
+		ErrorKind:   models.ErrorKindPermissionDenied,
+	})
+	output := RenderMarkdown(r, DefaultReportOptions())
+
+	privIdx := strings.Index(output, "## Insufficient Privileges")
+	errIdx := strings.Index(output, "## Errors")
+	if privIdx < 0 || errIdx < 0 {
+		t.Fatalf("markdown should contain both privilege and error sections")
+	}
+	if !strings.Contains(output, "needs SELECT on view pg_hba_file_rules") {
+		t.Error("markdown should name the missing privilege")
+	}
+	errSection := output[errIdx:]
+	if strings.Contains(errSection, "pg_hba_file_rules (SQLSTATE") {
+		t.Error("permission errors should not be listed under Errors")
+	}
+}
+
+func TestMarkdownSkippedChecksGroupedByReason(t *testing.T) {
+	r := sampleReport()
+	for _, name := range []string{"ddl_statements", "advisory_locks"} {
+		r.Results = append(r.Results, models.CheckResult{
+			CheckName:  name,
+			Category:   "sql_patterns",
+			Skipped:    true,
+			SkipReason: "extension pg_stat_statements is not installed",
+		})
+	}
+	output := RenderMarkdown(r, DefaultReportOptions())
+	if !strings.Contains(output, "## Skipped Checks") {
+		t.Fatal("markdown should contain Skipped Checks section")
+	}
+	if !strings.Contains(output, "- **extension pg_stat_statements is not installed**: ddl_statements, advisory_locks") {
+		t.Error("checks skipped for the same reason should share one line")
+	}
+}
+
+func TestMarkdownTodoList(t *testing.T) {
+	output := RenderMarkdown(sampleReport(), DefaultReportOptions())
+	if !strings.Contains(output, "## To Do List") {
+		t.Fatal("markdown should contain a To Do list")
+	}
+	todo := output[strings.Index(output, "## To Do List"):]
+	if !strings.Contains(todo, "**2 items to address (1 critical, 1 warning)**") {
+		t.Error("To Do list should summarize its items")
+	}
+	if !strings.Contains(todo, "- [ ] **Table missing primary key** — `public.orders`") {
+		t.Error("To Do list should have a checkbox per remediation")
+	}
+	if strings.Contains(todo, "ENUM type found") {
+		t.Error("CONSIDER items should be left out by default")
+	}
+
+	output = RenderMarkdown(sampleReport(), ReportOptions{TodoList: true, TodoIncludeConsider: true})
+	if !strings.Contains(output, "3 items to address (1 critical, 1 warning, 1 to consider)") {
+		t.Error("TodoIncludeConsider should add CONSIDER items")
+	}
+
+	output = RenderMarkdown(sampleReport(), ReportOptions{})
+	if strings.Contains(output, "To Do List") {
+		t.Error("TodoList false should omit the To Do list")
+	}
+}
+
+func TestMarkdownSevCatMatrix(t *testing.T) {
+	output := RenderMarkdown(sampleReport(), DefaultReportOptions())
+	for _, want := range []string{
+		"| Category | CRITICAL | WARNING | CONSIDER | INFO | Total |",
+		"| replication | 1 | 0 | 0 | 0 | 1 |",
+		"| schema | 0 | 1 | 1 | 0 | 2 |",
+		"| **Total** | **1** | **1** | **1** | **1** | **4** |",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("markdown matrix missing %q", want)
+		}
+	}
+}
+
+func TestMarkdownCollapsedSections(t *testing.T) {
+	output := RenderMarkdown(sampleReport(), DefaultReportOptions())
+	if !strings.Contains(output, "## CRITICAL (1)") {
+		t.Error("CRITICAL section should be expanded")
+	}
+	if !strings.Contains(output, "<details>\n<summary><strong>CONSIDER (1)</strong></summary>") {
+		t.Error("CONSIDER section should be collapsible")
+	}
+	if !strings.Contains(output, "<summary><strong>INFO (1)</strong></summary>") {
+		t.Error("INFO section should be collapsible")
+	}
+}
+
 // -- HTML Reporter ------------------------------------------------------------
 
 func TestHTMLValidStructure(t *testing.T) {
@@ -323,6 +636,13 @@ func TestHTMLContainsFindingContent(t *testing.T) {
 	}
 }
 
+func TestHTMLContainsFingerprint(t *testing.T) {
+	output := RenderHTML(sampleReport(), DefaultReportOptions())
+	if !strings.Contains(output, `id="f-0123456789abcdef" data-fingerprint="0123456789abcdef"`) {
+		t.Error("HTML finding card should carry the fingerprint")
+	}
+}
+
 func TestHTMLTodoSectionPresent(t *testing.T) {
 	output := RenderHTML(sampleReport(), DefaultReportOptions())
 	if !strings.Contains(output, "To Do") {
@@ -340,6 +660,53 @@ func TestHTMLSidebarPresent(t *testing.T) {
 	}
 }
 
+func TestHTMLPartialBanner(t *testing.T) {
+	r := sampleReport()
+	r.Partial = true
+	if !strings.Contains(RenderHTML(r, DefaultReportOptions()), "PARTIAL REPORT") {
+		t.Error("partial report should contain PARTIAL REPORT")
+	}
+}
+
+func TestHTMLPrivilegesSection(t *testing.T) {
+	r := sampleReport()
+	r.Results = append(r.Results, models.CheckResult{
+		CheckName:   "parameter_settings",
+		Category:    "config",
+		Description: "Parameter settings",
+		Error:       `ERROR: must be superuser or have privileges of pg_read_all_settings to examine "data_directory" (SQLSTATE 42501)`,
+		ErrorKind:   models.ErrorKindPermissionDenied,
+	})
+	output := RenderHTML(r, DefaultReportOptions())
+	if !strings.Contains(output, `id="privileges"`) {
+		t.Error("HTML should contain privileges section")
+	}
+	if !strings.Contains(output, "needs membership in pg_read_all_settings") {
+		t.Error("HTML should name the required role")
+	}
+}
+
+// -- Privilege Hints ----------------------------------------------------------
+
+func TestPrivilegeNeeded(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want string
+	}{
+		{"ERROR: permission denied for table node (SQLSTATE 42501)", "SELECT on table node"},
+		{"ERROR: permission denied for function pg_read_file (SQLSTATE 42501)", "EXECUTE on function pg_read_file"},
+		{"ERROR: permission denied for schema spock (SQLSTATE 42501)", "USAGE on schema spock"},
+		{"ERROR: must be superuser or a member of pg_read_all_settings (SQLSTATE 42501)", "membership in pg_read_all_settings"},
+		{"ERROR: must be superuser to read files (SQLSTATE 42501)", "superuser"},
+		{"something else", "a privilege: something else"},
+	}
+	for _, tt := range tests {
+		if got := privilegeNeeded(tt.msg); got != tt.want {
+			t.Errorf("privilegeNeeded(%q) = %q, want %q", tt.msg, got, tt.want)
+		}
+	}
+}
+
 // -- Verdict Logic ------------------------------------------------------------
 
 func TestVerdictReadyNoFindings(t *testing.T) {
@@ -357,7 +724,7 @@ func TestVerdictReadyNoFindings(t *testing.T) {
 		Category:    "c",
 		Description: "d",
 	})
-	md := RenderMarkdown(r)
+	md := RenderMarkdown(r, DefaultReportOptions())
 	if !strings.Contains(md, "READY") {
 		t.Error("should contain READY")
 	}
@@ -371,7 +738,7 @@ func TestVerdictReadyNoFindings(t *testing.T) {
 
 func TestVerdictNotReadyWithCritical(t *testing.T) {
 	r := makeReportWithSeverities(models.SeverityCritical)
-	md := RenderMarkdown(r)
+	md := RenderMarkdown(r, DefaultReportOptions())
 	if !strings.Contains(md, "NOT READY") {
 		t.Error("should contain NOT READY")
 	}
@@ -379,7 +746,7 @@ func TestVerdictNotReadyWithCritical(t *testing.T) {
 
 func TestVerdictConditionallyReadyWithWarning(t *testing.T) {
 	r := makeReportWithSeverities(models.SeverityWarning)
-	md := RenderMarkdown(r)
+	md := RenderMarkdown(r, DefaultReportOptions())
 	if !strings.Contains(md, "CONDITIONALLY READY") {
 		t.Error("should contain CONDITIONALLY READY")
 	}
@@ -387,7 +754,7 @@ func TestVerdictConditionallyReadyWithWarning(t *testing.T) {
 
 func TestVerdictReadyWithOnlyConsiderAndInfo(t *testing.T) {
 	r := makeReportWithSeverities(models.SeverityConsider, models.SeverityInfo)
-	md := RenderMarkdown(r)
+	md := RenderMarkdown(r, DefaultReportOptions())
 	upper := strings.ToUpper(md)
 	if strings.Contains(upper, "NOT READY") {
 		t.Error("should not contain NOT READY")
@@ -416,3 +783,626 @@ func TestVerdictNotInJSON(t *testing.T) {
 		t.Error("JSON summary should not contain verdict field")
 	}
 }
+
+// -- Baseline -----------------------------------------------------------------
+
+func TestLoadBaselineRoundTrip(t *testing.T) {
+	report := sampleReport()
+	for i := range report.Results {
+		report.Results[i].SetFingerprints()
+	}
+	path := t.TempDir() + "/base.json"
+	if err := os.WriteFile(path, []byte(RenderJSON(report)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	known, err := LoadBaseline(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report.ApplyBaseline(path, known)
+	if report.NewCount() != 0 || report.KnownCount() != len(report.Findings()) {
+		t.Errorf("new %d known %d, want every finding known", report.NewCount(), report.KnownCount())
+	}
+}
+
+func TestLoadBaselineWithoutFingerprints(t *testing.T) {
+	path := t.TempDir() + "/old.json"
+	old := `{"results": [{"check_name": "primary_keys", "findings": [
+		{"severity": "WARNING", "title": "Table 'public.t' has no primary key", "object_name": "public.t"}]}]}`
+	if err := os.WriteFile(path, []byte(old), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	known, err := LoadBaseline(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := models.CheckResult{CheckName: "primary_keys", Findings: []models.Finding{{
+		Title:      "Table 'public.t' has no primary key",
+		ObjectName: "public.t",
+	}}}
+	r.SetFingerprints()
+	if !known[r.Findings[0].Fingerprint] {
+		t.Error("finding from a baseline without fingerprints should still match")
+	}
+}
+
+func TestLoadBaselineInvalid(t *testing.T) {
+	path := t.TempDir() + "/bad.json"
+	if err := os.WriteFile(path, []byte("not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadBaseline(path); err == nil {
+		t.Error("expected an error for an invalid baseline")
+	}
+}
+
+func baselineReport() *models.ScanReport {
+	report := sampleReport()
+	report.ApplyBaseline("base.json", map[string]bool{"0123456789abcdef": true})
+	return report
+}
+
+func TestJSONBaselineCounts(t *testing.T) {
+	var data map[string]any
+	if err := json.Unmarshal([]byte(RenderJSON(baselineReport())), &data); err != nil {
+		t.Fatal(err)
+	}
+	summary := data["summary"].(map[string]any)
+	if summary["known"] != float64(1) || summary["new"] != float64(3) {
+		t.Errorf("summary new %v known %v, want 3 and 1", summary["new"], summary["known"])
+	}
+	if data["meta"].(map[string]any)["baseline"] != "base.json" {
+		t.Error("meta should name the baseline")
+	}
+	f := data["results"].([]any)[0].(map[string]any)["findings"].([]any)[0].(map[string]any)
+	if f["known"] != true {
+		t.Errorf("known = %v, want true", f["known"])
+	}
+}
+
+func TestHTMLFoldsKnownFindings(t *testing.T) {
+	output := RenderHTML(baselineReport(), DefaultReportOptions())
+	if !strings.Contains(output, `<summary>1 known finding from the baseline</summary>`) {
+		t.Error("HTML should fold known findings")
+	}
+	if !strings.Contains(output, `Known</div>`) {
+		t.Error("HTML summary should count known findings")
+	}
+	if strings.Contains(RenderHTML(sampleReport(), DefaultReportOptions()), "known-findings\">") {
+		t.Error("HTML without a baseline should not fold findings")
+	}
+}
+
+func TestMarkdownBaselineCounts(t *testing.T) {
+	output := RenderMarkdown(baselineReport(), DefaultReportOptions())
+	if !strings.Contains(output, "| Known (in baseline) | 1 |") {
+		t.Error("Markdown summary should count known findings")
+	}
+	if !strings.Contains(output, "*(known)*") {
+		t.Error("Markdown should mark known findings")
+	}
+	if !strings.Contains(output, "known finding from the baseline</summary>") {
+		t.Error("Markdown should fold known findings")
+	}
+}
+
+// -- Suppressions -------------------------------------------------------------
+
+func suppressedReport() *models.ScanReport {
+	report := sampleReport()
+	report.Results[1].Suppressed = []models.Finding{{
+		Severity:          models.SeverityWarning,
+		CheckName:         "primary_keys",
+		Category:          "schema",
+		Title:             "Table 'audit.login_log' has no primary key",
+		ObjectName:        "audit.login_log",
+		SuppressionReason: "Insert-only | log table",
+	}}
+	return report
+}
+
+func TestJSONSuppressedFindings(t *testing.T) {
+	var data map[string]any
+	if err := json.Unmarshal([]byte(RenderJSON(suppressedReport())), &data); err != nil {
+		t.Fatal(err)
+	}
+	if got := data["summary"].(map[string]any)["suppressed"]; got != float64(1) {
+		t.Errorf("summary suppressed = %v, want 1", got)
+	}
+	pk := data["results"].([]any)[1].(map[string]any)
+	sup := pk["suppressed"].([]any)[0].(map[string]any)
+	if sup["suppression_reason"] != "Insert-only | log table" {
+		t.Errorf("suppression_reason = %v", sup["suppression_reason"])
+	}
+}
+
+func TestMarkdownSuppressedSection(t *testing.T) {
+	output := RenderMarkdown(suppressedReport(), DefaultReportOptions())
+	if !strings.Contains(output, "## Suppressed Findings (1)") {
+		t.Error("Markdown should have a suppressed findings section")
+	}
+	if !strings.Contains(output, `Insert-only \| log table`) {
+		t.Error("Markdown should escape pipes in the reason")
+	}
+	if strings.Contains(RenderMarkdown(sampleReport(), DefaultReportOptions()), "Suppressed Findings") {
+		t.Error("Markdown without suppressions should not have the section")
+	}
+}
+
+func TestHTMLSuppressedSection(t *testing.T) {
+	output := RenderHTML(suppressedReport(), DefaultReportOptions())
+	if !strings.Contains(output, `<h2 id="suppressed">Suppressed Findings</h2>`) {
+		t.Error("HTML should have a suppressed findings section")
+	}
+	if !strings.Contains(output, `href="#suppressed"`) {
+		t.Error("HTML sidebar should link to suppressed findings")
+	}
+}
+
+// -- Diff ---------------------------------------------------------------------
+
+func sampleDiff() *diff.Result {
+	old := sampleReport()
+	new := sampleReport()
+	// Resolve the primary key finding, escalate the pg_version one, and
+	// make hba_config stop erroring.
+	new.Results[1].Findings = nil
+	new.Results[3].Findings[0].Severity = models.SeverityWarning
+	new.Results[5].Error = ""
+	return diff.Compare(old, new)
+}
+
+func TestLoadJSONFindings(t *testing.T) {
+	path := t.TempDir() + "/r.json"
+	if err := os.WriteFile(path, []byte(RenderJSON(sampleReport())), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	report, err := LoadJSON(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Database != "testdb" || len(report.Results) != len(sampleReport().Results) {
+		t.Errorf("loaded %s with %d results", report.Database, len(report.Results))
+	}
+	f := report.Results[0].Findings[0]
+	if f.Severity != models.SeverityCritical || f.CheckName != "wal_level" || f.Category != "replication" {
+		t.Errorf("loaded finding %+v", f)
+	}
+	if report.Results[5].Error == "" {
+		t.Error("loaded report lost the check error")
+	}
+}
+
+func TestLoadJSONRoundTrip(t *testing.T) {
+	r := sampleReport()
+	r.ScanMode = "audit"
+	r.Timestamp = time.Date(2026, 1, 27, 12, 0, 0, 123456000, time.UTC)
+	r.Partial = true
+	r.SnapshotID = "00000003-00000002-1"
+	r.StartLSN = "0/16B3748"
+	r.Baseline = "baseline.json"
+	r.Results[1].Findings[0].Known = true
+	r.Results[1].Findings[0].Fingerprint = "fedcba9876543210"
+	r.Results[2].Findings[0].Metadata = map[string]any{
+		"label_count": int64(3),
+		"ratio":       0.25,
+		"labels":      []any{"a", "b"},
+		"bytes":       int64(9007199254740993),
+		"nested":      map[string]any{"ok": true},
+	}
+	r.Results[2].Suppressed = []models.Finding{{
+		Severity: models.SeverityConsider, CheckName: "enum_types", Category: "schema",
+		Title: "ENUM type found", ObjectName: "public.other", SuppressionReason: "Reviewed",
+		Fingerprint: "1111111111111111",
+	}}
+	r.Results[5].ErrorKind = models.ErrorKindPermissionDenied
+	r.Results[1].Effort = models.EffortMedium
+	r.Weights = &models.ScoreWeights{Critical: 1, Warning: 0.3, Consider: 0, Categories: map[string]float64{"schema": 2}}
+	r.SyncThroughput = 50 << 20
+
+	path := t.TempDir() + "/scan.json"
+	if err := os.WriteFile(path, []byte(RenderJSON(r)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadJSON(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded, r) {
+		t.Errorf("round trip changed the report:\n got %+v\nwant %+v", loaded, r)
+	}
+	opts := DefaultReportOptions()
+	for _, format := range []string{"json", "markdown", "html"} {
+		want, _ := Render(r, format, opts)
+		got, _ := Render(loaded, format, opts)
+		if got != want {
+			t.Errorf("%s output differs after round trip", format)
+		}
+	}
+}
+
+func TestLoadJSONErrors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := LoadJSON(dir + "/missing.json"); err == nil {
+		t.Error("expected error for missing file")
+	}
+	bad := dir + "/bad.json"
+	if err := os.WriteFile(bad, []byte(`{"results":[{"check_name":"x","findings":[{"severity":"FATAL"}]}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadJSON(bad); err == nil || !strings.Contains(err.Error(), "x:") {
+		t.Errorf("expected severity error naming the check, got %v", err)
+	}
+}
+
+func TestRenderDiffJSON(t *testing.T) {
+	var data map[string]any
+	if err := json.Unmarshal([]byte(RenderDiffJSON(sampleDiff())), &data); err != nil {
+		t.Fatal(err)
+	}
+	summary := data["summary"].(map[string]any)
+	for key, want := range map[string]float64{
+		"resolved": 1, "new": 0, "severity_changed": 1, "started_erroring": 0, "stopped_erroring": 1,
+	} {
+		if summary[key] != want {
+			t.Errorf("summary %s = %v, want %v", key, summary[key], want)
+		}
+	}
+	change := data["severity_changed"].([]any)[0].(map[string]any)
+	if change["old_severity"] != "INFO" || change["new_severity"] != "WARNING" {
+		t.Errorf("severity change = %v", change)
+	}
+}
+
+func TestRenderDiffMarkdownAndHTML(t *testing.T) {
+	d := sampleDiff()
+	md := RenderDiffMarkdown(d)
+	for _, want := range []string{"## Resolved Findings (1)", "## Severity Changed (1)", "## Checks Stopped Erroring (1)"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown diff missing %q", want)
+		}
+	}
+	page := RenderDiffHTML(d)
+	for _, want := range []string{`<h2 id="resolved">`, `<h2 id="severity-changed">`, `<h2 id="stopped-erroring">`} {
+		if !strings.Contains(page, want) {
+			t.Errorf("HTML diff missing %q", want)
+		}
+	}
+	if _, err := RenderDiff(d, "xml"); err == nil {
+		t.Error("expected error for unknown diff format")
+	}
+}
+
+func TestRenderDiffSARIF(t *testing.T) {
+	d := sampleDiff()
+	d.Added = []models.Finding{{
+		Severity: models.SeverityCritical, CheckName: "wal_level", Category: "replication",
+		Title: "wal_level is minimal", ObjectName: "wal_level", Fingerprint: "0123456789abcdef",
+	}}
+
+	var log map[string]any
+	if err := json.Unmarshal([]byte(RenderDiffSARIF(d)), &log); err != nil {
+		t.Fatal(err)
+	}
+	run := log["runs"].([]any)[0].(map[string]any)
+	states := make(map[string]string)
+	for _, r := range run["results"].([]any) {
+		res := r.(map[string]any)
+		states[res["ruleId"].(string)] = res["baselineState"].(string)
+		if res["baselineState"] == "updated" && res["properties"].(map[string]any)["old_severity"] != "INFO" {
+			t.Errorf("updated result should carry its old severity: %v", res["properties"])
+		}
+	}
+	want := map[string]string{"wal_level": "new", "pg_version": "updated", "primary_keys": "absent"}
+	if !reflect.DeepEqual(states, want) {
+		t.Errorf("baseline states = %v, want %v", states, want)
+	}
+	rules := run["tool"].(map[string]any)["driver"].(map[string]any)["rules"].([]any)
+	if len(rules) != 3 {
+		t.Errorf("expected a rule per changed check, got %d", len(rules))
+	}
+	notes := run["invocations"].([]any)[0].(map[string]any)["toolExecutionNotifications"].([]any)
+	if len(notes) != 1 || notes[0].(map[string]any)["level"] != "note" {
+		t.Errorf("expected a note for the check that stopped erroring, got %v", notes)
+	}
+	if out, err := RenderDiff(d, "sarif"); err != nil || !strings.Contains(out, sarifSchema) {
+		t.Errorf("RenderDiff sarif: %v", err)
+	}
+}
+
+func TestRenderDiffJUnit(t *testing.T) {
+	d := sampleDiff()
+	d.Added = []models.Finding{{
+		Severity: models.SeverityCritical, CheckName: "wal_level", Category: "replication",
+		Title: "wal_level is minimal", ObjectName: "wal_level",
+	}}
+	d.StartedErroring = []models.CheckResult{{CheckName: "sub_health", Category: "replication", Error: "permission denied"}}
+
+	var root junitTestSuites
+	if err := xml.Unmarshal([]byte(RenderDiffJUnit(d)), &root); err != nil {
+		t.Fatal(err)
+	}
+	// The new CRITICAL finding and the INFO finding raised to WARNING fail.
+	if root.Tests != 5 || root.Failures != 2 || root.Errors != 1 {
+		t.Errorf("tests=%d failures=%d errors=%d, want 5/2/1", root.Tests, root.Failures, root.Errors)
+	}
+	var names []string
+	for _, s := range root.Suites {
+		names = append(names, s.Name)
+	}
+	want := "new,severity_changed,resolved,started_erroring,stopped_erroring"
+	if got := strings.Join(names, ","); got != want {
+		t.Errorf("suites = %s, want %s", got, want)
+	}
+	if tc := root.Suites[0].Cases[0]; tc.Name != "wal_level: wal_level" || tc.Failure == nil {
+		t.Errorf("new finding case = %+v", tc)
+	}
+	if _, err := RenderDiff(d, "junit"); err != nil {
+		t.Errorf("RenderDiff junit: %v", err)
+	}
+}
+
+func TestRenderDiffCSVAndTSV(t *testing.T) {
+	d := sampleDiff()
+	out, err := RenderDiff(d, "csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(rows[0], ",") != strings.Join(diffColumns, ",") {
+		t.Errorf("header = %v", rows[0])
+	}
+	var changes []string
+	for _, row := range rows[1:] {
+		changes = append(changes, row[0])
+	}
+	if got := strings.Join(changes, ","); got != "severity_changed,resolved,stopped_erroring" {
+		t.Errorf("changes = %s", got)
+	}
+	if rows[1][1] != "WARNING" || rows[1][2] != "INFO" {
+		t.Errorf("severity change row = %v", rows[1])
+	}
+	if rows[3][11] == "" {
+		t.Error("stopped_erroring row should carry the old error")
+	}
+
+	tsv, err := RenderDiff(d, "tsv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(tsv, "\n"), "\n")
+	if len(lines) != len(rows) {
+		t.Errorf("TSV has %d lines, want %d", len(lines), len(rows))
+	}
+	for _, line := range lines {
+		if n := strings.Count(line, "\t"); n != len(diffColumns)-1 {
+			t.Errorf("TSV line has %d tabs: %q", n, line)
+		}
+	}
+}
+
+// -- SARIF --------------------------------------------------------------------
+
+func TestRenderSARIF(t *testing.T) {
+	r := sampleReport()
+	r.Baseline = "baseline.json"
+	r.Results[1].Findings[0].Known = true
+	r.Results[2].Suppressed = []models.Finding{{
+		Severity: models.SeverityConsider, Title: "ENUM type found", ObjectName: "public.other",
+		SuppressionReason: "Reviewed",
+	}}
+
+	var log map[string]any
+	if err := json.Unmarshal([]byte(RenderSARIF(r)), &log); err != nil {
+		t.Fatal(err)
+	}
+	if log["version"] != "2.1.0" {
+		t.Errorf("version = %v", log["version"])
+	}
+	run := log["runs"].([]any)[0].(map[string]any)
+
+	rules := run["tool"].(map[string]any)["driver"].(map[string]any)["rules"].([]any)
+	if len(rules) != len(r.Results)-1 {
+		t.Errorf("got %d rules, want one per check that ran (%d)", len(rules), len(r.Results)-1)
+	}
+	wal := rules[0].(map[string]any)
+	if wal["id"] != "wal_level" || wal["shortDescription"].(map[string]any)["text"] != "WAL level check" {
+		t.Errorf("rule = %v", wal)
+	}
+	// The rule's help describes the check; the remediation naming an
+	// object stays on each result.
+	help := wal["help"].(map[string]any)["text"].(string)
+	if !strings.HasPrefix(help, "WAL level check.") || strings.Contains(help, "ALTER SYSTEM") {
+		t.Errorf("rule help = %q", help)
+	}
+	if wal["helpUri"] != checksReferenceURI+"#wal_level" {
+		t.Errorf("rule helpUri = %v", wal["helpUri"])
+	}
+
+	results := run["results"].([]any)
+	levels := make(map[string]string)
+	for _, res := range results {
+		m := res.(map[string]any)
+		levels[m["ruleId"].(string)+"/"+m["level"].(string)] = m["baselineState"].(string)
+	}
+	want := map[string]string{
+		"wal_level/error":      "new",
+		"primary_keys/warning": "unchanged",
+		"enum_types/note":      "new",
+		"pg_version/none":      "new",
+	}
+	for k, state := range want {
+		if levels[k] != state {
+			t.Errorf("result %s: baselineState %q, want %q", k, levels[k], state)
+		}
+	}
+	suppressed := results[3].(map[string]any)
+	if s, ok := suppressed["suppressions"].([]any); !ok || s[0].(map[string]any)["justification"] != "Reviewed" {
+		t.Errorf("suppressed result = %v", suppressed)
+	}
+
+	notes := run["invocations"].([]any)[0].(map[string]any)["toolExecutionNotifications"].([]any)
+	if len(notes) != 1 || notes[0].(map[string]any)["descriptor"].(map[string]any)["id"] != "hba_config" {
+		t.Errorf("notifications = %v", notes)
+	}
+}
+
+func TestRenderSARIFAnalyzeLocations(t *testing.T) {
+	r := sampleReport()
+	r.ScanMode = "analyze"
+	r.Host = "dumps/schema.sql"
+	r.Results[1].Findings[0].Line = 42
+
+	var log map[string]any
+	if err := json.Unmarshal([]byte(RenderSARIF(r)), &log); err != nil {
+		t.Fatal(err)
+	}
+	res := log["runs"].([]any)[0].(map[string]any)["results"].([]any)[1].(map[string]any)
+	loc := res["locations"].([]any)[0].(map[string]any)
+	phys := loc["physicalLocation"].(map[string]any)
+	if phys["artifactLocation"].(map[string]any)["uri"] != "dumps/schema.sql" {
+		t.Errorf("uri = %v", phys["artifactLocation"])
+	}
+	if phys["region"].(map[string]any)["startLine"] != float64(42) {
+		t.Errorf("region = %v", phys["region"])
+	}
+	if loc["logicalLocations"].([]any)[0].(map[string]any)["fullyQualifiedName"] != "public.orders" {
+		t.Errorf("logical location = %v", loc["logicalLocations"])
+	}
+}
+
+// -- JUnit --------------------------------------------------------------------
+
+func TestRenderJUnit(t *testing.T) {
+	r := sampleReport()
+	r.Results[1].Findings = append(r.Results[1].Findings, models.Finding{
+		Severity: models.SeverityWarning, Title: "Known table", ObjectName: "public.old", Known: true,
+	})
+
+	out := RenderJUnit(r)
+	if !strings.HasPrefix(out, xml.Header) {
+		t.Error("JUnit output missing XML header")
+	}
+	var doc junitTestSuites
+	if err := xml.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.Tests != 7 || doc.Failures != 2 || doc.Errors != 1 || doc.Skipped != 1 {
+		t.Errorf("totals tests=%d failures=%d errors=%d skipped=%d", doc.Tests, doc.Failures, doc.Errors, doc.Skipped)
+	}
+
+	var names []string
+	cases := make(map[string]junitTestCase)
+	for _, s := range doc.Suites {
+		names = append(names, s.Name)
+		for _, c := range s.Cases {
+			cases[c.Name] = c
+		}
+	}
+	if want := []string{"replication", "schema", "config", "monitor"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("suites = %v, want %v", names, want)
+	}
+
+	wal := cases["wal_level"]
+	if wal.Failure == nil || wal.Failure.Type != "CRITICAL" || wal.Classname != "mm-ready.replication" {
+		t.Fatalf("wal_level = %+v", wal)
+	}
+	for _, want := range []string{"wal_level is not 'logical'", "Current value: replica", "Remediation: ALTER SYSTEM SET wal_level = 'logical';"} {
+		if !strings.Contains(wal.Failure.Body, want) {
+			t.Errorf("failure body missing %q", want)
+		}
+	}
+	pk := cases["primary_keys"]
+	if pk.Failure == nil || strings.Contains(pk.Failure.Body, "Known table") {
+		t.Errorf("primary_keys failure = %+v, want only the new finding", pk.Failure)
+	}
+	if pk.SystemOut == nil || !strings.Contains(pk.SystemOut.Body, "Known table (known)") {
+		t.Errorf("primary_keys system-out = %+v", pk.SystemOut)
+	}
+	if c := cases["enum_types"]; c.Failure != nil || c.SystemOut == nil {
+		t.Errorf("CONSIDER finding should not fail: %+v", c)
+	}
+	if c := cases["hba_config"]; c.Error == nil || !strings.Contains(c.Error.Message, "PermissionError") {
+		t.Errorf("hba_config = %+v, want an error", c)
+	}
+	if c := cases["pgstat_observation"]; c.Skipped == nil || c.Skipped.Message != "pg_stat_statements not available" {
+		t.Errorf("pgstat_observation = %+v, want skipped", c)
+	}
+}
+
+// -- CSV / TSV ----------------------------------------------------------------
+
+func TestRenderCSV(t *testing.T) {
+	r := sampleReport()
+	r.Results[1].Findings[0].Metadata = map[string]any{"columns": []string{"a", "b"}, "rows": int64(12)}
+	r.Results[2].Findings[0].Metadata = map[string]any{"label_count": 3, "stats": map[string]any{"ratio": 0.5}}
+	r.Results[2].Findings[0].Remediation = "Use \"ALTER TYPE\", carefully."
+
+	rows, err := csv.NewReader(strings.NewReader(RenderCSV(r))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantHeader := []string{
+		"severity", "category", "check", "schema", "table", "object", "title", "remediation", "fingerprint",
+		"metadata.columns", "metadata.label_count", "metadata.rows", "metadata.stats.ratio",
+	}
+	if !reflect.DeepEqual(rows[0], wantHeader) {
+		t.Errorf("header = %v", rows[0])
+	}
+	if len(rows) != 5 {
+		t.Fatalf("got %d rows, want header + 4 findings", len(rows))
+	}
+	pk := rows[2]
+	if pk[0] != "WARNING" || pk[2] != "primary_keys" || pk[3] != "public" || pk[4] != "orders" || pk[5] != "public.orders" {
+		t.Errorf("primary_keys row = %v", pk)
+	}
+	if pk[9] != "a; b" || pk[11] != "12" || pk[10] != "" {
+		t.Errorf("primary_keys metadata = %v", pk[9:])
+	}
+	enum := rows[3]
+	if enum[7] != "Use \"ALTER TYPE\", carefully." || enum[10] != "3" || enum[12] != "0.5" {
+		t.Errorf("enum_types row = %v", enum)
+	}
+	if wal := rows[1]; wal[3] != "" || wal[4] != "" || wal[8] != "0123456789abcdef" {
+		t.Errorf("wal_level row = %v", wal)
+	}
+}
+
+func TestRenderTSV(t *testing.T) {
+	r := sampleReport()
+	r.Results[0].Findings[0].Remediation = "line one\nline\ttwo"
+
+	lines := strings.Split(strings.TrimSuffix(RenderTSV(r), "\n"), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("got %d lines, want header + 4 findings", len(lines))
+	}
+	fields := strings.Split(lines[1], "\t")
+	if len(fields) != len(csvColumns) || fields[7] != "line one line two" {
+		t.Errorf("wal_level fields = %q", fields)
+	}
+}
+
+func TestSplitObject(t *testing.T) {
+	tests := []struct {
+		category, object, schema, table string
+	}{
+		{"schema", "public.orders", "public", "orders"},
+		{"schema", "public.orders.id", "public", "orders"},
+		{"replication", "spock.subscription", "spock", "subscription"},
+		{"config", "spock.conflict_resolution", "", ""},
+		{"replication", "pg_hba.conf", "", ""},
+		{"extensions", "(extensions)", "", ""},
+		{"schema", "wal_level", "", ""},
+	}
+	for _, tt := range tests {
+		s, tbl := splitObject(tt.category, tt.object)
+		if s != tt.schema || tbl != tt.table {
+			t.Errorf("splitObject(%q, %q) = %q, %q", tt.category, tt.object, s, tbl)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package reporter
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"github.com/pgEdge/mm-ready-go/internal/models"
+)
+
+// sarifSchema is the JSON schema URI for SARIF 2.1.0.
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	// Tool describes mm-ready-go and its rules, one per check.
+	Tool sarifTool `json:"tool"`
+	// Invocations records whether the run completed and any check errors.
+	Invocations []sarifInvocation `json:"invocations"`
+	// Results holds one result per finding.
+	Results []sarifResult `json:"results"`
+	// Properties holds the scan metadata.
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	// ID is the check name.
+	ID string `json:"id"`
+	// ShortDescription is the check description.
+	ShortDescription sarifMessage `json:"shortDescription"`
+	// Help describes the check without naming any object; each result
+	// carries the remediation for its own object.
+	Help *sarifMessage `json:"help,omitempty"`
+	// HelpURI links to the check's entry in the check reference.
+	HelpURI string `json:"helpUri,omitempty"`
+	// DefaultConfiguration carries the level of the check's most severe finding.
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+	// Properties carries the check category.
+	Properties map[string]any `json:"properties"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifInvocation struct {
+	ExecutionSuccessful        bool                `json:"executionSuccessful"`
+	ToolExecutionNotifications []sarifNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+type sarifNotification struct {
+	Level      string             `json:"level"`
+	Message    sarifMessage       `json:"message"`
+	Descriptor sarifDescriptorRef `json:"descriptor"`
+}
+
+type sarifDescriptorRef struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID              string             `json:"ruleId"`
+	RuleIndex           int                `json:"ruleIndex"`
+	Level               string             `json:"level"`
+	Message             sarifMessage       `json:"message"`
+	Locations           []sarifLocation    `json:"locations,omitempty"`
+	PartialFingerprints map[string]string  `json:"partialFingerprints,omitempty"`
+	BaselineState       string             `json:"baselineState,omitempty"`
+	Suppressions        []sarifSuppression `json:"suppressions,omitempty"`
+	Properties          map[string]any     `json:"properties"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification"`
+}
+
+// checksReferenceURI is the check reference that rules link to, one heading
+// per check.
+const checksReferenceURI = "https://github.com/pgEdge/mm-ready-go/blob/main/docs/checks-reference.md"
+
+// sarifLevel maps a severity to a SARIF result level.
+func sarifLevel(sev models.Severity) string {
+	switch sev {
+	case models.SeverityCritical:
+		return "error"
+	case models.SeverityWarning:
+		return "warning"
+	case models.SeverityConsider:
+		return "note"
+	default:
+		return "none"
+	}
+}
+
+// RenderSARIF renders the report as a SARIF 2.1.0 log for code-scanning
+// tools. Each check that ran is a rule and each finding a result. For
+// analyze reports, results point at the line of the dump file where their
+// object is defined. Suppressed findings are included with their reason,
+// and when a baseline was applied each result's baselineState says whether
+// it is new.
+func RenderSARIF(report *models.ScanReport) string {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "mm-ready-go",
+			Version:        "0.1.0",
+			InformationURI: "https://github.com/pgEdge/mm-ready-go",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+		Properties: map[string]any{
+			"database":     report.Database,
+			"host":         report.Host,
+			"pg_version":   report.PGVersion,
+			"spock_target": report.SpockTarget,
+			"scan_mode":    report.ScanMode,
+		},
+	}
+	invocation := sarifInvocation{ExecutionSuccessful: !report.Partial}
+
+	// In analyze mode the report's host is the dump file.
+	var artifact string
+	if report.ScanMode == "analyze" {
+		artifact = filepath.ToSlash(report.Host)
+	}
+
+	for _, r := range report.Results {
+		if r.Skipped {
+			continue
+		}
+		if r.Error != "" {
+			invocation.ToolExecutionNotifications = append(invocation.ToolExecutionNotifications, sarifNotification{
+				Level:      "error",
+				Message:    sarifMessage{Text: r.Error},
+				Descriptor: sarifDescriptorRef{ID: r.CheckName},
+			})
+		}
+
+		ruleIndex := len(run.Tool.Driver.Rules)
+		rule := newSARIFRule(r)
+		worst := models.SeverityInfo
+		all := append(append([]models.Finding(nil), r.Findings...), r.Suppressed...)
+		for _, f := range all {
+			if f.Severity < worst {
+				worst = f.Severity
+			}
+		}
+		if len(all) > 0 {
+			rule.DefaultConfiguration.Level = sarifLevel(worst)
+		}
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, rule)
+
+		for _, f := range all {
+			run.Results = append(run.Results, sarifFinding(f, r.CheckName, ruleIndex, artifact, report.Baseline != ""))
+		}
+	}
+
+	run.Invocations = []sarifInvocation{invocation}
+	out, _ := json.MarshalIndent(sarifLog{Schema: sarifSchema, Version: "2.1.0", Runs: []sarifRun{run}}, "", "  ")
+	return string(out)
+}
+
+// newSARIFRule returns the rule for the check of r, at level none until a
+// finding raises it.
+func newSARIFRule(r models.CheckResult) sarifRule {
+	return sarifRule{
+		ID:               r.CheckName,
+		ShortDescription: sarifMessage{Text: r.Description},
+		// Findings name their objects, so the rule's help stays generic and
+		// stable between runs.
+		Help: &sarifMessage{Text: r.Description + ". The remediation for each " +
+			"object is in its result's remediation property; the check " +
+			"reference describes the check in full."},
+		HelpURI:              checksReferenceURI + "#" + r.CheckName,
+		DefaultConfiguration: sarifConfiguration{Level: "none"},
+		Properties:           map[string]any{"category": r.Category},
+	}
+}
+
+// sarifFinding converts a finding to a SARIF result.
+func sarifFinding(f models.Finding, checkName string, ruleIndex int, artifact string, baseline bool) sarifResult {
+	text := f.Title
+	if f.Detail != "" {
+		text += "\n\n" + f.Detail
+	}
+	res := sarifResult{
+		RuleID:    checkName,
+		RuleIndex: ruleIndex,
+		Level:     sarifLevel(f.Severity),
+		Message:   sarifMessage{Text: text},
+		Properties: map[string]any{
+			"severity": f.Severity.String(),
+		},
+	}
+	if f.Remediation != "" {
+		res.Properties["remediation"] = f.Remediation
+	}
+	if f.Fingerprint != "" {
+		res.PartialFingerprints = map[string]string{"mmReadyFingerprint/v1": f.Fingerprint}
+	}
+
+	var loc sarifLocation
+	if artifact != "" && f.Line > 0 {
+		loc.PhysicalLocation = &sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: artifact},
+			Region:           sarifRegion{StartLine: f.Line},
+		}
+	}
+	if f.ObjectName != "" && !strings.HasPrefix(f.ObjectName, "(") {
+		loc.LogicalLocations = []sarifLogicalLocation{{FullyQualifiedName: f.ObjectName}}
+	}
+	if loc.PhysicalLocation != nil || loc.LogicalLocations != nil {
+		res.Locations = []sarifLocation{loc}
+	}
+
+	if baseline {
+		res.BaselineState = "new"
+		if f.Known {
+			res.BaselineState = "unchanged"
+		}
+	}
+	if f.SuppressionReason != "" {
+		res.Suppressions = []sarifSuppression{{Kind: "external", Justification: f.SuppressionReason}}
+	}
+	return res
+}
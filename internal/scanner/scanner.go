@@ -3,12 +3,16 @@ package scanner
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/pgEdge/mm-ready-go/internal/check"
+	"github.com/pgEdge/mm-ready-go/internal/config"
 	"github.com/pgEdge/mm-ready-go/internal/connection"
 	"github.com/pgEdge/mm-ready-go/internal/models"
 )
@@ -31,71 +35,319 @@ type Options struct {
 	Mode string
 	// Verbose enables detailed progress output.
 	Verbose bool
+	// Parallel is the number of checks RunScanPool runs concurrently.
+	Parallel int
+	// Timeouts holds the time limit for each check. Zero means no limit.
+	Timeouts config.TimeoutConfig
 }
 
 // RunScan executes all discovered checks against the database and returns a ScanReport.
+//
+// All checks run inside a single read-only REPEATABLE READ transaction, so
+// they see one consistent snapshot; the snapshot ID and start LSN are
+// recorded on the report. Each check is isolated by a savepoint.
+//
+// Each check runs under its own time limit; a check that exceeds it is
+// recorded as timed out and the scan continues. If ctx is cancelled, the
+// scan stops and the report holds only the checks that completed, with
+// Partial set.
 func RunScan(ctx context.Context, conn *pgx.Conn, opts Options) (*models.ScanReport, error) {
-	mode := opts.Mode
-	if mode == "" {
-		mode = "scan"
+	pgVersion, err := connection.GetPGVersion(ctx, conn)
+	if err != nil {
+		return nil, fmt.Errorf("get pg version: %w", err)
 	}
 
-	modeLabel := "Readiness scan"
-	if mode == "audit" {
-		modeLabel = "Spock audit"
-	}
+	report := newReport(opts, pgVersion)
+	checks := check.GetChecks(report.ScanMode, opts.Categories, opts.Exclude, opts.IncludeOnly)
+	total := len(checks)
 
-	pgVersion, err := connection.GetPGVersion(ctx, conn)
+	env, err := check.LoadEnvironment(ctx, conn, checks)
 	if err != nil {
-		return nil, fmt.Errorf("get pg version: %w", err)
+		return nil, fmt.Errorf("evaluate prerequisites: %w", err)
 	}
 
-	report := &models.ScanReport{
-		Database:    opts.DBName,
-		Host:        opts.Host,
-		Port:        opts.Port,
-		Timestamp:   time.Now().UTC(),
-		PGVersion:   pgVersion,
-		SpockTarget: "5.0",
-		ScanMode:    mode,
+	tx, err := beginSnapshot(ctx, conn, report, false, opts.Verbose)
+	if err != nil {
+		return nil, err
 	}
+	defer func() { _ = tx.Rollback(context.Background()) }()
 
-	checks := check.GetChecks(mode, opts.Categories, opts.Exclude, opts.IncludeOnly)
-	total := len(checks)
+	ctx = preloadCatalog(ctx, conn, checks, opts)
 
 	if opts.Verbose {
-		fmt.Fprintf(os.Stderr, "%s: running %d checks against %s...\n", modeLabel, total, opts.DBName)
+		fmt.Fprintf(os.Stderr, "%s: running %d checks against %s...\n", modeLabel(report.ScanMode), total, opts.DBName)
+		printSnapshot(report)
 	}
 
 	for i, c := range checks {
+		if ctx.Err() != nil {
+			report.Partial = true
+			break
+		}
 		if opts.Verbose {
 			fmt.Fprintf(os.Stderr, "  [%d/%d] %s/%s: %s\n", i+1, total, c.Category(), c.Name(), c.Description())
 		}
 
-		result := models.CheckResult{
-			CheckName:   c.Name(),
-			Category:    c.Category(),
-			Description: c.Description(),
-		}
-
-		findings, runErr := c.Run(ctx, conn)
-		if runErr != nil {
-			result.Error = fmt.Sprintf("%v", runErr)
+		if reason := env.SkipReason(c); reason != "" {
 			if opts.Verbose {
-				fmt.Fprintf(os.Stderr, "    ERROR: %s\n", result.Error)
+				fmt.Fprintf(os.Stderr, "    SKIPPED: %s\n", reason)
 			}
-		} else {
-			result.Findings = findings
+			report.Results = append(report.Results, skippedResult(c, reason))
+			continue
+		}
+
+		result := runInSavepoint(ctx, c, conn, opts)
+		if ctx.Err() != nil {
+			// Interrupted mid-check: keep only what completed.
+			report.Partial = true
+			break
+		}
+		if opts.Verbose && result.Error != "" {
+			fmt.Fprintf(os.Stderr, "    ERROR: %s\n", result.Error)
 		}
 
 		report.Results = append(report.Results, result)
 	}
 
+	printDone(report, opts)
+	return report, nil
+}
+
+// RunScanPool executes all discovered checks concurrently, each on its own
+// connection acquired from pool. Results are returned in the same
+// (category, name) order as RunScan.
+//
+// A coordinating connection holds a read-only REPEATABLE READ transaction
+// for the whole scan and exports its snapshot; every check imports it with
+// SET TRANSACTION SNAPSHOT so all checks see the same database state. The
+// pool therefore needs one connection more than opts.Parallel.
+//
+// If ctx is cancelled, no further checks are started and the report holds
+// only the checks that completed, with Partial set.
+func RunScanPool(ctx context.Context, pool *pgxpool.Pool, opts Options) (*models.ScanReport, error) {
+	var pgVersion string
+	err := pool.AcquireFunc(ctx, func(pc *pgxpool.Conn) error {
+		var verErr error
+		pgVersion, verErr = connection.GetPGVersion(ctx, pc.Conn())
+		return verErr
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get pg version: %w", err)
+	}
+
+	report := newReport(opts, pgVersion)
+	checks := check.GetChecks(report.ScanMode, opts.Categories, opts.Exclude, opts.IncludeOnly)
+	total := len(checks)
+
+	coord, err := pool.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquire connection: %w", err)
+	}
+	defer coord.Release()
+
+	env, err := check.LoadEnvironment(ctx, coord.Conn(), checks)
+	if err != nil {
+		return nil, fmt.Errorf("evaluate prerequisites: %w", err)
+	}
+
+	tx, err := beginSnapshot(ctx, coord.Conn(), report, true, opts.Verbose)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(context.Background()) }()
+
+	ctx = preloadCatalog(ctx, coord.Conn(), checks, opts)
+
+	// Checks with unmet prerequisites are recorded up front and never queued.
+	results := make([]models.CheckResult, total)
+	var runnable []int
+	for i, c := range checks {
+		if reason := env.SkipReason(c); reason != "" {
+			results[i] = skippedResult(c, reason)
+		} else {
+			runnable = append(runnable, i)
+		}
+	}
+
+	workers := opts.Parallel
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(runnable) {
+		workers = len(runnable)
+	}
+
+	if opts.Verbose {
+		fmt.Fprintf(os.Stderr, "%s: running %d checks against %s with %d workers...\n",
+			modeLabel(report.ScanMode), len(runnable), opts.DBName, workers)
+		if skipped := total - len(runnable); skipped > 0 {
+			fmt.Fprintf(os.Stderr, "Skipping %d checks with unmet prerequisites.\n", skipped)
+		}
+		printSnapshot(report)
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	done := 0
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
+				c := checks[i]
+				start := time.Now()
+				result := runPooledCheck(ctx, c, pool, report.SnapshotID, opts)
+				if ctx.Err() != nil {
+					continue
+				}
+				results[i] = result
+
+				if opts.Verbose {
+					// Progress lines are written whole, in completion order,
+					// so concurrent checks never interleave their output.
+					mu.Lock()
+					done++
+					fmt.Fprintf(os.Stderr, "  [%d/%d] %s/%s: %s (%s)\n", done, len(runnable),
+						c.Category(), c.Name(), c.Description(), time.Since(start).Round(time.Millisecond))
+					if result.Error != "" {
+						fmt.Fprintf(os.Stderr, "    ERROR: %s\n", result.Error)
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for _, i := range runnable {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, r := range results {
+		if r.CheckName == "" {
+			report.Partial = true
+			continue
+		}
+		report.Results = append(report.Results, r)
+	}
+
+	printDone(report, opts)
+	return report, nil
+}
+
+// RunCheck runs a single check on conn and wraps the outcome in a CheckResult.
+// A positive timeout bounds the check's run time; exceeding it cancels the
+// running query and marks the result as timed out. A panic inside the check
+// is recovered and recorded as an error, so one bad check cannot stop a run.
+// Every finding is given its fingerprint.
+func RunCheck(ctx context.Context, c check.Check, conn *pgx.Conn, timeout time.Duration) (result models.CheckResult) {
+	result = models.CheckResult{
+		CheckName:   c.Name(),
+		Category:    c.Category(),
+		Description: c.Description(),
+		Effort:      check.EffortOf(c),
+	}
+
+	checkCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		checkCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			result.Findings = nil
+			result.Error = fmt.Sprintf("panic: %v", r)
+			result.ErrorKind = models.ErrorKindPanic
+		}
+	}()
+
+	findings, err := c.Run(checkCtx, conn)
+	switch {
+	case errors.Is(checkCtx.Err(), context.DeadlineExceeded):
+		result.TimedOut = true
+		result.Error = fmt.Sprintf("timed out after %s", timeout)
+		result.ErrorKind = models.ErrorKindTimeout
+	case err != nil:
+		result.Error = fmt.Sprintf("%v", err)
+		result.ErrorKind = check.ClassifyError(err)
+	default:
+		result.Findings = findings
+		result.SetFingerprints()
+	}
+	return result
+}
+
+// runPooledCheck adapts a check to the pool: it acquires a dedicated
+// connection, runs the check on it inside the shared snapshot, and releases
+// it afterwards.
+func runPooledCheck(ctx context.Context, c check.Check, pool *pgxpool.Pool, snapshotID string, opts Options) models.CheckResult {
+	pc, err := pool.Acquire(ctx)
+	if err != nil {
+		return errorResult(c, fmt.Errorf("acquire connection: %w", err))
+	}
+	defer pc.Release()
+
+	return runInImportedSnapshot(ctx, c, pc.Conn(), snapshotID, opts)
+}
+
+// skippedResult records that c was not run because of reason.
+func skippedResult(c check.Check, reason string) models.CheckResult {
+	return models.CheckResult{
+		CheckName:   c.Name(),
+		Category:    c.Category(),
+		Description: c.Description(),
+		Skipped:     true,
+		SkipReason:  reason,
+	}
+}
+
+func newReport(opts Options, pgVersion string) *models.ScanReport {
+	mode := opts.Mode
+	if mode == "" {
+		mode = "scan"
+	}
+
+	return &models.ScanReport{
+		Database:    opts.DBName,
+		Host:        opts.Host,
+		Port:        opts.Port,
+		Timestamp:   time.Now().UTC(),
+		PGVersion:   pgVersion,
+		SpockTarget: "5.0",
+		ScanMode:    mode,
+	}
+}
+
+func modeLabel(mode string) string {
+	if mode == "audit" {
+		return "Spock audit"
+	}
+	return "Readiness scan"
+}
+
+func printSnapshot(report *models.ScanReport) {
+	if report.SnapshotID != "" {
+		fmt.Fprintf(os.Stderr, "Snapshot %s at LSN %s\n", report.SnapshotID, report.StartLSN)
+	} else if report.StartLSN != "" {
+		fmt.Fprintf(os.Stderr, "Snapshot at LSN %s\n", report.StartLSN)
+	}
+}
+
+func printDone(report *models.ScanReport, opts Options) {
+	if opts.Verbose && report.Partial {
+		fmt.Fprintf(os.Stderr, "Interrupted: partial report with %d completed checks.\n", len(report.Results))
+	}
 	if opts.Verbose {
 		fmt.Fprintf(os.Stderr, "Done. %d critical, %d warnings, %d consider, %d info.\n",
 			report.CriticalCount(), report.WarningCount(),
 			report.ConsiderCount(), report.InfoCount())
 	}
-
-	return report, nil
 }
@@ -0,0 +1,143 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pgEdge/mm-ready-go/internal/catalog"
+	"github.com/pgEdge/mm-ready-go/internal/check"
+	"github.com/pgEdge/mm-ready-go/internal/models"
+)
+
+// snapshotTxOptions opens the read-only REPEATABLE READ transaction that
+// pins every check in a scan to the same view of the database.
+var snapshotTxOptions = pgx.TxOptions{
+	IsoLevel:   pgx.RepeatableRead,
+	AccessMode: pgx.ReadOnly,
+}
+
+// checkSavepoint isolates each check inside the shared snapshot transaction.
+const checkSavepoint = "mm_ready_check"
+
+// beginSnapshot opens the scan's snapshot transaction on conn, exports its
+// snapshot, and records the snapshot ID and start LSN on report.
+//
+// Exporting is best-effort: if pg_export_snapshot fails, the transaction is
+// restarted without it and report.SnapshotID stays empty. shared reports
+// whether other connections were meant to import the snapshot, in which case
+// the failure is always reported on stderr.
+func beginSnapshot(ctx context.Context, conn *pgx.Conn, report *models.ScanReport, shared, verbose bool) (pgx.Tx, error) {
+	tx, err := conn.BeginTx(ctx, snapshotTxOptions)
+	if err != nil {
+		return nil, fmt.Errorf("begin snapshot transaction: %w", err)
+	}
+
+	// pg_export_snapshot must be the first statement so that the exported
+	// snapshot is the one the transaction keeps.
+	var snapshotID string
+	if err := tx.QueryRow(ctx, "SELECT pg_export_snapshot()").Scan(&snapshotID); err != nil {
+		if shared {
+			fmt.Fprintf(os.Stderr, "Warning: could not export snapshot (%v); parallel checks will not share it.\n", err)
+		} else if verbose {
+			fmt.Fprintf(os.Stderr, "Could not export snapshot: %v\n", err)
+		}
+		_ = tx.Rollback(ctx)
+		tx, err = conn.BeginTx(ctx, snapshotTxOptions)
+		if err != nil {
+			return nil, fmt.Errorf("begin snapshot transaction: %w", err)
+		}
+		snapshotID = ""
+	}
+
+	var lsn *string
+	err = tx.QueryRow(ctx, `
+		SELECT CASE WHEN pg_is_in_recovery()
+		            THEN pg_last_wal_replay_lsn()
+		            ELSE pg_current_wal_lsn()
+		       END::text
+	`).Scan(&lsn)
+	if err != nil {
+		_ = tx.Rollback(ctx)
+		return nil, fmt.Errorf("get start lsn: %w", err)
+	}
+
+	report.SnapshotID = snapshotID
+	if lsn != nil {
+		report.StartLSN = *lsn
+	}
+	return tx, nil
+}
+
+// preloadCatalog loads the catalog shared by the structural checks among
+// checks on conn, inside the snapshot transaction, before any check runs. The
+// load has its own time limit, the default check timeout, and is wrapped in a
+// savepoint so that a failed load leaves the transaction usable.
+func preloadCatalog(ctx context.Context, conn *pgx.Conn, checks []check.Check, opts Options) context.Context {
+	if !check.UsesCatalog(checks) {
+		return ctx
+	}
+	if opts.Verbose {
+		fmt.Fprintln(os.Stderr, "Loading schema catalog...")
+	}
+	_, spErr := conn.Exec(ctx, "SAVEPOINT "+checkSavepoint)
+	ctx = catalog.Preload(ctx, conn, opts.Timeouts.Default)
+	if spErr == nil {
+		if _, err := conn.Exec(ctx, "ROLLBACK TO SAVEPOINT "+checkSavepoint); err == nil {
+			_, _ = conn.Exec(ctx, "RELEASE SAVEPOINT "+checkSavepoint)
+		}
+	}
+	return ctx
+}
+
+// runInSavepoint runs c on conn, which is inside the snapshot transaction,
+// wrapped in a savepoint. The savepoint is always rolled back: checks are
+// read-only, and rolling back also clears the aborted state left by a check
+// that swallowed a failed query, so the next check can still run.
+func runInSavepoint(ctx context.Context, c check.Check, conn *pgx.Conn, opts Options) models.CheckResult {
+	if _, err := conn.Exec(ctx, "SAVEPOINT "+checkSavepoint); err != nil {
+		return errorResult(c, fmt.Errorf("create savepoint: %w", err))
+	}
+
+	result := RunCheck(ctx, c, conn, opts.Timeouts.For(c.Name()))
+
+	if _, err := conn.Exec(ctx, "ROLLBACK TO SAVEPOINT "+checkSavepoint); err == nil {
+		_, _ = conn.Exec(ctx, "RELEASE SAVEPOINT "+checkSavepoint)
+	}
+	return result
+}
+
+// runInImportedSnapshot runs c on conn inside its own read-only REPEATABLE
+// READ transaction that imports snapshotID, so that concurrent checks all see
+// the same database state. An empty snapshotID leaves the transaction with
+// its own snapshot.
+func runInImportedSnapshot(ctx context.Context, c check.Check, conn *pgx.Conn, snapshotID string, opts Options) models.CheckResult {
+	tx, err := conn.BeginTx(ctx, snapshotTxOptions)
+	if err != nil {
+		return errorResult(c, fmt.Errorf("begin snapshot transaction: %w", err))
+	}
+	defer func() { _ = tx.Rollback(context.Background()) }()
+
+	if snapshotID != "" {
+		// SET TRANSACTION SNAPSHOT does not accept bind parameters.
+		quoted := "'" + strings.ReplaceAll(snapshotID, "'", "''") + "'"
+		if _, err := tx.Exec(ctx, "SET TRANSACTION SNAPSHOT "+quoted); err != nil {
+			return errorResult(c, fmt.Errorf("import snapshot %s: %w", snapshotID, err))
+		}
+	}
+
+	return RunCheck(ctx, c, conn, opts.Timeouts.For(c.Name()))
+}
+
+// errorResult records err as the outcome of c.
+func errorResult(c check.Check, err error) models.CheckResult {
+	return models.CheckResult{
+		CheckName:   c.Name(),
+		Category:    c.Category(),
+		Description: c.Description(),
+		Error:       fmt.Sprintf("%v", err),
+		ErrorKind:   check.ClassifyError(err),
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/pgEdge/mm-ready-go/internal/catalog"
 	"github.com/pgEdge/mm-ready-go/internal/connection"
 	"github.com/pgEdge/mm-ready-go/internal/reporter"
 	"github.com/pgEdge/mm-ready-go/internal/scanner"
@@ -231,3 +232,142 @@ func TestHTMLRendersFromScan(t *testing.T) {
 		t.Error("HTML output too short")
 	}
 }
+
+func TestParallelScanMatchesSequential(t *testing.T) {
+	ctx := context.Background()
+	cfg := connection.Config{
+		Host: "localhost", Port: 5499, DBName: "mmready",
+		User: "postgres", Password: "postgres",
+	}
+	conn, err := connection.Connect(ctx, cfg)
+	if err != nil {
+		t.Skipf("Test database not available: %v", err)
+	}
+	defer conn.Close(ctx)
+
+	pool, err := connection.ConnectPool(ctx, cfg, 5)
+	if err != nil {
+		t.Fatalf("connect pool: %v", err)
+	}
+	defer pool.Close()
+
+	opts := scanner.Options{
+		Host: "localhost", Port: 5499, DBName: "mmready", Mode: "scan", Parallel: 4,
+	}
+	sequential, err := scanner.RunScan(ctx, conn, opts)
+	if err != nil {
+		t.Fatalf("sequential scan failed: %v", err)
+	}
+	parallel, err := scanner.RunScanPool(ctx, pool, opts)
+	if err != nil {
+		t.Fatalf("parallel scan failed: %v", err)
+	}
+
+	if len(parallel.Results) != len(sequential.Results) {
+		t.Fatalf("parallel returned %d results, sequential %d", len(parallel.Results), len(sequential.Results))
+	}
+	for i := range sequential.Results {
+		if parallel.Results[i].CheckName != sequential.Results[i].CheckName {
+			t.Errorf("result %d: parallel %s, sequential %s", i, parallel.Results[i].CheckName, sequential.Results[i].CheckName)
+		}
+	}
+}
+
+func TestScanRecordsSnapshot(t *testing.T) {
+	ctx := context.Background()
+	conn, err := connection.Connect(ctx, connection.Config{
+		Host: "localhost", Port: 5499, DBName: "mmready",
+		User: "postgres", Password: "postgres",
+	})
+	if err != nil {
+		t.Skipf("Test database not available: %v", err)
+	}
+	defer conn.Close(ctx)
+
+	report, err := scanner.RunScan(ctx, conn, scanner.Options{
+		Host: "localhost", Port: 5499, DBName: "mmready", Mode: "scan",
+	})
+	if err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+
+	if report.SnapshotID == "" {
+		t.Error("snapshot_id should not be empty")
+	}
+	if report.StartLSN == "" {
+		t.Error("start_lsn should not be empty")
+	}
+}
+
+func TestAuditSkipsChecksWithoutSpock(t *testing.T) {
+	ctx := context.Background()
+	conn, err := connection.Connect(ctx, connection.Config{
+		Host: "localhost", Port: 5499, DBName: "mmready",
+		User: "postgres", Password: "postgres",
+	})
+	if err != nil {
+		t.Skipf("Test database not available: %v", err)
+	}
+	defer conn.Close(ctx)
+
+	var hasSpock bool
+	if err := conn.QueryRow(ctx, "SELECT to_regclass('spock.subscription') IS NOT NULL").Scan(&hasSpock); err != nil {
+		t.Fatal(err)
+	}
+	if hasSpock {
+		t.Skip("Spock is installed in the test database")
+	}
+
+	report, err := scanner.RunScan(ctx, conn, scanner.Options{
+		Host: "localhost", Port: 5499, DBName: "mmready", Mode: "audit",
+		IncludeOnly: []string{"subscription_health"},
+	})
+	if err != nil {
+		t.Fatalf("audit failed: %v", err)
+	}
+
+	if len(report.Results) != 1 {
+		t.Fatalf("results = %d, want 1", len(report.Results))
+	}
+	r := report.Results[0]
+	if !r.Skipped || r.SkipReason != "relation spock.subscription does not exist" {
+		t.Errorf("skipped = %v, reason = %q", r.Skipped, r.SkipReason)
+	}
+	if len(r.Findings) != 0 {
+		t.Errorf("skipped check should have no findings, got %d", len(r.Findings))
+	}
+}
+
+func TestCatalogLoad(t *testing.T) {
+	ctx := context.Background()
+	conn, err := connection.Connect(ctx, connection.Config{
+		Host: "localhost", Port: 5499, DBName: "mmready",
+		User: "postgres", Password: "postgres",
+	})
+	if err != nil {
+		t.Skipf("Test database not available: %v", err)
+	}
+	defer conn.Close(ctx)
+
+	ctx = catalog.Preload(ctx, conn, 0)
+	first, err := catalog.Get(ctx, conn)
+	if err != nil {
+		t.Fatalf("catalog load failed: %v", err)
+	}
+	if len(first.Tables()) == 0 {
+		t.Error("catalog should contain tables")
+	}
+	for _, r := range first.Tables() {
+		if len(r.Columns) == 0 {
+			t.Errorf("table %s has no columns", r.QualifiedName())
+		}
+	}
+
+	second, err := catalog.Get(ctx, conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Error("Get should return the preloaded catalog")
+	}
+}